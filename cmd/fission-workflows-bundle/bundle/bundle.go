@@ -17,12 +17,16 @@ import (
 	"github.com/fission/fission-workflows/pkg/controller"
 	"github.com/fission/fission-workflows/pkg/controller/executor"
 	"github.com/fission/fission-workflows/pkg/controller/expr"
+	"github.com/fission/fission-workflows/pkg/controller/lease"
 	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
 	"github.com/fission/fission-workflows/pkg/fes/backend/nats"
+	"github.com/fission/fission-workflows/pkg/fes/backend/sqlstore"
+	"github.com/fission/fission-workflows/pkg/fes/backend/wal"
 	"github.com/fission/fission-workflows/pkg/fes/cache"
 	"github.com/fission/fission-workflows/pkg/fnenv"
 	"github.com/fission/fission-workflows/pkg/fnenv/fission"
+	"github.com/fission/fission-workflows/pkg/fnenv/mock"
 	"github.com/fission/fission-workflows/pkg/fnenv/native"
 	"github.com/fission/fission-workflows/pkg/fnenv/native/builtin"
 	"github.com/fission/fission-workflows/pkg/fnenv/workflows"
@@ -53,12 +57,17 @@ const (
 	apiGatewayAddress            = ":8080"
 	jaegerTracerServiceName      = "fission.workflows"
 	WorkflowsCacheSize           = 10000
+	TriggersCacheSize            = 10000
 	InvocationsCacheSize         = 100000
+	InvocationSnapshotsSize      = 10000
+	invocationSnapshotInterval   = 100
 	executorMaxParallelism       = 1000
 	executorMaxTaskQueueSize     = 100000
 	workflowStorePollInterval    = time.Minute
 	invocationStorePollInterval  = time.Second
+	triggerStorePollInterval     = time.Second
 	workflowSubscriptionBuffer   = 50
+	triggerSubscriptionBuffer    = 50
 	invocationSubscriptionBuffer = 1000
 )
 
@@ -94,20 +103,37 @@ func (app *App) Close() error {
 
 type Options struct {
 	NATS                 *nats.Config
+	WAL                  *wal.Config
+	SQL                  *sqlstore.Config
+	HA                   *HAOptions
 	Scheduler            scheduler.Policy
+	Concurrency          scheduler.ConcurrencyLimits
+	MaxRuntime           time.Duration // Runtime of invocations without a deadline (default: types.DefaultMaxRuntime)
 	Fission              *FissionOptions
 	FissionProxy         *FissionProxyConfig
 	InternalRuntime      bool
+	MockFixtures         []string
 	InvocationController bool
 	WorkflowController   bool
+	TriggerController    bool
 	AdminAPI             bool
 	WorkflowAPI          bool
 	HTTPGateway          bool
 	InvocationAPI        bool
+	TriggerAPI           bool
 	Metrics              bool
 	Debug                bool
 }
 
+// HAOptions configures the invocation controllers of multiple replicas of the bundle to divide the invocations
+// between them, and to take over the invocations of replicas that stop.
+type HAOptions struct {
+	lease.ShardsConfig
+
+	// Locker is the lock service shared by the replicas. If not set, the leases are stored in the SQL event store.
+	Locker lease.Locker
+}
+
 type FissionOptions struct {
 	ExecutorAddress string
 	ControllerAddr  string
@@ -125,6 +151,7 @@ func Run(ctx context.Context, opts *Options) error {
 		closers: map[string]io.Closer{},
 	}
 	ps := Processes{}
+	maxRuntime := getMaxRuntime(opts.MaxRuntime)
 
 	// See https://github.com/jaegertracing/jaeger-client-go for the env vars to set; defaults to local Jaeger
 	// instance with default ports.
@@ -184,6 +211,7 @@ func Run(ctx context.Context, opts *Options) error {
 	// Event Store
 	//
 	var eventStore fes.Backend
+	var locker lease.Locker
 	if opts.NATS != nil {
 		log.WithFields(log.Fields{
 			"url":           "<redacted>", // Typically includes the password
@@ -195,6 +223,26 @@ func Run(ctx context.Context, opts *Options) error {
 		es = natsBackend
 		esPub = natsBackend
 		eventStore = natsBackend
+	} else if opts.WAL != nil {
+		log.WithFields(log.Fields{
+			"dir":    opts.WAL.Dir,
+			"noSync": opts.WAL.NoSync,
+		}).Infof("Using event store: write-ahead log")
+		walBackend := setupWALEventStore(*opts.WAL)
+		app.RegisterCloser("event-store", walBackend)
+		es = walBackend
+		esPub = walBackend
+		eventStore = walBackend
+	} else if opts.SQL != nil {
+		log.WithFields(log.Fields{
+			"driver": opts.SQL.Driver,
+		}).Infof("Using event store: SQL")
+		sqlBackend := setupSQLEventStore(*opts.SQL)
+		app.RegisterCloser("event-store", sqlBackend)
+		es = sqlBackend
+		esPub = sqlBackend
+		eventStore = sqlBackend
+		locker = sqlBackend
 	} else {
 		log.Info("Using the in-memory event store")
 		memBackend := mem.NewBackend()
@@ -206,6 +254,7 @@ func Run(ctx context.Context, opts *Options) error {
 	// Caches
 	invocationStore := getInvocationStore(app, esPub, eventStore)
 	workflowStore := getWorkflowStore(app, esPub, eventStore)
+	triggerStore := getTriggerStore(app, esPub, eventStore)
 
 	//
 	// Function Runtimes
@@ -214,7 +263,7 @@ func Run(ctx context.Context, opts *Options) error {
 	resolvers := map[string]fnenv.RuntimeResolver{}
 	runtimes := map[string]fnenv.Runtime{}
 	reflectiveRuntime := workflows.NewRuntime(invocationAPI, invocationStore, workflowStore)
-	if opts.InternalRuntime || opts.Fission != nil {
+	if opts.InternalRuntime || opts.Fission != nil || len(opts.MockFixtures) > 0 {
 		log.Infof("Using function runtime: Workflow")
 		runtimes[workflows.Name] = reflectiveRuntime
 	} else {
@@ -237,6 +286,15 @@ func Run(ctx context.Context, opts *Options) error {
 		runtimes["fission"] = fissionFnenv
 		resolvers["fission"] = fissionFnenv
 	}
+	if len(opts.MockFixtures) > 0 {
+		log.WithField("fixtures", opts.MockFixtures).Infof("Using function runtime: Mock")
+		mockRuntime, err := setupMockFunctionRuntime(opts.MockFixtures)
+		if err != nil {
+			log.Fatalf("Failed to set up mock function runtime: %v", err)
+		}
+		runtimes[mock.Name] = mockRuntime
+		resolvers[mock.Name] = mockRuntime
+	}
 
 	//
 	// Scheduler
@@ -248,7 +306,7 @@ func Run(ctx context.Context, opts *Options) error {
 	//
 	if opts.WorkflowController {
 		log.Info("Running workflow controller")
-		workflowCtrl := setupWorkflowController(workflowStore, es, resolvers)
+		workflowCtrl := setupWorkflowController(workflowStore, es, resolvers, workflowStorePollInterval)
 		go workflowCtrl.Run()
 		defer func() {
 			if err := workflowCtrl.Close(); err != nil {
@@ -260,7 +318,15 @@ func Run(ctx context.Context, opts *Options) error {
 	}
 	if opts.InvocationController {
 		log.Info("Running invocation controller")
-		invocationCtrl := setupInvocationController(invocationStore, es, runtimes, resolvers, sched)
+		invocationCtrl := setupInvocationController(invocationStore, es, runtimes, resolvers, sched,
+			opts.Concurrency, maxRuntime)
+		if opts.HA != nil {
+			shards, err := setupInvocationShards(*opts.HA, locker)
+			if err != nil {
+				log.Fatalf("Failed to setup high availability: %v", err)
+			}
+			invocationCtrl.Shard(shards)
+		}
 		go invocationCtrl.Run()
 		defer func() {
 			if err := invocationCtrl.Close(); err != nil {
@@ -270,6 +336,18 @@ func Run(ctx context.Context, opts *Options) error {
 			}
 		}()
 	}
+	if opts.TriggerController {
+		log.Info("Running trigger controller")
+		triggerCtrl := setupTriggerController(triggerStore, workflowStore, es)
+		go triggerCtrl.Run()
+		defer func() {
+			if err := triggerCtrl.Close(); err != nil {
+				log.Errorf("Failed to stop trigger controller: %v", err)
+			} else {
+				log.Info("Stopped trigger controller")
+			}
+		}()
+	}
 
 	//
 	// Fission integration
@@ -288,10 +366,14 @@ func Run(ctx context.Context, opts *Options) error {
 	}
 
 	if opts.InvocationAPI {
-		serveInvocationAPI(grpcServer, es, invocationStore, workflowStore)
+		serveInvocationAPI(grpcServer, es, invocationStore, workflowStore, maxRuntime)
 	}
 
-	if opts.AdminAPI || opts.WorkflowAPI || opts.InvocationAPI {
+	if opts.TriggerAPI {
+		serveTriggerAPI(grpcServer, es, triggerStore, workflowStore)
+	}
+
+	if opts.AdminAPI || opts.WorkflowAPI || opts.InvocationAPI || opts.TriggerAPI {
 		if opts.Metrics {
 			log.Debug("Instrumenting gRPC server with Prometheus metrics")
 			grpc_prometheus.Register(grpcServer)
@@ -319,7 +401,7 @@ func Run(ctx context.Context, opts *Options) error {
 
 		if opts.HTTPGateway {
 
-			var admin, wf, wfi string
+			var admin, wf, wfi, tr string
 			if opts.AdminAPI {
 				admin = gRPCAddress
 			}
@@ -329,7 +411,10 @@ func Run(ctx context.Context, opts *Options) error {
 			if opts.InvocationAPI {
 				wfi = gRPCAddress
 			}
-			serveHTTPGateway(ctx, grpcMux, admin, wf, wfi)
+			if opts.TriggerAPI {
+				tr = gRPCAddress
+			}
+			serveHTTPGateway(ctx, grpcMux, admin, wf, wfi, tr)
 		}
 
 		if opts.Metrics {
@@ -363,8 +448,8 @@ func Run(ctx context.Context, opts *Options) error {
 }
 
 func getWorkflowStore(app *App, eventPub pubsub.Publisher, backend fes.Backend) *store.Workflows {
-	c := setupWorkflowCache(app, eventPub, backend)
-	return store.NewWorkflowsStore(c)
+	c, names := setupWorkflowCache(app, eventPub, backend)
+	return store.NewWorkflowsStore(c).WithNameIndex(names)
 }
 
 func getInvocationStore(app *App, eventPub pubsub.Publisher, backend fes.Backend) *store.Invocations {
@@ -372,6 +457,11 @@ func getInvocationStore(app *App, eventPub pubsub.Publisher, backend fes.Backend
 	return store.NewInvocationStore(c)
 }
 
+func getTriggerStore(app *App, eventPub pubsub.Publisher, backend fes.Backend) *store.Triggers {
+	c := setupTriggerCache(app, eventPub, backend)
+	return store.NewTriggersStore(c)
+}
+
 func setupInternalFunctionRuntime() *native.FunctionEnv {
 	return native.NewFunctionEnv(builtin.DefaultBuiltinFunctions)
 }
@@ -380,6 +470,30 @@ func setupFissionFunctionRuntime(fissionOpts *FissionOptions) *fission.FunctionE
 	return fission.New(fissionOpts.ExecutorAddress, fissionOpts.ControllerAddr, fissionOpts.RouterAddr)
 }
 
+func setupMockFunctionRuntime(fixturePaths []string) (*mock.FixtureRuntime, error) {
+	fixtures, err := mock.LoadFixtures(fixturePaths...)
+	if err != nil {
+		return nil, err
+	}
+	return mock.NewFixtureRuntime(fixtures)
+}
+
+func setupWALEventStore(config wal.Config) *wal.Backend {
+	es, err := wal.NewBackend(config)
+	if err != nil {
+		panic(err)
+	}
+	return es
+}
+
+func setupSQLEventStore(config sqlstore.Config) *sqlstore.Backend {
+	es, err := sqlstore.NewBackend(config)
+	if err != nil {
+		panic(err)
+	}
+	return es
+}
+
 func setupNatsEventStoreClient(config nats.Config) *nats.EventStore {
 	if config.Client == "" {
 		config.Client = util.UID()
@@ -398,6 +512,10 @@ func setupNatsEventStoreClient(config nats.Config) *nats.EventStore {
 	if err != nil {
 		panic(err)
 	}
+	err = es.Watch(fes.Aggregate{Type: types.TypeTrigger})
+	if err != nil {
+		panic(err)
+	}
 	return es
 }
 
@@ -414,23 +532,45 @@ func setupWorkflowInvocationCache(app *App, invocationEventPub pubsub.Publisher,
 		cache.NewLoadingCache(
 			cache.NewLRUCache(InvocationsCacheSize),
 			backend,
-			projector),
+			projector).WithSnapshots(cache.NewLRUSnapshotStore(InvocationSnapshotsSize), invocationSnapshotInterval),
 		projector,
 		sub)
 	app.RegisterCloser("cache-"+name, c)
 	return c
 }
 
-func setupWorkflowCache(app *App, workflowEventPub pubsub.Publisher, backend fes.Backend) *cache.SubscribedCache {
+// setupWorkflowCache returns the workflow cache, along with the index of the workflow names in the cache.
+func setupWorkflowCache(app *App, workflowEventPub pubsub.Publisher,
+	backend fes.Backend) (*cache.SubscribedCache, *cache.IndexedCache) {
 	sub := workflowEventPub.Subscribe(pubsub.SubscriptionOptions{
 		Buffer:       workflowSubscriptionBuffer,
 		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, types.TypeWorkflow),
 	})
 	name := types.TypeWorkflow
 	projector := projectors.NewWorkflow()
+	names := cache.NewIndexedCache(cache.NewLRUCache(WorkflowsCacheSize), store.WorkflowNameIndex)
 	c := cache.NewSubscribedCache(
 		cache.NewLoadingCache(
-			cache.NewLRUCache(WorkflowsCacheSize),
+			names,
+			backend,
+			projector,
+		),
+		projector,
+		sub)
+	app.RegisterCloser("cache-"+name, c)
+	return c, names
+}
+
+func setupTriggerCache(app *App, triggerEventPub pubsub.Publisher, backend fes.Backend) *cache.SubscribedCache {
+	sub := triggerEventPub.Subscribe(pubsub.SubscriptionOptions{
+		Buffer:       triggerSubscriptionBuffer,
+		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, types.TypeTrigger),
+	})
+	name := types.TypeTrigger
+	projector := projectors.NewTrigger()
+	c := cache.NewSubscribedCache(
+		cache.NewLoadingCache(
+			cache.NewLRUCache(TriggersCacheSize),
 			backend,
 			projector,
 		),
@@ -455,15 +595,23 @@ func serveWorkflowAPI(s *grpc.Server, es fes.Backend, resolvers map[string]fnenv
 	log.Infof("Serving workflow gRPC API at %s.", gRPCAddress)
 }
 
-func serveInvocationAPI(s *grpc.Server, es fes.Backend, invocations *store.Invocations, workflows *store.Workflows) {
+func serveInvocationAPI(s *grpc.Server, es fes.Backend, invocations *store.Invocations, workflows *store.Workflows,
+	maxRuntime time.Duration) {
 	invocationAPI := api.NewInvocationAPI(es)
-	invocationServer := apiserver.NewInvocation(invocationAPI, invocations, workflows, es)
+	invocationServer := apiserver.NewInvocation(invocationAPI, invocations, workflows, es, maxRuntime)
 	apiserver.RegisterWorkflowInvocationAPIServer(s, invocationServer)
 	log.Infof("Serving workflow invocation gRPC API at %s.", gRPCAddress)
 }
 
+func serveTriggerAPI(s *grpc.Server, es fes.Backend, triggers *store.Triggers, workflows *store.Workflows) {
+	triggerAPI := api.NewTriggerAPI(es)
+	triggerServer := apiserver.NewTrigger(triggerAPI, triggers, workflows)
+	apiserver.RegisterTriggerAPIServer(s, triggerServer)
+	log.Infof("Serving trigger gRPC API at %s.", gRPCAddress)
+}
+
 func serveHTTPGateway(ctx context.Context, mux *grpcruntime.ServeMux, adminAPIAddr string, workflowAPIAddr string,
-	invocationAPIAddr string) {
+	invocationAPIAddr string, triggerAPIAddr string) {
 	tracer := opentracing.GlobalTracer()
 	opts := []grpc.DialOption{
 		grpc.WithInsecure(),
@@ -492,28 +640,85 @@ func serveHTTPGateway(ctx context.Context, mux *grpcruntime.ServeMux, adminAPIAd
 		if err != nil {
 			panic(err)
 		}
+		err = apiserver.RegisterInvocationWatchHandlerFromEndpoint(ctx, mux, invocationAPIAddr, opts)
+		if err != nil {
+			panic(err)
+		}
 		log.Info("Registered Workflow WorkflowInvocation API HTTP Endpoint")
 	}
+
+	if triggerAPIAddr != "" {
+		err := apiserver.RegisterTriggerAPIHandlerFromEndpoint(ctx, mux, triggerAPIAddr, opts)
+		if err != nil {
+			panic(err)
+		}
+		log.Info("Registered Trigger API HTTP Endpoint")
+	}
 }
 
 func setupInvocationController(invocations *store.Invocations, es fes.Backend,
 	fnRuntimes map[string]fnenv.Runtime, fnResolvers map[string]fnenv.RuntimeResolver,
-	s *scheduler.InvocationScheduler) *controller.InvocationMetaController {
+	s *scheduler.InvocationScheduler, limits scheduler.ConcurrencyLimits,
+	maxRuntime time.Duration) *controller.InvocationMetaController {
 
 	workflowAPI := api.NewWorkflowAPI(es, fnenv.NewMetaResolver(fnResolvers))
 	invocationAPI := api.NewInvocationAPI(es)
 	dynamicAPI := api.NewDynamicApi(workflowAPI, invocationAPI)
 	taskAPI := api.NewTaskAPI(fnRuntimes, es, dynamicAPI)
 	stateStore := expr.NewStore()
-	localExec := executor.NewLocalExecutor(executorMaxParallelism, executorMaxTaskQueueSize)
-	return controller.NewInvocationMetaController(localExec, invocations, invocationAPI, taskAPI, s, stateStore, invocationStorePollInterval)
+	localExec := executor.NewWeightedLocalExecutor(executorMaxParallelism, executorMaxTaskQueueSize,
+		scheduler.PriorityWeights)
+	limiter := scheduler.NewConcurrencyLimiter(limits)
+	return controller.NewInvocationMetaController(localExec, invocations, invocationAPI, taskAPI, s, limiter,
+		maxRuntime, stateStore, invocationStorePollInterval)
+}
+
+func getMaxRuntime(maxRuntime time.Duration) time.Duration {
+	if maxRuntime <= 0 {
+		return types.DefaultMaxRuntime
+	}
+	return maxRuntime
+}
+
+func setupInvocationShards(opts HAOptions, locker lease.Locker) (*lease.Shards, error) {
+	if opts.Locker != nil {
+		locker = opts.Locker
+	}
+	if locker == nil {
+		return nil, errors.New("high availability requires the SQL event store (--sql) to hold the leases of the replicas")
+	}
+	cfg := opts.ShardsConfig
+	cfg.Name = "invocations"
+	if cfg.Holder == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, err
+		}
+		cfg.Holder = hostname
+	}
+	shards := lease.NewShards(locker, cfg)
+	log.WithFields(log.Fields{
+		"holder": shards.Holder,
+		"shards": shards.Count,
+		"ttl":    shards.TTL,
+	}).Info("Dividing the invocations between the replicas")
+	return shards, nil
 }
 
 func setupWorkflowController(store *store.Workflows, es fes.Backend,
-	fnResolvers map[string]fnenv.RuntimeResolver) *controller.WorkflowMetaController {
+	fnResolvers map[string]fnenv.RuntimeResolver, pollInterval time.Duration) *controller.WorkflowMetaController {
 	wfAPI := api.NewWorkflowAPI(es, fnenv.NewMetaResolver(fnResolvers))
 	exec := executor.NewLocalExecutor(10, 1000)
-	return controller.NewWorkflowMetaController(wfAPI, store, exec, workflowStorePollInterval)
+	return controller.NewWorkflowMetaController(wfAPI, store, exec, pollInterval)
+}
+
+func setupTriggerController(triggers *store.Triggers, workflows *store.Workflows,
+	es fes.Backend) *controller.TriggerMetaController {
+	triggerAPI := api.NewTriggerAPI(es)
+	invocationAPI := api.NewInvocationAPI(es)
+	exec := executor.NewLocalExecutor(10, 1000)
+	return controller.NewTriggerMetaController(triggerAPI, invocationAPI, triggers, workflows, exec,
+		triggerStorePollInterval)
 }
 
 func setupMetricsEndpoint(apiMux *http.ServeMux) {
@@ -0,0 +1,122 @@
+package bundle
+
+import (
+	"io"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/apiserver"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/fnenv"
+	"github.com/fission/fission-workflows/pkg/fnenv/http"
+	"github.com/fission/fission-workflows/pkg/fnenv/workflows"
+	"github.com/fission/fission-workflows/pkg/scheduler"
+	log "github.com/sirupsen/logrus"
+)
+
+const localWorkflowStorePollInterval = time.Second
+
+// LocalOptions configures a LocalEngine.
+type LocalOptions struct {
+	// Runtimes and Resolvers contain additional function runtimes, such as mocked functions, by runtime name. These
+	// take precedence over the default runtimes with the same name.
+	Runtimes  map[string]fnenv.Runtime
+	Resolvers map[string]fnenv.RuntimeResolver
+
+	// Scheduler is the scheduling policy used for invocations; defaults to scheduler.DefaultPolicy.
+	Scheduler   scheduler.Policy
+	Concurrency scheduler.ConcurrencyLimits
+
+	// MaxRuntime is the runtime of invocations that do not specify a deadline; defaults to types.DefaultMaxRuntime.
+	MaxRuntime time.Duration
+}
+
+// LocalEngine is a workflow engine that runs in-process, without exposing any APIs over the network. It consists of
+// the same components as Run: an in-memory event store, the workflow and invocation controllers, and the internal,
+// workflow and HTTP function runtimes.
+type LocalEngine struct {
+	Workflows   *apiserver.Workflow
+	Invocations apiserver.WorkflowInvocationAPIServer
+	Resolver    fnenv.Resolver
+
+	app         *App
+	controllers map[string]io.Closer
+}
+
+// NewLocalEngine sets up and starts a LocalEngine. The engine should be closed once it is no longer used.
+func NewLocalEngine(opts *LocalOptions) *LocalEngine {
+	if opts == nil {
+		opts = &LocalOptions{}
+	}
+	policy := opts.Scheduler
+	if policy == nil {
+		policy = scheduler.DefaultPolicy
+	}
+	maxRuntime := getMaxRuntime(opts.MaxRuntime)
+	app := &App{
+		Options: &Options{
+			Scheduler:            policy,
+			Concurrency:          opts.Concurrency,
+			MaxRuntime:           maxRuntime,
+			InternalRuntime:      true,
+			InvocationController: true,
+			WorkflowController:   true,
+		},
+		closers: map[string]io.Closer{},
+	}
+
+	es := mem.NewBackend()
+	invocationStore := getInvocationStore(app, es, es)
+	workflowStore := getWorkflowStore(app, es, es)
+
+	invocationAPI := api.NewInvocationAPI(es)
+	internalRuntime := setupInternalFunctionRuntime()
+	httpRuntime := http.New()
+	runtimes := map[string]fnenv.Runtime{
+		workflows.Name: workflows.NewRuntime(invocationAPI, invocationStore, workflowStore),
+		"internal":     internalRuntime,
+		"http":         httpRuntime,
+		"https":        httpRuntime,
+	}
+	resolvers := map[string]fnenv.RuntimeResolver{
+		"internal": internalRuntime,
+		"http":     httpRuntime,
+		"https":    httpRuntime,
+	}
+	for name, runtime := range opts.Runtimes {
+		runtimes[name] = runtime
+	}
+	for name, resolver := range opts.Resolvers {
+		resolvers[name] = resolver
+	}
+
+	// The controllers start listening for events asynchronously, so workflows that are created right after the
+	// engine has been started might be missed. Polling the workflow store more often ensures that these are picked up.
+	workflowCtrl := setupWorkflowController(workflowStore, es, resolvers, localWorkflowStorePollInterval)
+	go workflowCtrl.Run()
+	invocationCtrl := setupInvocationController(invocationStore, es, runtimes, resolvers, SetupScheduler(policy),
+		opts.Concurrency, maxRuntime)
+	go invocationCtrl.Run()
+
+	resolver := fnenv.NewMetaResolver(resolvers)
+	return &LocalEngine{
+		Workflows:   apiserver.NewWorkflow(api.NewWorkflowAPI(es, resolver), workflowStore, es),
+		Invocations: apiserver.NewInvocation(invocationAPI, invocationStore, workflowStore, es, maxRuntime),
+		Resolver:    resolver,
+		app:         app,
+		controllers: map[string]io.Closer{
+			"workflow controller":   workflowCtrl,
+			"invocation controller": invocationCtrl,
+		},
+	}
+}
+
+// Close stops the controllers and the caches of the engine.
+func (e *LocalEngine) Close() error {
+	for name, ctrl := range e.controllers {
+		if err := ctrl.Close(); err != nil {
+			log.Errorf("Failed to stop %s: %v", name, err)
+		}
+	}
+	return e.app.Close()
+}
@@ -13,6 +13,9 @@ type SchedulerPolicy string
 const (
 	FlagSchedulerPolicy            = "scheduler.policy"
 	FlagSchedulerColdStartDuration = "scheduler.coldstart"
+	FlagConcurrencyWorkflow        = "concurrency.workflow"
+	FlagConcurrencyRuntime         = "concurrency.runtime"
+	FlagConcurrencyFunction        = "concurrency.function"
 )
 
 var schedulerPolicies = map[string]func(time.Duration) scheduler.Policy{
@@ -40,3 +43,31 @@ func SetupScheduler(policy scheduler.Policy) *scheduler.InvocationScheduler {
 	}
 	return scheduler.NewInvocationScheduler(policy)
 }
+
+// ParseConcurrencyLimits parses the default concurrency limits. The runtime and function limits are formatted as
+// <runtime>=<limit> and <fnref>=<limit> respectively.
+func ParseConcurrencyLimits(c *cli.Context) (scheduler.ConcurrencyLimits, error) {
+	limits := scheduler.ConcurrencyLimits{
+		Workflow:  c.Int(FlagConcurrencyWorkflow),
+		Runtimes:  map[string]int{},
+		Functions: map[string]int{},
+	}
+	if limits.Workflow < 0 {
+		return limits, fmt.Errorf("invalid workflow concurrency limit: %d", limits.Workflow)
+	}
+	for _, s := range c.StringSlice(FlagConcurrencyRuntime) {
+		runtime, limit, err := scheduler.ParseConcurrencyLimit(s)
+		if err != nil {
+			return limits, err
+		}
+		limits.Runtimes[runtime] = limit
+	}
+	for _, s := range c.StringSlice(FlagConcurrencyFunction) {
+		fn, limit, err := scheduler.ParseConcurrencyLimit(s)
+		if err != nil {
+			return limits, err
+		}
+		limits.Functions[fn] = limit
+	}
+	return limits, nil
+}
@@ -2,16 +2,23 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"sort"
+	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/blang/semver"
+	"github.com/fission/fission-workflows/pkg/apiserver"
 	"github.com/fission/fission-workflows/pkg/apiserver/httpclient"
+	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/parse/yaml"
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/graph"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus"
@@ -29,16 +36,39 @@ var cmdInvocation = cli.Command{
 			Flags: []cli.Flag{
 				cli.DurationFlag{
 					Name:  "history",
-					Usage: "Amount history (non-active invocations) to show.",
+					Usage: "Only list the invocations created within this duration (0 lists all invocations).",
 					Value: time.Duration(1) * time.Hour,
 				},
+				cli.StringSliceFlag{
+					Name:  "status",
+					Usage: "Only list the invocations with this status, such as 'failed' (can be repeated).",
+				},
+				cli.StringSliceFlag{
+					Name:  "workflow",
+					Usage: "Only list the invocations of the workflow with this id (can be repeated).",
+				},
+				cli.StringFlag{
+					Name:  "selector, l",
+					Usage: "Only list the invocations that match the label selector, such as 'trigger=<id>'.",
+				},
+				cli.BoolFlag{
+					Name:  "all, a",
+					Usage: "Include the child invocations of other invocations, such as those of dynamic tasks.",
+				},
+				cli.IntFlag{
+					Name:  "limit",
+					Usage: "Maximum number of invocations to list, starting at the most recent (0 lists all).",
+				},
 			},
 			Action: commandContext(func(ctx Context) error {
 				client := getClient(ctx)
 				switch ctx.NArg() {
 				case 0:
-					since := ctx.Duration("history")
-					invocationsList(os.Stdout, client.Invocation, time.Now().Add(-since))
+					query, err := parseInvocationListQuery(ctx)
+					if err != nil {
+						logrus.Fatal(err)
+					}
+					invocationsList(ctx, os.Stdout, client.Invocation, query, ctx.Int("limit"))
 				case 1:
 					// Get Workflow Invocation
 					wfiID := ctx.Args().Get(0)
@@ -88,6 +118,54 @@ var cmdInvocation = cli.Command{
 				return nil
 			}),
 		},
+		{
+			Name:  "retry",
+			Usage: "retry <invocation-id>",
+			Action: commandContext(func(ctx Context) error {
+				if !ctx.Args().Present() {
+					logrus.Fatal("Usage: fission-workflows invocation retry <invocation-id>")
+				}
+				client := getClient(ctx)
+				wfiID := ctx.Args().First()
+				err := client.Invocation.Retry(ctx, wfiID)
+				if err != nil {
+					logrus.Fatalf("Failed to retry %s: %v", wfiID, err)
+				}
+				return nil
+			}),
+		},
+		{
+			Name:  "signal",
+			Usage: "signal <invocation-id> <task-id>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "payload",
+					Usage: "Payload of the signal, which becomes the output of the task. Expects a JSON value; otherwise it is used as a string.",
+				},
+			},
+			Action: commandContext(func(ctx Context) error {
+				if len(ctx.Args()) < 2 {
+					logrus.Fatal("Usage: fission-workflows invocation signal <invocation-id> <task-id>")
+				}
+				client := getClient(ctx)
+				wfiID := ctx.Args().Get(0)
+				taskID := ctx.Args().Get(1)
+
+				var payload *typedvalues.TypedValue
+				if raw := ctx.String("payload"); len(raw) > 0 {
+					var i interface{}
+					if err := json.Unmarshal([]byte(raw), &i); err != nil {
+						i = raw
+					}
+					payload = typedvalues.MustWrap(i)
+				}
+				err := client.Invocation.Signal(ctx, wfiID, taskID, payload)
+				if err != nil {
+					logrus.Fatalf("Failed to signal task %s of %s: %v", taskID, wfiID, err)
+				}
+				return nil
+			}),
+		},
 		{
 			Name:  "events",
 			Usage: "events <invocation-id>",
@@ -116,9 +194,41 @@ var cmdInvocation = cli.Command{
 				return nil
 			}),
 		},
+		cmdInvocationReplay,
+		{
+			Name:  "graph",
+			Usage: "graph <invocation-id>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "format, f",
+					Value: graph.FormatDot,
+					Usage: "Format of the graph [dot|mermaid|json]",
+				},
+			},
+			Action: commandContext(func(ctx Context) error {
+				if !ctx.Args().Present() {
+					logrus.Fatal("Usage: fission-workflows invocation graph <invocation-id>")
+				}
+				client := getClient(ctx)
+				wfiID := ctx.Args().First()
+
+				result, err := client.Invocation.Graph(ctx, wfiID, ctx.String("format"))
+				if err != nil {
+					logrus.Fatalf("Failed to retrieve graph of %s: %v", wfiID, err)
+				}
+				fmt.Println(strings.TrimSpace(result.GetContent()))
+				return nil
+			}),
+		},
 		{
 			Name:  "status",
 			Usage: "status <Workflow-Invocation-id> ",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  "watch, w",
+					Usage: "Follow the events of the invocation until it has finished, before showing the status.",
+				},
+			},
 			Action: commandContext(func(ctx Context) error {
 				if !ctx.Args().Present() {
 					logrus.Fatal("Usage: fission-workflows invocation status <invocation-id>")
@@ -126,6 +236,18 @@ var cmdInvocation = cli.Command{
 				client := getClient(ctx)
 				wfiID := ctx.Args().First()
 
+				if ctx.Bool("watch") {
+					w := tabwriter.NewWriter(os.Stdout, 0, 0, 5, ' ', 0)
+					err := client.Invocation.Watch(ctx, wfiID, func(event *fes.Event) error {
+						defer w.Flush()
+						return printInvocationEvent(w, event)
+					})
+					if err != nil {
+						logrus.Fatalf("Failed to watch %s: %v", wfiID, err)
+					}
+					fmt.Println()
+				}
+
 				wfi, err := client.Invocation.Get(ctx, wfiID)
 				if err != nil {
 					logrus.Fatalf("Failed to retrieve status for %s: %v", wfiID, err)
@@ -162,53 +284,67 @@ var cmdInvocation = cli.Command{
 	},
 }
 
-func invocationsList(out io.Writer, wfiAPI *httpclient.InvocationAPI, since time.Time) {
-	// List workflows invocations
-	ctx := context.TODO()
-	wis, err := wfiAPI.List(ctx)
-	if err != nil {
-		panic(err)
-	}
+// invocationListPageSize is the number of invocations that are fetched per request.
+const invocationListPageSize = 100
 
-	var invocations []*types.WorkflowInvocation
-	for _, wfiID := range wis.Invocations {
-		wi, err := wfiAPI.Get(ctx, wfiID)
+func parseInvocationListQuery(ctx Context) (*apiserver.InvocationListQuery, error) {
+	query := &apiserver.InvocationListQuery{
+		Workflows:     ctx.StringSlice("workflow"),
+		LabelSelector: ctx.String("selector"),
+		Summaries:     true,
+	}
+	for _, s := range ctx.StringSlice("status") {
+		status, ok := types.WorkflowInvocationStatus_Status_value[strings.ToUpper(s)]
+		if !ok {
+			return nil, fmt.Errorf("unknown invocation status: %s", s)
+		}
+		query.Statuses = append(query.Statuses, types.WorkflowInvocationStatus_Status(status))
+	}
+	if history := ctx.Duration("history"); history > 0 {
+		createdAfter, err := ptypes.TimestampProto(time.Now().Add(-history))
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
-		if len(wi.Spec.ParentId) != 0 {
-			continue
+		query.CreatedAfter = createdAfter
+	}
+	if !ctx.Bool("all") {
+		// Child invocations are an implementation detail of the invocations that created them.
+		if len(query.LabelSelector) > 0 {
+			query.LabelSelector += ","
 		}
-
-		invocations = append(invocations, wi)
+		query.LabelSelector += "!parent"
 	}
+	return query, nil
+}
 
-	sort.Slice(invocations, func(i, j int) bool {
-		tsi, err := ptypes.Timestamp(invocations[i].Status.UpdatedAt)
-		if err != nil {
-			panic(err)
+func invocationsList(ctx context.Context, out io.Writer, wfiAPI *httpclient.InvocationAPI,
+	query *apiserver.InvocationListQuery, limit int) {
+	var rows [][]string
+	for {
+		query.PageSize = invocationListPageSize
+		if limit > 0 && limit-len(rows) < invocationListPageSize {
+			query.PageSize = int32(limit - len(rows))
 		}
-		tsj, err := ptypes.Timestamp(invocations[j].Status.UpdatedAt)
+		resp, err := wfiAPI.List(ctx, query)
 		if err != nil {
-			panic(err)
+			logrus.Fatalf("Failed to list invocations: %v", err)
 		}
-		return tsi.Before(tsj)
-	})
-
-	var rows [][]string
-	for _, wi := range invocations {
-		updated := ptypes.TimestampString(wi.Status.UpdatedAt)
-		created := ptypes.TimestampString(wi.Metadata.CreatedAt)
-
-		// TODO add filter params to endpoint instead
-		// TODO filter old invocations and system invocations
-
-		rows = append(rows, []string{wi.ID(), wi.Spec.WorkflowId, wi.Status.Status.String(),
-			created, updated})
+		for _, summary := range resp.Summaries {
+			workflow := summary.GetWorkflowName()
+			if len(workflow) == 0 {
+				workflow = summary.GetWorkflowId()
+			}
+			rows = append(rows, []string{summary.GetMetadata().GetId(), workflow, summary.GetStatus().String(),
+				ptypes.TimestampString(summary.GetMetadata().GetCreatedAt()),
+				ptypes.TimestampString(summary.GetUpdatedAt())})
+		}
+		if len(resp.NextPageToken) == 0 || (limit > 0 && len(rows) >= limit) {
+			break
+		}
+		query.PageToken = resp.NextPageToken
 	}
 
-	table(out, []string{"id", "WORKFLOW", "STATUS", "CREATED", "UPDATED"}, rows)
-
+	table(out, []string{"ID", "WORKFLOW", "STATUS", "CREATED", "UPDATED"}, rows)
 }
 
 func collectStatus(tasks map[string]*types.TaskSpec, taskStatus map[string]*types.TaskInvocation,
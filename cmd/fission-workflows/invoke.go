@@ -46,8 +46,8 @@ Whatever the method all inputs end up in a WorkflowInvocationSpec
 */
 var cmdInvoke = cli.Command{
 	Name:  "invoke",
-	Usage: "invoke <workflow-id>",
-	Flags: []cli.Flag{
+	Usage: "invoke <workflow-id|name[@revision]>",
+	Flags: append([]cli.Flag{
 		cli.BoolFlag{
 			Name:  "async",
 			Usage: "Invoke workflow asynchronously; invoke without waiting for the result.",
@@ -56,6 +56,11 @@ var cmdInvoke = cli.Command{
 			Name:  "inputs",
 			Usage: "Sets the inputs to provided value. Expects a JSON object.",
 		},
+		cli.StringFlag{
+			Name:  "priority",
+			Usage: "Priority of the invocation relative to other invocations (low, normal, high).",
+			Value: "normal",
+		},
 		cli.DurationFlag{
 			Name:  "poll",
 			Value: 10 * time.Millisecond,
@@ -64,7 +69,7 @@ var cmdInvoke = cli.Command{
 			Name:  "timeout",
 			Value: 10 * time.Minute,
 		},
-	},
+	}, labelFlags...),
 	Description: "Invoke a workflow",
 	Action: commandContext(func(ctx Context) error {
 		listenToEvents := ensureServerVersionAtLeast(ctx, semver.MustParse("0.7.0"), false)
@@ -91,10 +96,18 @@ var cmdInvoke = cli.Command{
 			inputs = typedvalues.MustWrapMapTypedValue(inputMap)
 		}
 
+		priority, ok := types.WorkflowInvocationSpec_Priority_value[strings.ToUpper(ctx.String("priority"))]
+		if !ok {
+			logrus.Fatalf("Unknown priority: %s", ctx.String("priority"))
+		}
+
 		client := getClient(ctx)
 		spec := &types.WorkflowInvocationSpec{
-			WorkflowId: workflowID,
-			Inputs:     inputs,
+			WorkflowId:  workflowID,
+			Inputs:      inputs,
+			Priority:    types.WorkflowInvocationSpec_Priority(priority),
+			Labels:      parseLabels(ctx, "label"),
+			Annotations: parseLabels(ctx, "annotation"),
 		}
 		types.NewWorkflowInvocationSpec(workflowID, time.Now().Add(timeout))
 		md, err := client.Invocation.Invoke(ctx, spec)
@@ -102,42 +115,6 @@ var cmdInvoke = cli.Command{
 			logrus.Fatalf("Error occurred while invoking workflow: %v", err)
 		}
 
-		// Poll for updates on the progress. In the future we can replace this with a streaming API.
-		outputChan := make(chan *types.WorkflowInvocation)
-		go func() {
-			var offset int
-			ticker := time.Tick(ctx.Duration("poll"))
-			w := tabwriter.NewWriter(os.Stdout, 0, 0, 5, ' ', 0)
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker:
-					pollStatus := !listenToEvents
-					if listenToEvents {
-						pollStatus, err = fetchAndPrintEvents(ctx, client, md.GetId(), offset, w)
-						if err != nil {
-							logrus.Error(err)
-							logrus.Warn("Halting event streaming; falling back to polling status instead")
-							listenToEvents = false
-						}
-						w.Flush()
-					}
-
-					if pollStatus {
-						wi, err := client.Invocation.Get(ctx, md.GetId())
-						if err != nil {
-							logrus.Fatal("Failed to fetch invocation: ", err)
-						}
-						if wi.GetStatus().Finished() {
-							outputChan <- wi
-							close(outputChan)
-							return
-						}
-					}
-				}
-			}
-		}()
 		c := make(chan os.Signal, 1)
 		signal.Notify(c, os.Interrupt)
 		go func() {
@@ -149,10 +126,22 @@ var cmdInvoke = cli.Command{
 			}
 		}()
 
-		wi, ok := <-outputChan
-		if !ok {
-			logrus.Fatal("No output could be retrieved.")
+		// Follow the progress of the invocation; fall back to polling if the event stream is not available.
+		if listenToEvents {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 5, ' ', 0)
+			err := client.Invocation.Watch(ctx, md.GetId(), func(event *fes.Event) error {
+				defer w.Flush()
+				return printInvocationEvent(w, event)
+			})
+			if err != nil {
+				logrus.Warnf("Failed to watch events; falling back to polling status instead: %v", err)
+			}
+		}
+		wi, err := pollInvocation(ctx, client, md.GetId(), ctx.Duration("poll"))
+		if err != nil {
+			logrus.Fatal("No output could be retrieved: ", err)
 		}
+
 		// Display output or error
 		logrus.Debugf("Invocation status: %s", wi.GetStatus().GetStatus().String())
 		if wi.GetStatus().Successful() {
@@ -165,43 +154,54 @@ var cmdInvoke = cli.Command{
 	}),
 }
 
-func fetchAndPrintEvents(ctx context.Context, client client, invocationID string, offset int,
-	w io.Writer) (finished bool,
-	err error) {
-	// Future: support and use offset in invocationEvents API requests
-	invocationEvents, err := client.Invocation.Events(ctx, invocationID)
-	if err != nil {
-		return finished, fmt.Errorf("failed to fetch events: %v", err)
-	}
-
-	// Traverse all new invocationEvents
-	for _, event := range invocationEvents.GetEvents()[offset:] {
-		invocationEvent, err := parseInvocationEvent(event)
+// pollInvocation fetches the invocation until it has finished.
+func pollInvocation(ctx context.Context, client client, invocationID string,
+	interval time.Duration) (*types.WorkflowInvocation, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		wi, err := client.Invocation.Get(ctx, invocationID)
 		if err != nil {
-			return finished, fmt.Errorf("failed to parse events: %v", err)
+			return nil, err
 		}
-
-		if e, ok := invocationEvent.data.(events.Event); ok {
-			switch e.Type() {
-			case events.EventInvocationFailed:
-				invocationEvent.subjectType = subjectTypeError
-				finished = true
-			case events.EventInvocationCanceled:
-				invocationEvent.subjectType = subjectTypeError
-				finished = true
-			case events.EventInvocationCompleted:
-				invocationEvent.subjectType = subjectTypeSuccess
-				finished = true
-			case events.EventTaskSucceeded:
-				invocationEvent.subjectType = subjectTypeSuccess
-				invocationEvent.target = event.GetId() // TODO can we get task name
-			}
+		if wi.GetStatus().Finished() {
+			return wi, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
 		}
+	}
+}
+
+// printInvocationEvent writes a single event of an invocation as a tab-separated line.
+func printInvocationEvent(w io.Writer, fesEvent *fes.Event) error {
+	invocationEvent, err := parseInvocationEvent(fesEvent)
+	if err != nil {
+		return fmt.Errorf("failed to parse event: %v", err)
+	}
 
-		w.Write([]byte(eventToTabString(invocationEvent)))
-		offset++
+	if e, ok := invocationEvent.data.(events.Event); ok {
+		switch e.Type() {
+		case events.EventInvocationFailed:
+			invocationEvent.subjectType = subjectTypeError
+		case events.EventInvocationCanceled:
+			invocationEvent.subjectType = subjectTypeError
+		case events.EventInvocationCompleted:
+			invocationEvent.subjectType = subjectTypeSuccess
+		case events.EventTaskSucceeded:
+			invocationEvent.subjectType = subjectTypeSuccess
+		case events.EventTaskFailed:
+			invocationEvent.subjectType = subjectTypeError
+		}
 	}
-	return finished, nil
+	if fesEvent.GetAggregate().GetType() == types.TypeTaskRun {
+		invocationEvent.target = fesEvent.GetAggregate().GetId()
+	}
+
+	_, err = w.Write([]byte(eventToTabString(invocationEvent)))
+	return err
 }
 
 type subjectType int
@@ -217,7 +217,7 @@ type event struct {
 	timestamp   time.Time
 	subject     string
 	subjectType subjectType
-	target      string // TODO add target task
+	target      string
 	message     string
 	data        proto.Message
 }
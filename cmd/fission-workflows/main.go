@@ -9,6 +9,8 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/fission/fission-workflows/pkg/parse"
+	"github.com/fission/fission-workflows/pkg/parse/yaml"
 	"github.com/fission/fission-workflows/pkg/version"
 	"github.com/fission/fission/fission/plugin"
 	"github.com/sirupsen/logrus"
@@ -44,6 +46,10 @@ func main() {
 			Usage:  "CLI verbosity (0 is quiet, 1 is the default, 2 is verbose.)",
 			EnvVar: "FISSION_VERBOSITY",
 		},
+		cli.BoolFlag{
+			Name:  "no-dependency-inference",
+			Usage: "Only use the explicit 'requires' of tasks, rather than inferring dependencies from expressions",
+		},
 		cli.BoolFlag{
 			Hidden: true,
 			Name:   "plugin",
@@ -52,11 +58,14 @@ func main() {
 	}
 	app.Commands = []cli.Command{
 		cmdInvoke,
+		cmdRun,
+		cmdTest,
 		cmdConfig,
 		cmdStatus,
 		cmdParse,
 		cmdWorkflow,
 		cmdInvocation,
+		cmdTrigger,
 		cmdValidate,
 		cmdVersion,
 	}
@@ -94,6 +103,34 @@ func table(writer io.Writer, headings []string, rows [][]string) {
 	}
 }
 
+// labelFlags are the flags to add labels and annotations to the objects created by a command.
+var labelFlags = []cli.Flag{
+	cli.StringSliceFlag{
+		Name:  "label",
+		Usage: "Label formatted as <key>=<value> (can be repeated)",
+	},
+	cli.StringSliceFlag{
+		Name:  "annotation",
+		Usage: "Annotation formatted as <key>=<value> (can be repeated)",
+	},
+}
+
+// parseLabels parses the labels or annotations of the flag, which are formatted as <key>=<value>.
+func parseLabels(ctx Context, flag string) map[string]string {
+	var labels map[string]string
+	for _, arg := range ctx.StringSlice(flag) {
+		i := strings.Index(arg, "=")
+		if i <= 0 {
+			logrus.Fatalf("Invalid %s '%s': expected <key>=<value>", flag, arg)
+		}
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[arg[:i]] = arg[i+1:]
+	}
+	return labels
+}
+
 func fail(msg ...interface{}) {
 	for _, line := range msg {
 		fmt.Fprintln(os.Stderr, line)
@@ -117,6 +154,24 @@ func (c Context) Err() error {
 	return nil
 }
 
+// Parser returns the parser for workflow definitions, configured according to the global flags.
+func (c Context) Parser() *parse.MetaParser {
+	return parse.NewDefaultParser(c.yamlParserOptions()...)
+}
+
+// YAMLParser returns the parser for YAML workflow definitions, configured according to the global flags.
+func (c Context) YAMLParser() *yaml.Parser {
+	return yaml.NewParser(c.yamlParserOptions()...)
+}
+
+func (c Context) yamlParserOptions() []yaml.Option {
+	var opts []yaml.Option
+	if c.GlobalBool("no-dependency-inference") {
+		opts = append(opts, yaml.WithoutDependencyInference())
+	}
+	return opts
+}
+
 func (c Context) Value(key interface{}) interface{} {
 	if s, ok := key.(string); ok {
 		return c.Generic(s)
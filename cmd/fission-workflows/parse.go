@@ -5,7 +5,6 @@ import (
 	"os"
 	"strings"
 
-	"github.com/fission/fission-workflows/pkg/parse"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/golang/protobuf/jsonpb"
 	log "github.com/sirupsen/logrus"
@@ -29,8 +28,9 @@ var cmdParse = cli.Command{
 			log.Fatal("No file provided.")
 		}
 
+		parser := ctx.Parser()
 		parserType := ctx.String("type")
-		if parserType != "" && !parse.Supports(parserType) {
+		if parserType != "" && !parser.Supports(parserType) {
 			log.Fatalf("Unknown parser '%s'", parserType)
 		}
 
@@ -44,7 +44,7 @@ var cmdParse = cli.Command{
 				panic(err)
 			}
 
-			wfSpec, err := parse.ParseWith(f, parserType)
+			wfSpec, err := parser.ParseWith(f, parserType)
 			f.Close()
 			if err != nil {
 				panic(err)
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fission/fission-workflows/cmd/fission-workflows-bundle/bundle"
+	"github.com/fission/fission-workflows/pkg/apiserver"
+	"github.com/fission/fission-workflows/pkg/fnenv"
+	"github.com/fission/fission-workflows/pkg/fnenv/mock"
+	"github.com/fission/fission-workflows/pkg/fnenv/replay"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var cmdInvocationReplay = cli.Command{
+	Name:  "replay",
+	Usage: "replay <invocation-id> [--workflow <file>]",
+	Description: "Re-project an invocation from its events. With --workflow (or --rerun) the invocation is replayed " +
+		"in an in-process engine against the (modified) workflow, in which the tasks that completed in the " +
+		"recorded invocation return their recorded results, unless their function, inputs or outputs changed. " +
+		"Internal functions are always run again.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "events",
+			Usage: "Read the events from a file exported with --export, rather than from the workflow engine",
+		},
+		cli.StringFlag{
+			Name:  "export",
+			Usage: "Export the events of the invocation to this file",
+		},
+		cli.StringFlag{
+			Name:  "workflow",
+			Usage: "Replay the invocation against this workflow definition, instead of the recorded workflow",
+		},
+		cli.StringSliceFlag{
+			Name:  "rerun",
+			Usage: "Run this task again instead of using its recorded result (can be repeated)",
+		},
+		cli.StringSliceFlag{
+			Name:  "mock",
+			Usage: "File or directory with fixtures of mocked functions, for tasks without a recorded result",
+		},
+		cli.DurationFlag{
+			Name:  "timeout",
+			Value: 10 * time.Minute,
+		},
+	},
+	Action: commandContext(func(ctx Context) error {
+		if !ctx.Args().Present() && len(ctx.String("events")) == 0 {
+			logrus.Fatal("Usage: fission-workflows invocation replay <invocation-id> [--workflow <file>]")
+		}
+
+		var objectEvents *apiserver.ObjectEvents
+		var err error
+		if path := ctx.String("events"); len(path) > 0 {
+			objectEvents, err = readEvents(path)
+		} else {
+			wfiID := ctx.Args().First()
+			objectEvents, err = getClient(ctx).Invocation.Events(ctx, wfiID)
+		}
+		if err != nil {
+			logrus.Fatalf("Failed to retrieve events: %v", err)
+		}
+		if path := ctx.String("export"); len(path) > 0 {
+			if err := writeEvents(path, objectEvents); err != nil {
+				logrus.Fatalf("Failed to export events: %v", err)
+			}
+		}
+
+		recorded, err := replay.Project(objectEvents.GetEvents())
+		if err != nil {
+			logrus.Fatalf("Failed to project the events: %v", err)
+		}
+		fmt.Printf("Recorded invocation %s (%d events):\n\n", recorded.ID(), len(objectEvents.GetEvents()))
+		printInvocationResult(recorded)
+
+		rerun := ctx.StringSlice("rerun")
+		if len(ctx.String("workflow")) == 0 && len(rerun) == 0 {
+			return nil
+		}
+		spec := recorded.Workflow().GetSpec()
+		if path := ctx.String("workflow"); len(path) > 0 {
+			spec = parseWorkflowFile(ctx, path)
+		}
+		if spec == nil {
+			logrus.Fatal("The recorded invocation does not contain its workflow; provide one with --workflow")
+		}
+
+		// The engine logs every step of the invocation; only show these when asked for.
+		if ctx.GlobalInt("verbosity") < 2 {
+			logrus.SetLevel(logrus.WarnLevel)
+		}
+		var fixtures []*mock.Fixture
+		if paths := ctx.StringSlice("mock"); len(paths) > 0 {
+			fixtures, err = mock.LoadFixtures(paths...)
+			if err != nil {
+				logrus.Fatalf("Failed to load fixtures: %v", err)
+			}
+		}
+		recordings := replay.New(recorded)
+		engine, _, err := newLocalEngine(&bundle.LocalOptions{
+			Runtimes:  map[string]fnenv.Runtime{replay.Name: recordings},
+			Resolvers: map[string]fnenv.RuntimeResolver{replay.Name: recordings},
+		}, fixtures)
+		if err != nil {
+			logrus.Fatalf("Failed to load fixtures: %v", err)
+		}
+		defer engine.Close()
+
+		spec, substituted := recordings.Substitute(spec, func(taskID string, task *types.TaskSpec) bool {
+			for _, id := range rerun {
+				if id == taskID {
+					return false
+				}
+			}
+			// Internal functions are deterministic, so rather than replaying their results they are run again
+			// to reflect changes in the control flow of the workflow.
+			ref, err := engine.Resolver.Resolve(task.GetFunctionRef())
+			return err != nil || ref.Runtime != "internal"
+		})
+		wi, err := runLocal(engine, spec, recorded.GetSpec().GetInputs(), ctx.Duration("timeout"))
+		if err != nil {
+			logrus.Fatal(err)
+		}
+		fmt.Printf("\nReplayed invocation (recorded results of %d tasks):\n\n", len(substituted))
+		printInvocationResult(wi)
+		return nil
+	}),
+}
+
+// printInvocationResult prints the tasks of the invocation, followed by its output or error.
+func printInvocationResult(wi *types.WorkflowInvocation) {
+	table(os.Stdout, []string{"TASK", "FUNCTION", "STATUS", "FINISHED", "ERROR"}, collectTrace(wi))
+	fmt.Println()
+	status := wi.GetStatus()
+	switch {
+	case status.Successful():
+		output, err := typedvalues.Unwrap(status.GetOutput())
+		if err != nil {
+			output = err
+		}
+		fmt.Printf("%v: %v\n", status.GetStatus(), output)
+	case len(status.GetError().GetMessage()) > 0:
+		fmt.Printf("%v: %v\n", status.GetStatus(), status.GetError().GetMessage())
+	default:
+		fmt.Println(status.GetStatus())
+	}
+}
+
+func readEvents(path string) (*apiserver.ObjectEvents, error) {
+	bs, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	objectEvents := &apiserver.ObjectEvents{}
+	if err := jsonpb.Unmarshal(bytes.NewReader(bs), objectEvents); err != nil {
+		return nil, err
+	}
+	return objectEvents, nil
+}
+
+func writeEvents(path string, objectEvents *apiserver.ObjectEvents) error {
+	s, err := (&jsonpb.Marshaler{Indent: "  "}).MarshalToString(objectEvents)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(strings.TrimSpace(s)+"\n"), 0644)
+}
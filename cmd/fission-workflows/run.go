@@ -0,0 +1,209 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fission/fission-workflows/cmd/fission-workflows-bundle/bundle"
+	"github.com/fission/fission-workflows/pkg/fnenv"
+	"github.com/fission/fission-workflows/pkg/fnenv/mock"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var cmdRun = cli.Command{
+	Name:  "run",
+	Usage: "run <file> [--input key=value]",
+	Description: "Run a workflow definition in an in-process engine, without a Fission Workflows deployment. " +
+		"Besides the internal functions, the engine can invoke HTTP(S) functions and mocked functions.",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name: "input, i",
+			Usage: "Input of the invocation formatted as <key>=<value>, where the value is parsed as JSON if " +
+				"possible. Without a key the value is used as the default input.",
+		},
+		cli.StringSliceFlag{
+			Name: "mock",
+			Usage: "File or directory with fixtures of mocked functions (can be repeated). " +
+				"Mocked functions can be referenced as <name>, mock://<name> or fission://<name>.",
+		},
+		cli.DurationFlag{
+			Name:  "timeout",
+			Value: 10 * time.Minute,
+		},
+	},
+	Action: commandContext(func(ctx Context) error {
+		if !ctx.Args().Present() {
+			logrus.Fatal("Usage: fission-workflows run <file> [--input key=value]")
+		}
+		timeout := ctx.Duration("timeout")
+		if timeout <= 0 {
+			logrus.Fatal("Timeout should be larger than 0")
+		}
+		spec := parseWorkflowFile(ctx, ctx.Args().First())
+		inputs, err := parseRunInputs(ctx.StringSlice("input"))
+		if err != nil {
+			logrus.Fatal(err)
+		}
+
+		// The engine logs every step of the invocation; only show these when asked for.
+		if ctx.GlobalInt("verbosity") < 2 {
+			logrus.SetLevel(logrus.WarnLevel)
+		}
+
+		var fixtures []*mock.Fixture
+		if paths := ctx.StringSlice("mock"); len(paths) > 0 {
+			fixtures, err = mock.LoadFixtures(paths...)
+			if err != nil {
+				logrus.Fatalf("Failed to load fixtures: %v", err)
+			}
+		}
+		engine, _, err := newLocalEngine(nil, fixtures)
+		if err != nil {
+			logrus.Fatalf("Failed to load fixtures: %v", err)
+		}
+		defer engine.Close()
+
+		wi, err := runLocal(engine, spec, inputs, timeout)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+
+		table(os.Stdout, []string{"TASK", "FUNCTION", "STATUS", "FINISHED", "ERROR"}, collectTrace(wi))
+		fmt.Println()
+		if !wi.GetStatus().Successful() {
+			logrus.Error(wi.GetStatus().GetError().GetMessage())
+			os.Exit(1)
+		}
+		fmt.Println(typedvalues.MustUnwrap(wi.GetStatus().GetOutput()))
+		return nil
+	}),
+}
+
+// newLocalEngine starts an in-process engine, in which the functions in the fixtures are mocked. The options, if
+// provided, are extended with the runtime of the mocked functions.
+func newLocalEngine(opts *bundle.LocalOptions, fixtures []*mock.Fixture) (*bundle.LocalEngine, *mock.FixtureRuntime,
+	error) {
+	runtime, err := mock.NewFixtureRuntime(fixtures)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts == nil {
+		opts = &bundle.LocalOptions{}
+	}
+	if len(fixtures) > 0 {
+		if opts.Runtimes == nil {
+			opts.Runtimes = map[string]fnenv.Runtime{}
+		}
+		if opts.Resolvers == nil {
+			opts.Resolvers = map[string]fnenv.RuntimeResolver{}
+		}
+		// There is no Fission deployment to run Fission functions in, so mocks stand in for them.
+		for _, name := range []string{mock.Name, "fission"} {
+			opts.Runtimes[name] = runtime
+			opts.Resolvers[name] = runtime
+		}
+	}
+	return bundle.NewLocalEngine(opts), runtime, nil
+}
+
+// runLocal creates the workflow in the local engine and invokes it with the inputs, waiting for the invocation to
+// finish.
+func runLocal(engine *bundle.LocalEngine, spec *types.WorkflowSpec, inputs map[string]*typedvalues.TypedValue,
+	timeout time.Duration) (*types.WorkflowInvocation, error) {
+	// The engine keeps retrying workflows that it cannot parse, as functions might be deployed later on. Locally
+	// that will not happen, so check the workflow beforehand instead.
+	if err := validate.WorkflowSpec(spec); err != nil {
+		return nil, errors.New(validate.Format(err))
+	}
+	if _, err := fnenv.ResolveTasks(engine.Resolver, spec.GetTasks()); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	wf, err := engine.Workflows.CreateSync(ctx, spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workflow: %v", err)
+	}
+	invocationSpec := types.NewWorkflowInvocationSpec(wf.ID(), time.Now().Add(timeout))
+	invocationSpec.Inputs = inputs
+	wi, err := engine.Invocations.InvokeSync(ctx, invocationSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke workflow: %v", err)
+	}
+	return wi, nil
+}
+
+// parseRunInputs parses the inputs formatted as <key>=<value>. Values that are not valid JSON are used as strings.
+func parseRunInputs(args []string) (map[string]*typedvalues.TypedValue, error) {
+	inputs := map[string]*typedvalues.TypedValue{}
+	for _, arg := range args {
+		key := types.InputMain
+		value := arg
+		if i := strings.Index(arg, "="); i >= 0 {
+			key, value = arg[:i], arg[i+1:]
+		}
+		if len(key) == 0 {
+			return nil, fmt.Errorf("invalid input '%s': key is empty", arg)
+		}
+		var i interface{}
+		if err := json.Unmarshal([]byte(value), &i); err != nil {
+			i = value
+		}
+		tv, err := typedvalues.Wrap(i)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input '%s': %v", arg, err)
+		}
+		inputs[key] = tv
+	}
+	return inputs, nil
+}
+
+// collectTrace returns a row for each of the tasks that were run in the invocation, in the order that they finished.
+// The time at which each task finished is relative to the start of the invocation.
+func collectTrace(wi *types.WorkflowInvocation) [][]string {
+	var ids []string
+	for id := range wi.GetStatus().GetTasks() {
+		ids = append(ids, id)
+	}
+	updatedAt := func(id string) time.Time {
+		ts, _ := ptypes.Timestamp(wi.Status.Tasks[id].GetStatus().GetUpdatedAt())
+		return ts
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if ti, tj := updatedAt(ids[i]), updatedAt(ids[j]); !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return ids[i] < ids[j]
+	})
+
+	tasks := wi.Tasks()
+	started, _ := ptypes.Timestamp(wi.GetMetadata().GetCreatedAt())
+	var rows [][]string
+	for _, id := range ids {
+		ti := wi.Status.Tasks[id]
+		var finished string
+		if ti.GetStatus().GetUpdatedAt() != nil {
+			finished = "+" + updatedAt(id).Sub(started).String()
+		}
+		rows = append(rows, []string{
+			id,
+			tasks[id].GetSpec().GetFunctionRef(),
+			ti.GetStatus().GetStatus().String(),
+			finished,
+			ti.GetStatus().GetError().GetMessage(),
+		})
+	}
+	return rows
+}
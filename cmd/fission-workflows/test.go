@@ -0,0 +1,450 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fnenv/mock"
+	"github.com/fission/fission-workflows/pkg/parse"
+	"github.com/fission/fission-workflows/pkg/parse/yaml"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+// testCaseSuffixes contains the suffixes of the files that contain test cases.
+var testCaseSuffixes = []string{".test.yaml", ".test.yml", ".test.json"}
+
+var cmdTest = cli.Command{
+	Name:  "test",
+	Usage: "test <dir|file>...",
+	Description: "Run the workflow test cases in the files ending with .test.yaml, each in an in-process engine in " +
+		"which the functions are mocked.",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "junit",
+			Usage: "Write the results of the test cases to this file as JUnit XML",
+		},
+		cli.DurationFlag{
+			Name:  "timeout",
+			Usage: "Default timeout of a test case",
+			Value: time.Minute,
+		},
+	},
+	Action: commandContext(func(ctx Context) error {
+		if !ctx.Args().Present() {
+			logrus.Fatal("Usage: fission-workflows test <dir|file>...")
+		}
+		var files []string
+		for _, path := range ctx.Args() {
+			found, err := findTestCaseFiles(path)
+			if err != nil {
+				logrus.Fatal(err)
+			}
+			files = append(files, found...)
+		}
+		if len(files) == 0 {
+			logrus.Fatalf("No test cases found (files ending with %s)", strings.Join(testCaseSuffixes, ", "))
+		}
+
+		// The engine logs every step of the invocations, as well as the failures that test cases might expect. The
+		// results of the test cases already report any unexpected failures, so only show the logs when asked for.
+		if ctx.GlobalInt("verbosity") < 2 {
+			logrus.SetLevel(logrus.FatalLevel)
+		}
+
+		suite := &junitTestSuite{Name: strings.Join(ctx.Args(), " ")}
+		start := time.Now()
+		for _, file := range files {
+			cases, err := loadTestCases(file)
+			if err != nil {
+				// Report the file as a single test case that could not be run.
+				cases = []*testCase{{Name: filepath.Base(file), file: file, loadErr: err}}
+			}
+			for _, tc := range cases {
+				if tc.Timeout == "" {
+					tc.Timeout = ctx.Duration("timeout").String()
+				}
+				tc.parser = ctx.Parser()
+				result := tc.run()
+				result.print()
+				suite.add(result)
+			}
+		}
+		suite.Time = seconds(time.Since(start))
+
+		fmt.Printf("\n%d tests, %d failures, %d errors\n", suite.Tests, suite.Failures, suite.Errors)
+		if path := ctx.String("junit"); len(path) > 0 {
+			if err := writeJUnit(path, suite); err != nil {
+				logrus.Fatalf("Failed to write JUnit report: %v", err)
+			}
+		}
+		if suite.Failures > 0 || suite.Errors > 0 {
+			os.Exit(1)
+		}
+		return nil
+	}),
+}
+
+// testCase is a test of a workflow. A test case file contains either a single test case or a list of test cases:
+//
+//   name: fortune is passed to the whale
+//   workflow: fortunewhale.wf.yaml   # path relative to the test case file, or an inline workflow definition
+//   inputs:
+//     default: hello
+//   mocks:                           # fixtures of the mocked functions; see mock.Fixture
+//   - function: fortune
+//     output: Be yourself
+//   fixtures:                        # paths to fixture files, relative to the test case file
+//   - fixtures/whalesay.yaml
+//   expect:
+//     status: SUCCEEDED              # defaults to FAILED if an error is expected, otherwise to SUCCEEDED
+//     output: The whale says "Be yourself"
+//     error: ""                      # a part of the error message of the invocation
+//     tasks:                         # the statuses of tasks; UNKNOWN for tasks that did not run
+//       GenerateFortune: SUCCEEDED
+//     calls:                         # the number of invocations of mocked functions
+//       fortune: 1
+type testCase struct {
+	Name     string                 `json:"name"`
+	Workflow interface{}            `json:"workflow"`
+	Inputs   map[string]interface{} `json:"inputs"`
+	Mocks    []*mock.Fixture        `json:"mocks"`
+	Fixtures []string               `json:"fixtures"`
+	Expect   testExpectation        `json:"expect"`
+	Timeout  string                 `json:"timeout"`
+
+	file    string
+	loadErr error
+	parser  *parse.MetaParser
+}
+
+type testExpectation struct {
+	Status string            `json:"status"`
+	Output interface{}       `json:"output"`
+	Error  string            `json:"error"`
+	Tasks  map[string]string `json:"tasks"`
+	Calls  map[string]int    `json:"calls"`
+}
+
+// testResult is the outcome of a test case. Failures are unmet expectations, whereas an error indicates that the test
+// case could not be run.
+type testResult struct {
+	testCase *testCase
+	duration time.Duration
+	failures []string
+	err      error
+}
+
+func findTestCaseFiles(path string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		// Files that are passed explicitly are always considered to be test case files.
+		if file == path || isTestCaseFile(file) {
+			files = append(files, file)
+		}
+		return nil
+	})
+	sort.Strings(files)
+	return files, err
+}
+
+func isTestCaseFile(file string) bool {
+	for _, suffix := range testCaseSuffixes {
+		if strings.HasSuffix(strings.ToLower(file), suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadTestCases parses the test cases in the file. YAML is converted to JSON first, so that the test cases can
+// contain arbitrary values, such as the expected output, as JSON-compatible values.
+func loadTestCases(file string) ([]*testCase, error) {
+	bs, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var i interface{}
+	if err := yaml.Unmarshal(bs, &i); err != nil {
+		return nil, err
+	}
+	if _, ok := i.([]interface{}); !ok {
+		i = []interface{}{i}
+	}
+	bs, err = json.Marshal(i)
+	if err != nil {
+		return nil, err
+	}
+	var cases []*testCase
+	if err := json.Unmarshal(bs, &cases); err != nil {
+		return nil, fmt.Errorf("invalid test case: %v", err)
+	}
+	for i, tc := range cases {
+		if tc == nil {
+			return nil, fmt.Errorf("test case %d is empty", i)
+		}
+		if len(tc.Name) == 0 {
+			tc.Name = fmt.Sprintf("%s#%d", filepath.Base(file), i)
+		}
+		tc.file = file
+	}
+	return cases, nil
+}
+
+func (tc *testCase) run() *testResult {
+	start := time.Now()
+	result := &testResult{testCase: tc}
+	result.failures, result.err = tc.evaluate()
+	result.duration = time.Since(start)
+	return result
+}
+
+func (tc *testCase) evaluate() ([]string, error) {
+	if tc.loadErr != nil {
+		return nil, tc.loadErr
+	}
+	timeout, err := time.ParseDuration(tc.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeout: %v", err)
+	}
+	spec, err := tc.workflow()
+	if err != nil {
+		return nil, err
+	}
+	inputs, err := typedvalues.WrapMapTypedValue(tc.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid inputs: %v", err)
+	}
+	fixtures := tc.Mocks
+	if len(tc.Fixtures) > 0 {
+		loaded, err := mock.LoadFixtures(tc.paths(tc.Fixtures)...)
+		if err != nil {
+			return nil, err
+		}
+		fixtures = append(fixtures, loaded...)
+	}
+
+	engine, runtime, err := newLocalEngine(nil, fixtures)
+	if err != nil {
+		return nil, err
+	}
+	defer engine.Close()
+	wi, err := runLocal(engine, spec, inputs, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return tc.Expect.check(wi, runtime)
+}
+
+// workflow parses the workflow of the test case, which is either a path to a workflow definition or an inline
+// definition.
+func (tc *testCase) workflow() (*types.WorkflowSpec, error) {
+	switch wf := tc.Workflow.(type) {
+	case string:
+		fd, err := os.Open(tc.paths([]string{wf})[0])
+		if err != nil {
+			return nil, err
+		}
+		defer fd.Close()
+		return tc.parser.Parse(fd)
+	case map[string]interface{}:
+		// JSON is a subset of YAML, so the inline definition can be parsed by the YAML parser.
+		bs, err := json.Marshal(wf)
+		if err != nil {
+			return nil, err
+		}
+		return tc.parser.ParseWith(bytes.NewReader(bs), "yaml")
+	case nil:
+		return nil, errors.New("workflow is required")
+	default:
+		return nil, fmt.Errorf("workflow should be a path or a workflow definition, but was %T", wf)
+	}
+}
+
+// paths resolves the paths relative to the directory of the test case file.
+func (tc *testCase) paths(paths []string) []string {
+	resolved := make([]string, len(paths))
+	for i, path := range paths {
+		if filepath.IsAbs(path) {
+			resolved[i] = path
+		} else {
+			resolved[i] = filepath.Join(filepath.Dir(tc.file), path)
+		}
+	}
+	return resolved
+}
+
+// check returns the expectations that the invocation does not meet.
+func (e testExpectation) check(wi *types.WorkflowInvocation, runtime *mock.FixtureRuntime) ([]string, error) {
+	var failures []string
+	status := e.Status
+	if len(status) == 0 {
+		status = types.WorkflowInvocationStatus_SUCCEEDED.String()
+		if len(e.Error) > 0 {
+			status = types.WorkflowInvocationStatus_FAILED.String()
+		}
+	}
+	actualStatus := wi.GetStatus().GetStatus().String()
+	if !strings.EqualFold(status, actualStatus) {
+		failure := fmt.Sprintf("expected invocation status %s, but was %s", strings.ToUpper(status), actualStatus)
+		if msg := wi.GetStatus().GetError().GetMessage(); len(msg) > 0 {
+			failure += fmt.Sprintf(" (%s)", msg)
+		}
+		failures = append(failures, failure)
+	}
+	if len(e.Error) > 0 {
+		if msg := wi.GetStatus().GetError().GetMessage(); !strings.Contains(msg, e.Error) {
+			failures = append(failures, fmt.Sprintf("expected error containing '%s', but was '%s'", e.Error, msg))
+		}
+	}
+
+	if e.Output != nil {
+		expected, err := json.Marshal(e.Output)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expected output: %v", err)
+		}
+		var actual []byte
+		if output := wi.GetStatus().GetOutput(); output != nil {
+			i, err := typedvalues.Unwrap(output)
+			if err != nil {
+				return nil, err
+			}
+			if actual, err = json.Marshal(i); err != nil {
+				return nil, err
+			}
+		}
+		if !bytes.Equal(expected, actual) {
+			failures = append(failures, fmt.Sprintf("expected output %s, but was %s", expected, actual))
+		}
+	}
+
+	for _, taskID := range sortedKeys(e.Tasks) {
+		actual := types.TaskInvocationStatus_UNKNOWN.String()
+		if ti, ok := wi.TaskInvocation(taskID); ok {
+			actual = ti.GetStatus().GetStatus().String()
+		}
+		if !strings.EqualFold(e.Tasks[taskID], actual) {
+			failures = append(failures, fmt.Sprintf("expected task %s to be %s, but was %s", taskID,
+				strings.ToUpper(e.Tasks[taskID]), actual))
+		}
+	}
+
+	var fns []string
+	for fn := range e.Calls {
+		fns = append(fns, fn)
+	}
+	sort.Strings(fns)
+	for _, fn := range fns {
+		ref, err := types.ParseFnRef(fn)
+		if err != nil {
+			return nil, fmt.Errorf("invalid function '%s': %v", fn, err)
+		}
+		if _, err := runtime.Resolve(ref); err != nil {
+			return nil, fmt.Errorf("calls can only be counted for mocked functions: %v", err)
+		}
+		if actual := runtime.Calls(ref.ID); actual != e.Calls[fn] {
+			failures = append(failures, fmt.Sprintf("expected %d calls of %s, but was %d", e.Calls[fn], fn, actual))
+		}
+	}
+	return failures, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (r *testResult) print() {
+	switch {
+	case r.err != nil:
+		fmt.Printf("ERROR %s (%s): %v\n", r.testCase.Name, r.testCase.file, r.err)
+	case len(r.failures) > 0:
+		fmt.Printf("FAIL  %s (%s)\n", r.testCase.Name, r.testCase.file)
+		for _, failure := range r.failures {
+			fmt.Printf("      %s\n", failure)
+		}
+	default:
+		fmt.Printf("PASS  %s (%v)\n", r.testCase.Name, r.duration.Round(time.Millisecond))
+	}
+}
+
+//
+// JUnit XML
+//
+
+type junitTestSuite struct {
+	XMLName   xml.Name         `xml:"testsuite"`
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Errors    int              `xml:"errors,attr"`
+	Time      string           `xml:"time,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+}
+
+type junitMessage struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+func (s *junitTestSuite) add(r *testResult) {
+	tc := &junitTestCase{
+		Name:      r.testCase.Name,
+		ClassName: r.testCase.file,
+		Time:      seconds(r.duration),
+	}
+	switch {
+	case r.err != nil:
+		s.Errors++
+		tc.Error = &junitMessage{Message: r.err.Error()}
+	case len(r.failures) > 0:
+		s.Failures++
+		tc.Failure = &junitMessage{
+			Message:  r.failures[0],
+			Contents: strings.Join(r.failures, "\n"),
+		}
+	}
+	s.Tests++
+	s.TestCases = append(s.TestCases, tc)
+}
+
+func writeJUnit(path string, suite *junitTestSuite) error {
+	bs, err := xml.MarshalIndent(suite, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append([]byte(xml.Header), append(bs, '\n')...), 0644)
+}
+
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
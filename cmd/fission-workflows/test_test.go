@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fnenv/mock"
+	"github.com/fission/fission-workflows/pkg/parse"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTestExpectation_Check(t *testing.T) {
+	wi := &types.WorkflowInvocation{
+		Status: &types.WorkflowInvocationStatus{
+			Status: types.WorkflowInvocationStatus_FAILED,
+			Output: typedvalues.MustWrap(map[string]interface{}{"message": "hello"}),
+			Error:  &types.Error{Message: "task 'b' failed: out of fortunes"},
+			Tasks: map[string]*types.TaskInvocation{
+				"a": {Status: &types.TaskInvocationStatus{Status: types.TaskInvocationStatus_SUCCEEDED}},
+				"b": {Status: &types.TaskInvocationStatus{Status: types.TaskInvocationStatus_FAILED}},
+			},
+		},
+	}
+	runtime, err := mock.NewFixtureRuntime([]*mock.Fixture{{Function: "fortune"}})
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		expect   testExpectation
+		failures []string
+		err      bool
+	}{
+		{
+			name:   "status defaults to FAILED if an error is expected",
+			expect: testExpectation{Error: "out of fortunes"},
+		},
+		{
+			name:     "status defaults to SUCCEEDED",
+			expect:   testExpectation{},
+			failures: []string{"expected invocation status SUCCEEDED, but was FAILED (task 'b' failed: out of fortunes)"},
+		},
+		{
+			name:   "status is case-insensitive",
+			expect: testExpectation{Status: "failed"},
+		},
+		{
+			name:     "error",
+			expect:   testExpectation{Error: "asleep"},
+			failures: []string{"expected error containing 'asleep', but was 'task 'b' failed: out of fortunes'"},
+		},
+		{
+			name: "output",
+			expect: testExpectation{
+				Status: "FAILED",
+				Output: map[string]interface{}{"message": "hello"},
+			},
+		},
+		{
+			name:     "different output",
+			expect:   testExpectation{Status: "FAILED", Output: "hello"},
+			failures: []string{`expected output "hello", but was {"message":"hello"}`},
+		},
+		{
+			name: "tasks",
+			expect: testExpectation{
+				Status: "FAILED",
+				Tasks:  map[string]string{"a": "SUCCEEDED", "b": "succeeded", "c": "UNKNOWN", "d": "SKIPPED"},
+			},
+			failures: []string{
+				"expected task b to be SUCCEEDED, but was FAILED",
+				"expected task d to be SKIPPED, but was UNKNOWN",
+			},
+		},
+		{
+			name:     "calls",
+			expect:   testExpectation{Status: "FAILED", Calls: map[string]int{"fortune": 1, "mock://fortune": 0}},
+			failures: []string{"expected 1 calls of fortune, but was 0"},
+		},
+		{
+			name:   "calls of functions that are not mocked",
+			expect: testExpectation{Status: "FAILED", Calls: map[string]int{"whalesay": 0}},
+			err:    true,
+		},
+	}
+	for _, c := range cases {
+		failures, err := c.expect.check(wi, runtime)
+		if c.err {
+			assert.Error(t, err, c.name)
+			continue
+		}
+		assert.NoError(t, err, c.name)
+		assert.Equal(t, c.failures, failures, c.name)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	dir, err := ioutil.TempDir("", "junit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	suite := &junitTestSuite{Name: "whales"}
+	for _, result := range []*testResult{
+		{testCase: &testCase{Name: "pass", file: "a.test.yaml"}, duration: 1500 * time.Millisecond},
+		{testCase: &testCase{Name: "fail", file: "a.test.yaml"}, failures: []string{"first", "second"}},
+		{testCase: &testCase{Name: "error", file: "b.test.yaml"}, err: errors.New("workflow is required")},
+	} {
+		suite.add(result)
+	}
+	path := filepath.Join(dir, "report.xml")
+	assert.NoError(t, writeJUnit(path, suite))
+
+	bs, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(bs), xml.Header)
+	report := &junitTestSuite{}
+	assert.NoError(t, xml.Unmarshal(bs, report))
+	assert.Equal(t, "whales", report.Name)
+	assert.Equal(t, 3, report.Tests)
+	assert.Equal(t, 1, report.Failures)
+	assert.Equal(t, 1, report.Errors)
+	if assert.Len(t, report.TestCases, 3) {
+		assert.Equal(t, &junitTestCase{Name: "pass", ClassName: "a.test.yaml", Time: "1.500"}, report.TestCases[0])
+		assert.Equal(t, &junitMessage{Message: "first", Contents: "first\nsecond"}, report.TestCases[1].Failure)
+		assert.Nil(t, report.TestCases[1].Error)
+		assert.Equal(t, &junitMessage{Message: "workflow is required"}, report.TestCases[2].Error)
+	}
+}
+
+// TestWhaleExamples runs the test cases of the whale examples, so that the examples stay in sync with the engine.
+func TestWhaleExamples(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Short test; skipping the test cases of the examples.")
+	}
+	files, err := findTestCaseFiles("../../examples/whales/test")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+	for _, file := range files {
+		cases, err := loadTestCases(file)
+		if !assert.NoError(t, err, file) {
+			continue
+		}
+		for _, tc := range cases {
+			if tc.Timeout == "" {
+				tc.Timeout = time.Minute.String()
+			}
+			tc.parser = parse.DefaultParser
+			result := tc.run()
+			assert.NoError(t, result.err, tc.Name)
+			assert.Empty(t, result.failures, tc.Name)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/fission/fission-workflows/pkg/apiserver"
+	"github.com/fission/fission-workflows/pkg/parse/yaml"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli"
+)
+
+var cmdTrigger = cli.Command{
+	Name:    "trigger",
+	Aliases: []string{"tr", "triggers"},
+	Usage:   "Trigger-related commands",
+	Subcommands: []cli.Command{
+		{
+			Name:  "create",
+			Usage: "create <workflow-id|name[@revision]> --cron <schedule>",
+			Flags: append([]cli.Flag{
+				cli.StringFlag{
+					Name:  "cron",
+					Usage: "Schedule of the trigger, such as '*/5 * * * *', '@hourly' or '@every 1m'",
+				},
+				cli.StringFlag{
+					Name:  "inputs",
+					Usage: "Sets the inputs of the invocations to provided value. Expects a JSON object.",
+				},
+				cli.DurationFlag{
+					Name:  "timeout",
+					Usage: "Timeout of the invocations started by the trigger",
+				},
+			}, labelFlags...),
+			Action: commandContext(func(ctx Context) error {
+				if !ctx.Args().Present() {
+					logrus.Fatal("Usage: fission-workflows trigger create <workflow-id|name[@revision]> --cron <schedule>")
+				}
+				spec := &types.TriggerSpec{
+					WorkflowId:  ctx.Args().First(),
+					Cron:        ctx.String("cron"),
+					Labels:      parseLabels(ctx, "label"),
+					Annotations: parseLabels(ctx, "annotation"),
+				}
+				if jsonInputs := ctx.String("inputs"); len(jsonInputs) > 0 {
+					inputMap := map[string]interface{}{}
+					err := json.Unmarshal([]byte(jsonInputs), &inputMap)
+					if err != nil {
+						logrus.Fatalf("Failed to parse provided inputs to JSON object: %v", err)
+					}
+					spec.Inputs = typedvalues.MustWrapMapTypedValue(inputMap)
+				}
+				if timeout := ctx.Duration("timeout"); timeout > 0 {
+					spec.Timeout = ptypes.DurationProto(timeout)
+				}
+
+				client := getClient(ctx)
+				md, err := client.Trigger.Create(ctx, spec)
+				if err != nil {
+					logrus.Fatalf("Failed to create trigger: %v", err)
+				}
+				fmt.Println(md.GetId())
+				return nil
+			}),
+		},
+		{
+			Name:  "get",
+			Usage: "get <trigger-id>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "selector, l",
+					Usage: "Only list the triggers that match the label selector.",
+				},
+			},
+			Action: commandContext(func(ctx Context) error {
+				client := getClient(ctx)
+
+				if ctx.Args().Present() {
+					triggerID := ctx.Args().First()
+					trigger, err := client.Trigger.Get(ctx, triggerID)
+					if err != nil {
+						logrus.Fatalf("Failed to get trigger %s: %v", triggerID, err)
+					}
+					b, err := yaml.Marshal(trigger)
+					if err != nil {
+						panic(err)
+					}
+					fmt.Printf("%v\n", string(b))
+					return nil
+				}
+
+				// List triggers
+				resp, err := client.Trigger.List(ctx, &apiserver.TriggerListQuery{
+					LabelSelector: ctx.String("selector"),
+				})
+				if err != nil {
+					logrus.Fatalf("Failed to list triggers: %v", err)
+				}
+				triggerIDs := resp.GetTriggers()
+				sort.Strings(triggerIDs)
+				var rows [][]string
+				for _, triggerID := range triggerIDs {
+					trigger, err := client.Trigger.Get(ctx, triggerID)
+					if err != nil {
+						logrus.Fatalf("Failed to get trigger %s: %v", triggerID, err)
+					}
+					var lastFired string
+					if trigger.GetStatus().GetLastFiredAt() != nil {
+						ts, _ := ptypes.Timestamp(trigger.GetStatus().GetLastFiredAt())
+						lastFired = ts.String()
+					}
+					rows = append(rows, []string{triggerID, trigger.GetSpec().GetWorkflowId(),
+						trigger.GetSpec().GetCron(), lastFired, trigger.GetStatus().GetLastInvocationId()})
+				}
+				table(os.Stdout, []string{"ID", "WORKFLOW", "CRON", "LAST FIRED", "LAST INVOCATION"}, rows)
+				return nil
+			}),
+		},
+		{
+			Name:  "delete",
+			Usage: "delete <trigger-id...>",
+			Action: commandContext(func(ctx Context) error {
+				if !ctx.Args().Present() {
+					logrus.Fatal("Usage: fission-workflows trigger delete <trigger-id...>")
+				}
+				client := getClient(ctx)
+				for _, triggerID := range ctx.Args() {
+					if err := client.Trigger.Delete(ctx, triggerID); err != nil {
+						logrus.Fatalf("Failed to delete %s: %v", triggerID, err)
+					}
+					fmt.Println(triggerID)
+				}
+				return nil
+			}),
+		},
+	},
+}
@@ -5,7 +5,6 @@ import (
 	"os"
 
 	"github.com/fission/fission-workflows/pkg/parse/protobuf"
-	"github.com/fission/fission-workflows/pkg/parse/yaml"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/golang/protobuf/jsonpb"
 
@@ -32,7 +31,7 @@ var cmdValidate = cli.Command{
 
 		var failed bool
 		for _, path := range ctx.Args() {
-			if err := validateWorkflowDefinition(path, ctx.String("type")); err != nil {
+			if err := validateWorkflowDefinition(ctx, path, ctx.String("type")); err != nil {
 				if _, err := fmt.Fprintf(os.Stderr, "%s: %s\n", path, err.Error()); err != nil {
 					panic(err)
 				}
@@ -48,7 +47,7 @@ var cmdValidate = cli.Command{
 	}),
 }
 
-func validateWorkflowDefinition(path string, fType string) error {
+func validateWorkflowDefinition(ctx Context, path string, fType string) error {
 	// Get file
 	file, err := os.Open(path)
 	defer file.Close()
@@ -60,7 +59,7 @@ func validateWorkflowDefinition(path string, fType string) error {
 	var spec *types.WorkflowSpec
 	switch fType {
 	case "yaml":
-		spec, err = yaml.Parse(file)
+		spec, err = ctx.YAMLParser().Parse(file)
 		if err != nil {
 			return fmt.Errorf("failed to parse yaml definition: %v", err)
 		}
@@ -4,10 +4,14 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 
 	"github.com/blang/semver"
-	"github.com/fission/fission-workflows/pkg/parse"
+	"github.com/fission/fission-workflows/pkg/apiserver"
 	"github.com/fission/fission-workflows/pkg/parse/yaml"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/graph"
+	"github.com/fission/fission-workflows/pkg/util/labels"
 	"github.com/golang/protobuf/jsonpb"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus"
@@ -22,7 +26,7 @@ var cmdWorkflow = cli.Command{
 		{
 			Name:  "create",
 			Usage: "Define a workflow within the workflow engine.",
-			Flags: []cli.Flag{
+			Flags: append([]cli.Flag{
 				cli.StringFlag{
 					Name:  "src",
 					Usage: "Path to the YAML or Protobuf workflow definition file",
@@ -31,24 +35,15 @@ var cmdWorkflow = cli.Command{
 					Name:  "name",
 					Usage: "Name of the workflow",
 				},
-			},
+			}, labelFlags...),
 			Action: commandContext(func(ctx Context) error {
 				client := getClient(ctx)
 
 				// Fetch and parse the workflow
-				srcPath := ctx.String("src")
-				if len(srcPath) == 0 {
-					logrus.Fatalf("Requires workflow definition file. Use `--src <file>`.")
-				}
-				fd, err := os.Open(srcPath)
-				if err != nil {
-					logrus.Fatalf("Failed to open workflow definition file: %v", err)
-				}
-				spec, err := parse.Parse(fd)
-				if err != nil {
-					logrus.Fatal(err)
-				}
+				spec := parseWorkflowFile(ctx, ctx.String("src"))
 				spec.Name = ctx.String("name")
+				spec.Labels = labels.Merge(spec.Labels, parseLabels(ctx, "label"))
+				spec.Annotations = labels.Merge(spec.Annotations, parseLabels(ctx, "annotation"))
 
 				// Create workflow
 				md, err := client.Workflow.CreateSync(ctx, spec)
@@ -60,6 +55,62 @@ var cmdWorkflow = cli.Command{
 				return nil
 			}),
 		},
+		{
+			Name:  "update",
+			Usage: "Create a new revision of a named workflow within the workflow engine.",
+			Flags: append([]cli.Flag{
+				cli.StringFlag{
+					Name:  "src",
+					Usage: "Path to the YAML or Protobuf workflow definition file",
+				},
+				cli.StringFlag{
+					Name:  "name",
+					Usage: "Name of the workflow",
+				},
+			}, labelFlags...),
+			Action: commandContext(func(ctx Context) error {
+				name := ctx.String("name")
+				if len(name) == 0 {
+					logrus.Fatalf("Requires the name of the workflow to update. Use `--name <name>`.")
+				}
+				client := getClient(ctx)
+				spec := parseWorkflowFile(ctx, ctx.String("src"))
+				spec.Name = name
+				spec.Labels = labels.Merge(spec.Labels, parseLabels(ctx, "label"))
+				spec.Annotations = labels.Merge(spec.Annotations, parseLabels(ctx, "annotation"))
+
+				md, err := client.Workflow.Update(ctx, spec)
+				if err != nil {
+					logrus.Fatalf("Failed to update workflow: %v", err)
+				}
+				fmt.Println(md.GetId())
+				return nil
+			}),
+		},
+		{
+			Name:  "history",
+			Usage: "history <workflow-name>",
+			Action: commandContext(func(ctx Context) error {
+				if !ctx.Args().Present() {
+					logrus.Fatal("Usage: fission-workflows workflow history <workflow-name>")
+				}
+				client := getClient(ctx)
+				name := ctx.Args().First()
+
+				history, err := client.Workflow.History(ctx, name)
+				if err != nil {
+					logrus.Fatalf("Failed to retrieve history of %s: %v", name, err)
+				}
+				var rows [][]string
+				for _, wf := range history.GetRevisions() {
+					created, _ := ptypes.Timestamp(wf.GetMetadata().GetCreatedAt())
+					rows = append(rows, []string{fmt.Sprintf("%d", wf.GetMetadata().GetRevision()), wf.ID(),
+						wf.GetStatus().GetStatus().String(), created.String()})
+				}
+				table(os.Stdout, []string{"REVISION", "ID", "STATUS", "CREATED"}, rows)
+				return nil
+			}),
+		},
 		{
 			Name:  "delete",
 			Usage: "Delete workflow within the workflow engine.",
@@ -105,16 +156,59 @@ var cmdWorkflow = cli.Command{
 				return nil
 			}),
 		},
+		{
+			Name:  "graph",
+			Usage: "graph <workflow-id|name[@revision]|file>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "format, f",
+					Value: graph.FormatDot,
+					Usage: "Format of the graph [dot|mermaid|json]",
+				},
+			},
+			Action: commandContext(func(ctx Context) error {
+				if !ctx.Args().Present() {
+					logrus.Fatal("Usage: fission-workflows workflow graph <workflow-id|name[@revision]|file>")
+				}
+				ref := ctx.Args().First()
+
+				// Workflow definition files are rendered locally, which does not require the workflow engine.
+				if _, err := os.Stat(ref); err == nil {
+					content, err := graph.NewWorkflowDiagram(parseWorkflowFile(ctx, ref)).Render(ctx.String("format"))
+					if err != nil {
+						logrus.Fatal(err)
+					}
+					fmt.Println(strings.TrimSpace(content))
+					return nil
+				}
+
+				client := getClient(ctx)
+				result, err := client.Workflow.Graph(ctx, ref, ctx.String("format"))
+				if err != nil {
+					logrus.Fatalf("Failed to retrieve graph of %s: %v", ref, err)
+				}
+				fmt.Println(strings.TrimSpace(result.GetContent()))
+				return nil
+			}),
+		},
 		{
 			Name:  "get",
-			Usage: "get <Workflow-id> <task-id>",
+			Usage: "get <workflow-id|name[@revision]> <task-id>",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  "selector, l",
+					Usage: "Only list the workflows that match the label selector, such as 'team=payments'.",
+				},
+			},
 			Action: commandContext(func(ctx Context) error {
 				client := getClient(ctx)
 
 				switch ctx.NArg() {
 				case 0:
 					// List workflows
-					resp, err := client.Workflow.List(ctx)
+					resp, err := client.Workflow.List(ctx, &apiserver.WorkflowListQuery{
+						LabelSelector: ctx.String("selector"),
+					})
 					if err != nil {
 						panic(err)
 					}
@@ -129,10 +223,14 @@ var cmdWorkflow = cli.Command{
 						updated, _ := ptypes.Timestamp(wf.Status.UpdatedAt)
 						created, _ := ptypes.Timestamp(wf.Metadata.CreatedAt)
 
-						rows = append(rows, []string{wfID, wf.Spec.Name, wf.Status.Status.String(),
+						var revision string
+						if wf.GetMetadata().GetRevision() > 0 {
+							revision = fmt.Sprintf("%d", wf.GetMetadata().GetRevision())
+						}
+						rows = append(rows, []string{wfID, wf.Spec.Name, revision, wf.Status.Status.String(),
 							created.String(), updated.String()})
 					}
-					table(os.Stdout, []string{"ID", "NAME", "STATUS", "CREATED", "UPDATED"}, rows)
+					table(os.Stdout, []string{"ID", "NAME", "REVISION", "STATUS", "CREATED", "UPDATED"}, rows)
 				case 1:
 					// Get Workflow
 					wfID := ctx.Args().Get(0)
@@ -172,3 +270,20 @@ var cmdWorkflow = cli.Command{
 		},
 	},
 }
+
+// parseWorkflowFile reads and parses the workflow definition file at the path, exiting if that fails.
+func parseWorkflowFile(ctx Context, srcPath string) *types.WorkflowSpec {
+	if len(srcPath) == 0 {
+		logrus.Fatalf("Requires workflow definition file. Use `--src <file>`.")
+	}
+	fd, err := os.Open(srcPath)
+	if err != nil {
+		logrus.Fatalf("Failed to open workflow definition file: %v", err)
+	}
+	defer fd.Close()
+	spec, err := ctx.Parser().Parse(fd)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	return spec
+}
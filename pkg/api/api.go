@@ -5,13 +5,15 @@ import (
 	"errors"
 	"time"
 
+	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/types"
 )
 
 type CallConfig struct {
-	ctx             context.Context
-	postTransformer func(i interface{}) error
-	awaitWorkflow   time.Duration
+	ctx                context.Context
+	postTransformer    func(i interface{}) error
+	awaitWorkflow      time.Duration
+	expectedGeneration int64
 }
 
 type CallOption func(op *CallConfig)
@@ -37,7 +39,8 @@ func PostTransformer(fn func(ti *types.TaskInvocation) error) CallOption {
 func parseCallOptions(opts []CallOption) *CallConfig {
 	// Default
 	cfg := &CallConfig{
-		ctx: context.Background(),
+		ctx:                context.Background(),
+		expectedGeneration: fes.AnyGeneration,
 	}
 	// Parse options
 	for _, opt := range opts {
@@ -51,3 +54,16 @@ func AwaitWorklow(timeout time.Duration) CallOption {
 		config.awaitWorkflow = timeout
 	}
 }
+
+// ExpectGeneration rejects the call with a fes.ErrGenerationConflict if the entity that it modifies no longer has the
+// generation that the caller observed. This prevents concurrent callers, such as the controllers of multiple
+// replicas, from both acting on the same state of the entity.
+func ExpectGeneration(generation int64) CallOption {
+	return func(config *CallConfig) {
+		config.expectedGeneration = generation
+	}
+}
+
+func (cfg *CallConfig) appendOptions() []fes.AppendOption {
+	return []fes.AppendOption{fes.ExpectGeneration(cfg.expectedGeneration)}
+}
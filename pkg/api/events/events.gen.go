@@ -11,19 +11,28 @@ type Event interface {
 }
 
 const (
-	EventWorkflowCreated       EventType = "WorkflowCreated"
-	EventWorkflowDeleted       EventType = "WorkflowDeleted"
-	EventWorkflowParsed        EventType = "WorkflowParsed"
-	EventWorkflowParsingFailed EventType = "WorkflowParsingFailed"
-	EventInvocationCreated     EventType = "InvocationCreated"
-	EventInvocationCompleted   EventType = "InvocationCompleted"
-	EventInvocationCanceled    EventType = "InvocationCanceled"
-	EventInvocationTaskAdded   EventType = "InvocationTaskAdded"
-	EventInvocationFailed      EventType = "InvocationFailed"
-	EventTaskStarted           EventType = "TaskStarted"
-	EventTaskSucceeded         EventType = "TaskSucceeded"
-	EventTaskSkipped           EventType = "TaskSkipped"
-	EventTaskFailed            EventType = "TaskFailed"
+	EventWorkflowCreated          EventType = "WorkflowCreated"
+	EventWorkflowDeleted          EventType = "WorkflowDeleted"
+	EventWorkflowParsed           EventType = "WorkflowParsed"
+	EventWorkflowParsingFailed    EventType = "WorkflowParsingFailed"
+	EventWorkflowRevisionReserved EventType = "WorkflowRevisionReserved"
+	EventInvocationCreated        EventType = "InvocationCreated"
+	EventInvocationCompleted      EventType = "InvocationCompleted"
+	EventInvocationCanceled       EventType = "InvocationCanceled"
+	EventInvocationTaskAdded      EventType = "InvocationTaskAdded"
+	EventInvocationFailed         EventType = "InvocationFailed"
+	EventInvocationRetried        EventType = "InvocationRetried"
+	EventTriggerCreated           EventType = "TriggerCreated"
+	EventTriggerDeleted           EventType = "TriggerDeleted"
+	EventTriggerFired             EventType = "TriggerFired"
+	EventTriggerInvoked           EventType = "TriggerInvoked"
+	EventTaskStarted              EventType = "TaskStarted"
+	EventTaskSucceeded            EventType = "TaskSucceeded"
+	EventTaskSkipped              EventType = "TaskSkipped"
+	EventTaskFailed               EventType = "TaskFailed"
+	EventTaskRetried              EventType = "TaskRetried"
+	EventTaskAwaitingSignal       EventType = "TaskAwaitingSignal"
+	EventTaskSignaled             EventType = "TaskSignaled"
 )
 
 func (m *WorkflowCreated) Type() EventType {
@@ -42,6 +51,10 @@ func (m *WorkflowParsingFailed) Type() EventType {
 	return EventWorkflowParsingFailed
 }
 
+func (m *WorkflowRevisionReserved) Type() EventType {
+	return EventWorkflowRevisionReserved
+}
+
 func (m *InvocationCreated) Type() EventType {
 	return EventInvocationCreated
 }
@@ -62,6 +75,26 @@ func (m *InvocationFailed) Type() EventType {
 	return EventInvocationFailed
 }
 
+func (m *InvocationRetried) Type() EventType {
+	return EventInvocationRetried
+}
+
+func (m *TriggerCreated) Type() EventType {
+	return EventTriggerCreated
+}
+
+func (m *TriggerDeleted) Type() EventType {
+	return EventTriggerDeleted
+}
+
+func (m *TriggerFired) Type() EventType {
+	return EventTriggerFired
+}
+
+func (m *TriggerInvoked) Type() EventType {
+	return EventTriggerInvoked
+}
+
 func (m *TaskStarted) Type() EventType {
 	return EventTaskStarted
 }
@@ -77,3 +110,15 @@ func (m *TaskSkipped) Type() EventType {
 func (m *TaskFailed) Type() EventType {
 	return EventTaskFailed
 }
+
+func (m *TaskRetried) Type() EventType {
+	return EventTaskRetried
+}
+
+func (m *TaskAwaitingSignal) Type() EventType {
+	return EventTaskAwaitingSignal
+}
+
+func (m *TaskSignaled) Type() EventType {
+	return EventTaskSignaled
+}
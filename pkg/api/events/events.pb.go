@@ -12,15 +12,24 @@ It has these top-level messages:
 	WorkflowDeleted
 	WorkflowParsed
 	WorkflowParsingFailed
+	WorkflowRevisionReserved
 	InvocationCreated
 	InvocationCompleted
 	InvocationCanceled
 	InvocationTaskAdded
 	InvocationFailed
+	InvocationRetried
+	TriggerCreated
+	TriggerDeleted
+	TriggerFired
+	TriggerInvoked
 	TaskStarted
 	TaskSucceeded
 	TaskSkipped
 	TaskFailed
+	TaskRetried
+	TaskAwaitingSignal
+	TaskSignaled
 */
 package events
 
@@ -29,6 +38,7 @@ import fmt "fmt"
 import math "math"
 import fission_workflows_types1 "github.com/fission/fission-workflows/pkg/types"
 import fission_workflows_types "github.com/fission/fission-workflows/pkg/types/typedvalues"
+import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -43,6 +53,8 @@ const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
 type WorkflowCreated struct {
 	Spec *fission_workflows_types1.WorkflowSpec `protobuf:"bytes,1,opt,name=spec" json:"spec,omitempty"`
+	// Revision is the revision of the named workflow that this workflow represents.
+	Revision int64 `protobuf:"varint,2,opt,name=revision" json:"revision,omitempty"`
 }
 
 func (m *WorkflowCreated) Reset()                    { *m = WorkflowCreated{} }
@@ -57,6 +69,13 @@ func (m *WorkflowCreated) GetSpec() *fission_workflows_types1.WorkflowSpec {
 	return nil
 }
 
+func (m *WorkflowCreated) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
 type WorkflowDeleted struct {
 }
 
@@ -97,6 +116,32 @@ func (m *WorkflowParsingFailed) GetError() *fission_workflows_types1.Error {
 	return nil
 }
 
+// WorkflowRevisionReserved is appended to the stream of a workflow name to claim the next revision of the workflow.
+type WorkflowRevisionReserved struct {
+	Revision int64 `protobuf:"varint,1,opt,name=revision" json:"revision,omitempty"`
+	// WorkflowId is the id of the workflow that represents the revision.
+	WorkflowId string `protobuf:"bytes,2,opt,name=workflowId" json:"workflowId,omitempty"`
+}
+
+func (m *WorkflowRevisionReserved) Reset()                    { *m = WorkflowRevisionReserved{} }
+func (m *WorkflowRevisionReserved) String() string            { return proto.CompactTextString(m) }
+func (*WorkflowRevisionReserved) ProtoMessage()               {}
+func (*WorkflowRevisionReserved) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *WorkflowRevisionReserved) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *WorkflowRevisionReserved) GetWorkflowId() string {
+	if m != nil {
+		return m.WorkflowId
+	}
+	return ""
+}
+
 type InvocationCreated struct {
 	Spec *fission_workflows_types1.WorkflowInvocationSpec `protobuf:"bytes,1,opt,name=spec" json:"spec,omitempty"`
 }
@@ -104,7 +149,7 @@ type InvocationCreated struct {
 func (m *InvocationCreated) Reset()                    { *m = InvocationCreated{} }
 func (m *InvocationCreated) String() string            { return proto.CompactTextString(m) }
 func (*InvocationCreated) ProtoMessage()               {}
-func (*InvocationCreated) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*InvocationCreated) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *InvocationCreated) GetSpec() *fission_workflows_types1.WorkflowInvocationSpec {
 	if m != nil {
@@ -121,7 +166,7 @@ type InvocationCompleted struct {
 func (m *InvocationCompleted) Reset()                    { *m = InvocationCompleted{} }
 func (m *InvocationCompleted) String() string            { return proto.CompactTextString(m) }
 func (*InvocationCompleted) ProtoMessage()               {}
-func (*InvocationCompleted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*InvocationCompleted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *InvocationCompleted) GetOutput() *fission_workflows_types.TypedValue {
 	if m != nil {
@@ -144,7 +189,7 @@ type InvocationCanceled struct {
 func (m *InvocationCanceled) Reset()                    { *m = InvocationCanceled{} }
 func (m *InvocationCanceled) String() string            { return proto.CompactTextString(m) }
 func (*InvocationCanceled) ProtoMessage()               {}
-func (*InvocationCanceled) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*InvocationCanceled) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *InvocationCanceled) GetError() *fission_workflows_types1.Error {
 	if m != nil {
@@ -160,7 +205,7 @@ type InvocationTaskAdded struct {
 func (m *InvocationTaskAdded) Reset()                    { *m = InvocationTaskAdded{} }
 func (m *InvocationTaskAdded) String() string            { return proto.CompactTextString(m) }
 func (*InvocationTaskAdded) ProtoMessage()               {}
-func (*InvocationTaskAdded) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*InvocationTaskAdded) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *InvocationTaskAdded) GetTask() *fission_workflows_types1.Task {
 	if m != nil {
@@ -176,7 +221,7 @@ type InvocationFailed struct {
 func (m *InvocationFailed) Reset()                    { *m = InvocationFailed{} }
 func (m *InvocationFailed) String() string            { return proto.CompactTextString(m) }
 func (*InvocationFailed) ProtoMessage()               {}
-func (*InvocationFailed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*InvocationFailed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *InvocationFailed) GetError() *fission_workflows_types1.Error {
 	if m != nil {
@@ -185,7 +230,93 @@ func (m *InvocationFailed) GetError() *fission_workflows_types1.Error {
 	return nil
 }
 
-//
+// InvocationRetried resumes a failed or aborted invocation. The failed, aborted and skipped task runs are discarded,
+// whereas the outputs of the succeeded task runs are reused.
+type InvocationRetried struct {
+	// Deadline optionally replaces the deadline of the invocation.
+	Deadline *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=deadline" json:"deadline,omitempty"`
+}
+
+func (m *InvocationRetried) Reset()                    { *m = InvocationRetried{} }
+func (m *InvocationRetried) String() string            { return proto.CompactTextString(m) }
+func (*InvocationRetried) ProtoMessage()               {}
+func (*InvocationRetried) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+
+func (m *InvocationRetried) GetDeadline() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.Deadline
+	}
+	return nil
+}
+
+type TriggerCreated struct {
+	Spec *fission_workflows_types1.TriggerSpec `protobuf:"bytes,1,opt,name=spec" json:"spec,omitempty"`
+}
+
+func (m *TriggerCreated) Reset()                    { *m = TriggerCreated{} }
+func (m *TriggerCreated) String() string            { return proto.CompactTextString(m) }
+func (*TriggerCreated) ProtoMessage()               {}
+func (*TriggerCreated) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *TriggerCreated) GetSpec() *fission_workflows_types1.TriggerSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+type TriggerDeleted struct {
+}
+
+func (m *TriggerDeleted) Reset()                    { *m = TriggerDeleted{} }
+func (m *TriggerDeleted) String() string            { return proto.CompactTextString(m) }
+func (*TriggerDeleted) ProtoMessage()               {}
+func (*TriggerDeleted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+// TriggerFired records that the trigger fired for the given scheduled time.
+// TriggerFired claims the firing of the trigger for the scheduled time. The invocation that the trigger started is
+// recorded by a subsequent TriggerInvoked event.
+type TriggerFired struct {
+	ScheduledAt *google_protobuf.Timestamp `protobuf:"bytes,1,opt,name=scheduledAt" json:"scheduledAt,omitempty"`
+}
+
+func (m *TriggerFired) Reset()                    { *m = TriggerFired{} }
+func (m *TriggerFired) String() string            { return proto.CompactTextString(m) }
+func (*TriggerFired) ProtoMessage()               {}
+func (*TriggerFired) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+
+func (m *TriggerFired) GetScheduledAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.ScheduledAt
+	}
+	return nil
+}
+
+type TriggerInvoked struct {
+	// InvocationId is the id of the invocation that was started; it is empty if the invocation could not be started.
+	InvocationId string                          `protobuf:"bytes,1,opt,name=invocationId" json:"invocationId,omitempty"`
+	Error        *fission_workflows_types1.Error `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *TriggerInvoked) Reset()                    { *m = TriggerInvoked{} }
+func (m *TriggerInvoked) String() string            { return proto.CompactTextString(m) }
+func (*TriggerInvoked) ProtoMessage()               {}
+func (*TriggerInvoked) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+
+func (m *TriggerInvoked) GetInvocationId() string {
+	if m != nil {
+		return m.InvocationId
+	}
+	return ""
+}
+
+func (m *TriggerInvoked) GetError() *fission_workflows_types1.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 // Task
 //
 // TODO why do we need task, and not just task spec.
@@ -196,7 +327,7 @@ type TaskStarted struct {
 func (m *TaskStarted) Reset()                    { *m = TaskStarted{} }
 func (m *TaskStarted) String() string            { return proto.CompactTextString(m) }
 func (*TaskStarted) ProtoMessage()               {}
-func (*TaskStarted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*TaskStarted) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *TaskStarted) GetSpec() *fission_workflows_types1.TaskInvocationSpec {
 	if m != nil {
@@ -212,7 +343,7 @@ type TaskSucceeded struct {
 func (m *TaskSucceeded) Reset()                    { *m = TaskSucceeded{} }
 func (m *TaskSucceeded) String() string            { return proto.CompactTextString(m) }
 func (*TaskSucceeded) ProtoMessage()               {}
-func (*TaskSucceeded) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*TaskSucceeded) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *TaskSucceeded) GetResult() *fission_workflows_types1.TaskInvocationStatus {
 	if m != nil {
@@ -227,7 +358,7 @@ type TaskSkipped struct {
 func (m *TaskSkipped) Reset()                    { *m = TaskSkipped{} }
 func (m *TaskSkipped) String() string            { return proto.CompactTextString(m) }
 func (*TaskSkipped) ProtoMessage()               {}
-func (*TaskSkipped) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*TaskSkipped) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 type TaskFailed struct {
 	Error *fission_workflows_types1.Error `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
@@ -236,7 +367,7 @@ type TaskFailed struct {
 func (m *TaskFailed) Reset()                    { *m = TaskFailed{} }
 func (m *TaskFailed) String() string            { return proto.CompactTextString(m) }
 func (*TaskFailed) ProtoMessage()               {}
-func (*TaskFailed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*TaskFailed) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *TaskFailed) GetError() *fission_workflows_types1.Error {
 	if m != nil {
@@ -245,55 +376,132 @@ func (m *TaskFailed) GetError() *fission_workflows_types1.Error {
 	return nil
 }
 
+// TaskRetried resets a failed task, allowing it to be scheduled again. The error contains the cause of the failed
+// attempt, which is no longer tracked in the status of the task.
+type TaskRetried struct {
+	Error   *fission_workflows_types1.Error `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	Attempt int32                           `protobuf:"varint,2,opt,name=attempt" json:"attempt,omitempty"`
+}
+
+func (m *TaskRetried) Reset()                    { *m = TaskRetried{} }
+func (m *TaskRetried) String() string            { return proto.CompactTextString(m) }
+func (*TaskRetried) ProtoMessage()               {}
+func (*TaskRetried) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *TaskRetried) GetError() *fission_workflows_types1.Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *TaskRetried) GetAttempt() int32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+// TaskAwaitingSignal records that the function of the task has returned without completing the task, such as the wait
+// function, after which the task can be completed by a TaskSignaled.
+type TaskAwaitingSignal struct {
+}
+
+func (m *TaskAwaitingSignal) Reset()                    { *m = TaskAwaitingSignal{} }
+func (m *TaskAwaitingSignal) String() string            { return proto.CompactTextString(m) }
+func (*TaskAwaitingSignal) ProtoMessage()               {}
+func (*TaskAwaitingSignal) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+
+// TaskSignaled provides the signal to a task that is awaiting one, such as the wait function. The payload of the signal
+// becomes the output of the task, which is completed once the output has been transformed with a TaskSucceeded.
+type TaskSignaled struct {
+	Payload *fission_workflows_types.TypedValue `protobuf:"bytes,1,opt,name=payload" json:"payload,omitempty"`
+}
+
+func (m *TaskSignaled) Reset()                    { *m = TaskSignaled{} }
+func (m *TaskSignaled) String() string            { return proto.CompactTextString(m) }
+func (*TaskSignaled) ProtoMessage()               {}
+func (*TaskSignaled) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+
+func (m *TaskSignaled) GetPayload() *fission_workflows_types.TypedValue {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*WorkflowCreated)(nil), "fission.workflows.events.WorkflowCreated")
 	proto.RegisterType((*WorkflowDeleted)(nil), "fission.workflows.events.WorkflowDeleted")
 	proto.RegisterType((*WorkflowParsed)(nil), "fission.workflows.events.WorkflowParsed")
 	proto.RegisterType((*WorkflowParsingFailed)(nil), "fission.workflows.events.WorkflowParsingFailed")
+	proto.RegisterType((*WorkflowRevisionReserved)(nil), "fission.workflows.events.WorkflowRevisionReserved")
 	proto.RegisterType((*InvocationCreated)(nil), "fission.workflows.events.InvocationCreated")
 	proto.RegisterType((*InvocationCompleted)(nil), "fission.workflows.events.InvocationCompleted")
 	proto.RegisterType((*InvocationCanceled)(nil), "fission.workflows.events.InvocationCanceled")
 	proto.RegisterType((*InvocationTaskAdded)(nil), "fission.workflows.events.InvocationTaskAdded")
 	proto.RegisterType((*InvocationFailed)(nil), "fission.workflows.events.InvocationFailed")
+	proto.RegisterType((*InvocationRetried)(nil), "fission.workflows.events.InvocationRetried")
+	proto.RegisterType((*TriggerCreated)(nil), "fission.workflows.events.TriggerCreated")
+	proto.RegisterType((*TriggerDeleted)(nil), "fission.workflows.events.TriggerDeleted")
+	proto.RegisterType((*TriggerFired)(nil), "fission.workflows.events.TriggerFired")
+	proto.RegisterType((*TriggerInvoked)(nil), "fission.workflows.events.TriggerInvoked")
 	proto.RegisterType((*TaskStarted)(nil), "fission.workflows.events.TaskStarted")
 	proto.RegisterType((*TaskSucceeded)(nil), "fission.workflows.events.TaskSucceeded")
 	proto.RegisterType((*TaskSkipped)(nil), "fission.workflows.events.TaskSkipped")
 	proto.RegisterType((*TaskFailed)(nil), "fission.workflows.events.TaskFailed")
+	proto.RegisterType((*TaskRetried)(nil), "fission.workflows.events.TaskRetried")
+	proto.RegisterType((*TaskAwaitingSignal)(nil), "fission.workflows.events.TaskAwaitingSignal")
+	proto.RegisterType((*TaskSignaled)(nil), "fission.workflows.events.TaskSignaled")
 }
 
 func init() { proto.RegisterFile("pkg/api/events/events.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 488 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0xdf, 0x6a, 0xd4, 0x40,
-	0x14, 0xc6, 0x49, 0xdb, 0x5d, 0xf4, 0x2c, 0xab, 0xed, 0x88, 0xb0, 0xac, 0x28, 0x25, 0x22, 0x14,
-	0xa4, 0x09, 0xb6, 0x5e, 0xd8, 0x7a, 0x21, 0xb6, 0xae, 0xec, 0x4a, 0xfd, 0x43, 0x2a, 0x55, 0x04,
-	0x2f, 0xa6, 0x99, 0xd3, 0x35, 0x24, 0xcd, 0x0c, 0x33, 0x93, 0x2d, 0xfb, 0x30, 0xbe, 0x88, 0x4f,
-	0x27, 0x93, 0x99, 0x98, 0x04, 0xdd, 0xad, 0x74, 0x6f, 0x36, 0x43, 0xf6, 0x7c, 0x3f, 0xce, 0xf7,
-	0x9d, 0x33, 0x81, 0x07, 0x22, 0x9d, 0x86, 0x54, 0x24, 0x21, 0xce, 0x30, 0xd7, 0xca, 0x3d, 0x02,
-	0x21, 0xb9, 0xe6, 0x64, 0x70, 0x91, 0x28, 0x95, 0xf0, 0x3c, 0xb8, 0xe2, 0x32, 0xbd, 0xc8, 0xf8,
-	0x95, 0x0a, 0xec, 0xff, 0xc3, 0xc3, 0x69, 0xa2, 0x7f, 0x14, 0xe7, 0x41, 0xcc, 0x2f, 0x43, 0x57,
-	0x54, 0x3d, 0x77, 0xff, 0x14, 0x87, 0x86, 0xad, 0xe7, 0x02, 0x95, 0xfd, 0xb5, 0xd4, 0xe1, 0xc9,
-	0x0d, 0xb4, 0x6c, 0x46, 0xb3, 0xa2, 0x7d, 0xb6, 0x34, 0xff, 0x04, 0xee, 0x7e, 0x71, 0xa2, 0x63,
-	0x89, 0x54, 0x23, 0x23, 0x07, 0xb0, 0xa1, 0x04, 0xc6, 0x03, 0x6f, 0xdb, 0xdb, 0xe9, 0xed, 0x3d,
-	0x09, 0xfe, 0x76, 0x61, 0xdb, 0xa9, 0x74, 0xa7, 0x02, 0xe3, 0xa8, 0x94, 0xf8, 0x5b, 0x35, 0xed,
-	0x0d, 0x66, 0xa8, 0x91, 0xf9, 0xbf, 0x3c, 0xb8, 0x53, 0xbd, 0xfb, 0x44, 0xa5, 0x42, 0x46, 0x26,
-	0xd0, 0xd1, 0x54, 0xa5, 0x6a, 0xe0, 0x6d, 0xaf, 0xef, 0xf4, 0xf6, 0xf6, 0x83, 0x45, 0x39, 0x05,
-	0x6d, 0x61, 0xf0, 0xd9, 0xa8, 0x46, 0xb9, 0x96, 0xf3, 0xc8, 0x12, 0x86, 0xdf, 0x01, 0xea, 0x97,
-	0x64, 0x13, 0xd6, 0x53, 0x9c, 0x97, 0x8d, 0xdf, 0x8e, 0xcc, 0x91, 0x1c, 0x40, 0xa7, 0xb4, 0x3b,
-	0x58, 0x2b, 0xcd, 0x3c, 0x5e, 0x68, 0xc6, 0x50, 0x4e, 0x35, 0xd5, 0x85, 0x8a, 0xac, 0xe2, 0x70,
-	0xed, 0x85, 0xe7, 0xbf, 0x87, 0xfb, 0xcd, 0x16, 0x92, 0x7c, 0xfa, 0x96, 0x26, 0x19, 0x32, 0xf2,
-	0x1c, 0x3a, 0x28, 0x25, 0x97, 0x2e, 0xa4, 0x47, 0x0b, 0xb9, 0x23, 0x53, 0x15, 0xd9, 0x62, 0xff,
-	0x2b, 0x6c, 0x4d, 0xf2, 0x19, 0x8f, 0xa9, 0x4e, 0x78, 0x5e, 0xc5, 0x7d, 0xdc, 0x8a, 0x3b, 0xbc,
-	0x36, 0xee, 0x9a, 0xd0, 0x08, 0xfe, 0xa7, 0x07, 0xf7, 0x1a, 0x68, 0x7e, 0x29, 0xca, 0xf4, 0xc9,
-	0x4b, 0xe8, 0xf2, 0x42, 0x8b, 0x42, 0x3b, 0xfc, 0x92, 0x00, 0xcc, 0x6a, 0x9c, 0x19, 0xe7, 0x91,
-	0x93, 0x90, 0x09, 0xf4, 0x3f, 0x96, 0xa7, 0x31, 0x52, 0x86, 0x52, 0x5d, 0x1f, 0x62, 0xcd, 0x68,
-	0x2b, 0xfd, 0x77, 0x40, 0x1a, 0xed, 0xd1, 0x3c, 0xc6, 0x9b, 0xa7, 0x38, 0x6e, 0x5a, 0x35, 0x73,
-	0x7b, 0xcd, 0x18, 0x32, 0xf2, 0x0c, 0x36, 0xcc, 0x4e, 0x38, 0xd6, 0xc3, 0xa5, 0x93, 0x8e, 0xca,
-	0x52, 0x7f, 0x0c, 0x9b, 0x35, 0x69, 0xa5, 0xc9, 0x7e, 0x80, 0x9e, 0xdb, 0x20, 0x69, 0x62, 0x7f,
-	0xd5, 0x9a, 0xe9, 0xd3, 0xa5, 0xbd, 0xfc, 0x73, 0x9e, 0x67, 0xd0, 0x2f, 0x79, 0x45, 0x1c, 0x23,
-	0x1a, 0x77, 0x23, 0xe8, 0x4a, 0x54, 0x45, 0x56, 0x0d, 0x72, 0xf7, 0x7f, 0x99, 0x76, 0xa7, 0x9d,
-	0xd8, 0xef, 0xbb, 0x3e, 0xd3, 0x44, 0x08, 0x64, 0xfe, 0x91, 0xbd, 0x3e, 0xab, 0x58, 0x3f, 0xba,
-	0xf5, 0xad, 0x6b, 0x6f, 0xeb, 0x79, 0xb7, 0xfc, 0xa4, 0xec, 0xff, 0x0e, 0x00, 0x00, 0xff, 0xff,
-	0x86, 0x61, 0xe6, 0x8d, 0x15, 0x05, 0x00, 0x00,
+	// 719 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0x7f, 0x4f, 0xdb, 0x48,
+	0x10, 0x95, 0x03, 0x09, 0x30, 0x01, 0x0e, 0xf6, 0xee, 0x24, 0x2b, 0xa7, 0xe3, 0xd0, 0x5e, 0x2b,
+	0x21, 0x55, 0x38, 0x2a, 0x54, 0x15, 0xd0, 0x56, 0x15, 0x50, 0x10, 0xa1, 0xd0, 0x56, 0x06, 0xd1,
+	0xaa, 0x12, 0x7f, 0x2c, 0xde, 0xc1, 0x6c, 0xe3, 0xd8, 0xd6, 0xee, 0x3a, 0x28, 0x1f, 0xa6, 0x5f,
+	0xa4, 0x9f, 0xae, 0xf2, 0xda, 0x4e, 0x6c, 0xb5, 0xe1, 0xe7, 0x3f, 0xb1, 0x77, 0x33, 0xef, 0x65,
+	0xe6, 0xcd, 0x9b, 0x09, 0xfc, 0x13, 0x77, 0xfd, 0x36, 0x8b, 0x45, 0x1b, 0xfb, 0x18, 0x6a, 0x95,
+	0x3f, 0x9c, 0x58, 0x46, 0x3a, 0x22, 0xf6, 0xa5, 0x50, 0x4a, 0x44, 0xa1, 0x73, 0x1d, 0xc9, 0xee,
+	0x65, 0x10, 0x5d, 0x2b, 0x27, 0xfb, 0xbe, 0xb5, 0xe5, 0x0b, 0x7d, 0x95, 0x5c, 0x38, 0x5e, 0xd4,
+	0x6b, 0xe7, 0x41, 0xc5, 0x73, 0x75, 0x18, 0xdc, 0x4e, 0xb9, 0xf5, 0x20, 0x46, 0x95, 0x7d, 0x66,
+	0xac, 0xad, 0xa3, 0x07, 0x60, 0x79, 0x9f, 0x05, 0x49, 0xf5, 0x3d, 0x67, 0xfb, 0xcf, 0x8f, 0x22,
+	0x3f, 0xc0, 0xb6, 0x39, 0x5d, 0x24, 0x97, 0x6d, 0x2d, 0x7a, 0xa8, 0x34, 0xeb, 0xc5, 0x59, 0x00,
+	0xbd, 0x82, 0x3f, 0x3e, 0xe7, 0xac, 0xbb, 0x12, 0x99, 0x46, 0x4e, 0x36, 0x61, 0x52, 0xc5, 0xe8,
+	0xd9, 0xd6, 0xb2, 0xb5, 0xd2, 0x5c, 0x7b, 0xea, 0xfc, 0x5a, 0x66, 0x96, 0x6f, 0x81, 0x3b, 0x89,
+	0xd1, 0x73, 0x0d, 0x84, 0xb4, 0x60, 0x5a, 0x62, 0x5f, 0xa4, 0xe1, 0x76, 0x6d, 0xd9, 0x5a, 0x99,
+	0x70, 0x87, 0x67, 0xba, 0x38, 0xfa, 0xa5, 0x77, 0x18, 0xa0, 0x46, 0x4e, 0x7f, 0x58, 0x30, 0x5f,
+	0xdc, 0x7d, 0x62, 0x52, 0x21, 0x27, 0x1d, 0xa8, 0x6b, 0xa6, 0xba, 0xca, 0xb6, 0x96, 0x27, 0x56,
+	0x9a, 0x6b, 0xeb, 0xce, 0x38, 0x91, 0x9d, 0x2a, 0xd0, 0x39, 0x4d, 0x51, 0x7b, 0xa1, 0x96, 0x03,
+	0x37, 0x63, 0x68, 0x9d, 0x03, 0x8c, 0x2e, 0xc9, 0x02, 0x4c, 0x74, 0x71, 0x60, 0x8a, 0x9a, 0x71,
+	0xd3, 0x57, 0xb2, 0x09, 0x75, 0xa3, 0x95, 0xc9, 0xb4, 0xb9, 0xf6, 0xff, 0xd8, 0x42, 0x53, 0x96,
+	0x13, 0xcd, 0x74, 0xa2, 0xdc, 0x0c, 0xb1, 0x55, 0xdb, 0xb0, 0xe8, 0x31, 0xfc, 0x5d, 0x4e, 0x41,
+	0x84, 0xfe, 0x3e, 0x13, 0x01, 0x72, 0xf2, 0x02, 0xea, 0x28, 0x65, 0x24, 0x73, 0x01, 0x97, 0xc6,
+	0xf2, 0xee, 0xa5, 0x51, 0x6e, 0x16, 0x4c, 0xcf, 0xc0, 0x2e, 0xe8, 0xdc, 0x5c, 0x32, 0x17, 0x15,
+	0xca, 0x3e, 0xf2, 0x8a, 0xac, 0x56, 0x55, 0x56, 0xb2, 0x04, 0x50, 0xf0, 0x76, 0xb8, 0x29, 0x65,
+	0xc6, 0x2d, 0xdd, 0xd0, 0x2f, 0xb0, 0xd8, 0x09, 0xfb, 0x91, 0xc7, 0xb4, 0x88, 0xc2, 0xa2, 0xc5,
+	0xbb, 0x95, 0x16, 0xb7, 0x6f, 0x6d, 0xf1, 0x88, 0x61, 0xd4, 0x6c, 0xfa, 0xdd, 0x82, 0x3f, 0x4b,
+	0xd4, 0x51, 0x2f, 0x36, 0x5d, 0x25, 0xaf, 0xa0, 0x11, 0x25, 0x3a, 0x4e, 0xb4, 0x6d, 0xdd, 0x26,
+	0x6c, 0xea, 0xd7, 0xb3, 0x54, 0x51, 0x37, 0x87, 0x90, 0x0e, 0xcc, 0x7d, 0x34, 0x6f, 0x07, 0xc8,
+	0x38, 0x4a, 0x65, 0xd7, 0xee, 0xce, 0x51, 0x45, 0xd2, 0x43, 0x20, 0xa5, 0xf4, 0x58, 0xe8, 0xe1,
+	0xc3, 0xbb, 0x73, 0x50, 0x2e, 0x35, 0xf5, 0xc3, 0x36, 0xe7, 0xc8, 0xc9, 0x73, 0x98, 0x4c, 0xbd,
+	0x96, 0x73, 0xfd, 0x7b, 0xa3, 0x83, 0x5c, 0x13, 0x4a, 0x0f, 0x60, 0x61, 0xc4, 0xf4, 0x28, 0xc7,
+	0xbc, 0x2f, 0x77, 0xd6, 0x45, 0x2d, 0x05, 0x72, 0xf2, 0x12, 0xa6, 0x39, 0x32, 0x1e, 0x88, 0x10,
+	0x73, 0xb6, 0x96, 0x93, 0xed, 0x00, 0xa7, 0xd8, 0x01, 0xce, 0x69, 0xb1, 0x03, 0xdc, 0x61, 0x2c,
+	0x3d, 0x84, 0xf9, 0x53, 0x29, 0x7c, 0x1f, 0x65, 0xe1, 0x91, 0x8d, 0x8a, 0x47, 0x9e, 0x8c, 0xaf,
+	0x2d, 0x83, 0x95, 0x8c, 0xb1, 0x30, 0xe4, 0x2a, 0x06, 0xfd, 0x08, 0x66, 0xf3, 0x9b, 0x7d, 0x21,
+	0x91, 0x93, 0xd7, 0xd0, 0x54, 0xde, 0x15, 0xf2, 0x24, 0x40, 0xbe, 0xad, 0xef, 0x90, 0x68, 0x39,
+	0x9c, 0x7e, 0x1b, 0xf2, 0xa7, 0xf5, 0x77, 0x91, 0x13, 0x0a, 0xb3, 0x62, 0x28, 0x45, 0x87, 0xe7,
+	0x53, 0x5e, 0xb9, 0x1b, 0x89, 0x5c, 0xbb, 0x8f, 0xc8, 0x1f, 0xa0, 0x99, 0x8f, 0xbf, 0x4c, 0x45,
+	0x79, 0x5b, 0x11, 0xe5, 0xd9, 0x8d, 0x0d, 0xff, 0xed, 0xd0, 0x9c, 0xc1, 0x9c, 0xe1, 0x4b, 0x3c,
+	0x0f, 0x31, 0xb5, 0xd0, 0x1e, 0x34, 0x24, 0xaa, 0x24, 0x28, 0x54, 0x58, 0xbd, 0x2b, 0x67, 0xb6,
+	0x90, 0x72, 0x30, 0x9d, 0xcb, 0xf3, 0xec, 0x8a, 0x38, 0x46, 0x4e, 0x77, 0xb2, 0xdd, 0xf7, 0x28,
+	0x7f, 0x9d, 0x67, 0x94, 0x85, 0xb3, 0x1e, 0x44, 0x42, 0x6c, 0x98, 0x62, 0x5a, 0x63, 0x2f, 0xd6,
+	0x46, 0xf7, 0xba, 0x5b, 0x1c, 0xe9, 0x5f, 0x40, 0xcc, 0x20, 0x5d, 0x33, 0xa1, 0x45, 0xe8, 0x9f,
+	0x08, 0x3f, 0x64, 0x01, 0x3d, 0x86, 0x59, 0x53, 0x87, 0x39, 0x21, 0x27, 0x6f, 0x60, 0x2a, 0x66,
+	0x83, 0x20, 0x62, 0xfc, 0x3e, 0xdb, 0xa4, 0xc0, 0xec, 0x4c, 0x7f, 0x6d, 0x64, 0x7f, 0x17, 0x17,
+	0x0d, 0xe3, 0xaa, 0xf5, 0x9f, 0x03, 0x00, 0x43, 0x71, 0x72, 0xdd, 0xd3, 0x07, 0x00, 0x00,
 }
@@ -3,6 +3,7 @@ package api
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/api/events"
 	"github.com/fission/fission-workflows/pkg/api/projectors"
@@ -11,6 +12,7 @@ import (
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/fission/fission-workflows/pkg/types/validate"
 	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 )
@@ -37,6 +39,11 @@ func (ia *Invocation) Invoke(spec *types.WorkflowInvocationSpec, opts ...CallOpt
 	if err != nil {
 		return "", err
 	}
+	if spec.Workflow != nil {
+		if err := validate.WorkflowInputs(spec.Workflow.GetSpec(), spec.Inputs); err != nil {
+			return "", err
+		}
+	}
 
 	// Ensure that te body input is also accessible on the default parameter
 	// TODO remove once default input field is removed
@@ -144,6 +151,33 @@ func (ia *Invocation) Fail(invocationID string, errMsg error) error {
 	return ia.es.Append(event)
 }
 
+// Retry resumes a failed or aborted invocation from the point of failure. The failed, aborted and skipped tasks are
+// scheduled again, whereas the outputs of the tasks that succeeded are reused. If the deadline is not zero, it replaces
+// the deadline of the invocation. If the API fails to append the event to the event store, it will return an error.
+//
+// With ExpectGeneration, the retry is rejected with a fes.ErrGenerationConflict if the invocation has been modified
+// since it was checked, such as by a concurrent retry.
+func (ia *Invocation) Retry(invocationID string, deadline time.Time, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+
+	retried := &events.InvocationRetried{}
+	if !deadline.IsZero() {
+		ts, err := ptypes.TimestampProto(deadline)
+		if err != nil {
+			return validate.NewError("deadline", err)
+		}
+		retried.Deadline = ts
+	}
+	event, err := fes.NewEvent(projectors.NewInvocationAggregate(invocationID), retried)
+	if err != nil {
+		return err
+	}
+	return ia.es.Append(event, cfg.appendOptions()...)
+}
+
 // AddTask provides functionality to add a task to a specific invocation (instead of a workflow).
 // This allows users to modify specific invocations (see dynamic API).
 // The error can be a validate.Err, proto marshall error, or a fes error.
@@ -164,3 +198,27 @@ func (ia *Invocation) AddTask(invocationID string, task *types.Task) error {
 	}
 	return ia.es.Append(event)
 }
+
+// Signal provides the payload to a task of the invocation that is awaiting a signal, such as the wait function. The
+// controller completes the task with the payload as its output, after transforming it according to the task spec.
+// Signals for tasks that are not awaiting one are ignored.
+func (ia *Invocation) Signal(invocationID string, taskID string, payload *typedvalues.TypedValue,
+	opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+	if len(taskID) == 0 {
+		return validate.NewError("taskID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(projectors.NewTaskRunAggregate(taskID), &events.TaskSignaled{
+		Payload: payload,
+	})
+	if err != nil {
+		return err
+	}
+	aggregate := projectors.NewInvocationAggregate(invocationID)
+	event.Parent = &aggregate
+	return ia.es.Append(event, cfg.appendOptions()...)
+}
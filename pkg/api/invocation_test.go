@@ -0,0 +1,40 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvocation_RetryExpectGeneration(t *testing.T) {
+	backend := mem.NewBackend()
+	invocationAPI := NewInvocationAPI(backend)
+
+	wf := types.NewWorkflow("wf")
+	wf.Spec.Tasks = types.Tasks{"task": types.NewTaskSpec("fn")}
+	spec := types.NewWorkflowInvocationSpec(wf.ID(), time.Now().Add(time.Minute))
+	spec.Workflow = wf
+	invocationID, err := invocationAPI.Invoke(spec)
+	assert.NoError(t, err)
+	assert.NoError(t, invocationAPI.Cancel(invocationID))
+
+	events, err := backend.Get(projectors.NewInvocationAggregate(invocationID))
+	assert.NoError(t, err)
+	entity, err := projectors.NewWorkflowInvocation().Project(nil, events...)
+	assert.NoError(t, err)
+	generation := entity.(*types.WorkflowInvocation).GetMetadata().GetGeneration()
+
+	// Retries that observed the same state of the invocation retry it once.
+	assert.NoError(t, invocationAPI.Retry(invocationID, time.Time{}, ExpectGeneration(generation)))
+	err = invocationAPI.Retry(invocationID, time.Time{}, ExpectGeneration(generation))
+	assert.True(t, fes.ErrGenerationConflict.Is(err), "expected a generation conflict, but was %v", err)
+
+	events, err = backend.Get(projectors.NewInvocationAggregate(invocationID))
+	assert.NoError(t, err)
+	assert.Len(t, events, int(generation)+1)
+}
@@ -58,8 +58,10 @@ func (i *WorkflowInvocation) project(wi *types.WorkflowInvocation, event *fes.Ev
 	switch m := eventData.(type) {
 	case *events.InvocationCreated:
 		wi.Metadata = &types.ObjectMetadata{
-			Id:        event.Aggregate.Id,
-			CreatedAt: event.Timestamp,
+			Id:          event.Aggregate.Id,
+			CreatedAt:   event.Timestamp,
+			Labels:      m.GetSpec().GetLabels(),
+			Annotations: m.GetSpec().GetAnnotations(),
 		}
 		wi.Spec = m.GetSpec()
 		wi.Status = &types.WorkflowInvocationStatus{
@@ -83,6 +85,25 @@ func (i *WorkflowInvocation) project(wi *types.WorkflowInvocation, event *fes.Ev
 	case *events.InvocationFailed:
 		wi.Status.Error = m.GetError()
 		wi.Status.Status = types.WorkflowInvocationStatus_FAILED
+	case *events.InvocationRetried:
+		wi.Status.Status = types.WorkflowInvocationStatus_IN_PROGRESS
+		wi.Status.Error = nil
+		if m.GetDeadline() != nil {
+			wi.Spec.Deadline = m.GetDeadline()
+		}
+		// Reset the unsuccessful task runs, so that the tasks are scheduled again. This includes tasks that were
+		// still awaiting a signal when the invocation was aborted. Unlike a retry of a single task, the attempts of
+		// the task start over.
+		for _, taskRun := range wi.Status.Tasks {
+			switch taskRun.GetStatus().GetStatus() {
+			case types.TaskInvocationStatus_FAILED, types.TaskInvocationStatus_ABORTED,
+				types.TaskInvocationStatus_SKIPPED, types.TaskInvocationStatus_IN_PROGRESS:
+				taskRun.Status = &types.TaskInvocationStatus{
+					Status:    types.TaskInvocationStatus_UNKNOWN,
+					UpdatedAt: event.GetTimestamp(),
+				}
+			}
+		}
 	default:
 		//key := wi.Aggregate()
 		return fes.ErrUnsupportedEntityEvent.WithEvent(event)
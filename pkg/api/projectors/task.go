@@ -49,14 +49,16 @@ func (t *TaskRun) project(taskRun *types.TaskInvocation, event *fes.Event) error
 
 	switch m := eventData.(type) {
 	case *events.TaskStarted:
+		// The generation is retained, as it reflects the events of the task run that preceded a retry.
 		taskRun.Metadata = &types.ObjectMetadata{
 			Id:         m.GetSpec().TaskId,
 			CreatedAt:  event.Timestamp,
-			Generation: 1,
+			Generation: taskRun.GetMetadata().GetGeneration(),
 		}
 		taskRun.Spec = m.GetSpec()
 		taskRun.Status = &types.TaskInvocationStatus{
-			Status: types.TaskInvocationStatus_IN_PROGRESS,
+			Status:  types.TaskInvocationStatus_IN_PROGRESS,
+			Retries: taskRun.GetStatus().GetRetries(),
 		}
 	case *events.TaskSucceeded:
 		taskRun.Status.Output = m.GetResult().Output
@@ -65,6 +67,25 @@ func (t *TaskRun) project(taskRun *types.TaskInvocation, event *fes.Event) error
 	case *events.TaskFailed:
 		taskRun.Status.Error = m.GetError()
 		taskRun.Status.Status = types.TaskInvocationStatus_FAILED
+	case *events.TaskRetried:
+		// Reset the task, so that it will be picked up by the scheduler again.
+		taskRun.Status.Status = types.TaskInvocationStatus_UNKNOWN
+		taskRun.Status.Error = nil
+		taskRun.Status.Signaled = false
+		taskRun.Status.AwaitingSignal = false
+		taskRun.Status.Retries = m.GetAttempt()
+	case *events.TaskAwaitingSignal:
+		taskRun.Status.AwaitingSignal = true
+	case *events.TaskSignaled:
+		// Signals for tasks that are not awaiting one, such as duplicate or late signals, are ignored.
+		if taskRun.GetStatus().GetStatus() != types.TaskInvocationStatus_IN_PROGRESS ||
+			!taskRun.GetStatus().GetAwaitingSignal() || taskRun.GetStatus().GetSignaled() {
+			taskRun.Metadata.Generation++
+			return nil
+		}
+		// The task is completed by the controller, once it has transformed the output.
+		taskRun.Status.Output = m.GetPayload()
+		taskRun.Status.Signaled = true
 	case *events.TaskSkipped:
 		// TODO ensure that object (spec/status) is present
 		taskRun.Status.Status = types.TaskInvocationStatus_SKIPPED
@@ -0,0 +1,110 @@
+package projectors
+
+import (
+	"fmt"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/golang/protobuf/ptypes"
+)
+
+type Trigger struct {
+}
+
+func NewTrigger() *Trigger {
+	return &Trigger{}
+}
+
+func (t *Trigger) Project(base fes.Entity, events ...*fes.Event) (updated fes.Entity, err error) {
+	var trigger *types.Trigger
+	if base == nil {
+		trigger = &types.Trigger{}
+	} else {
+		var ok bool
+		trigger, ok = base.(*types.Trigger)
+		if !ok {
+			return nil, fmt.Errorf("entity expected trigger, but was %T", base)
+		}
+		trigger = trigger.Copy()
+	}
+
+	for _, event := range events {
+		err := t.project(trigger, event)
+		if err != nil {
+			return trigger, err
+		}
+	}
+	return trigger, nil
+}
+
+func (t *Trigger) project(trigger *types.Trigger, event *fes.Event) error {
+	if err := t.ensureValidEvent(event); err != nil {
+		return err
+	}
+
+	eventData, err := fes.ParseEventData(event)
+	if err != nil {
+		return err
+	}
+
+	switch m := eventData.(type) {
+	case *events.TriggerCreated:
+		trigger.Metadata = &types.ObjectMetadata{
+			Id:          trigger.GetMetadata().GetId(),
+			CreatedAt:   event.GetTimestamp(),
+			Labels:      m.GetSpec().GetLabels(),
+			Annotations: m.GetSpec().GetAnnotations(),
+		}
+		trigger.Spec = m.GetSpec()
+		trigger.Status = &types.TriggerStatus{
+			Status: types.TriggerStatus_ACTIVE,
+		}
+	case *events.TriggerFired:
+		trigger.Status.LastFiredAt = m.GetScheduledAt()
+		trigger.Status.LastInvocationId = ""
+		trigger.Status.Error = nil
+	case *events.TriggerInvoked:
+		trigger.Status.LastInvocationId = m.GetInvocationId()
+		trigger.Status.Error = m.GetError()
+	case *events.TriggerDeleted:
+		trigger.Status.Status = types.TriggerStatus_DELETED
+	default:
+		return fes.ErrUnsupportedEntityEvent.WithEvent(event)
+	}
+	trigger.Metadata.Generation++
+	trigger.Status.UpdatedAt = event.GetTimestamp()
+	return nil
+}
+
+func (t *Trigger) ensureValidEvent(event *fes.Event) error {
+	if err := fes.ValidateEvent(event); err != nil {
+		return err
+	}
+
+	if event.Aggregate.Type != types.TypeTrigger {
+		return fes.ErrUnsupportedEntityEvent.WithEvent(event)
+	}
+	return nil
+}
+
+func (t *Trigger) NewProjection(key fes.Aggregate) (fes.Entity, error) {
+	if key.Type != types.TypeTrigger {
+		return nil, fes.ErrInvalidAggregate.WithAggregate(&key)
+	}
+	return &types.Trigger{
+		Metadata: &types.ObjectMetadata{
+			Id:        key.Id,
+			CreatedAt: ptypes.TimestampNow(),
+		},
+		Spec:   &types.TriggerSpec{},
+		Status: &types.TriggerStatus{},
+	}, nil
+}
+
+func NewTriggerAggregate(id string) fes.Aggregate {
+	return fes.Aggregate{
+		Id:   id,
+		Type: types.TypeTrigger,
+	}
+}
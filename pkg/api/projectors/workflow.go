@@ -52,9 +52,12 @@ func (w *Workflow) project(wf *types.Workflow, event *fes.Event) error {
 	case *events.WorkflowCreated:
 		spec := m.GetSpec()
 		wf.Metadata = &types.ObjectMetadata{
-			Id:        wf.GetMetadata().GetId(),
-			Name:      spec.GetName(),
-			CreatedAt: event.GetTimestamp(),
+			Id:          wf.GetMetadata().GetId(),
+			Name:        spec.GetName(),
+			CreatedAt:   event.GetTimestamp(),
+			Revision:    m.GetRevision(),
+			Labels:      spec.GetLabels(),
+			Annotations: spec.GetAnnotations(),
 		}
 		wf.Spec = spec
 		wf.Status = &types.WorkflowStatus{
@@ -121,3 +124,11 @@ func NewWorkflowAggregate(id string) fes.Aggregate {
 		Type: types.TypeWorkflow,
 	}
 }
+
+// NewWorkflowNameAggregate returns the aggregate that tracks the revisions of the workflows with the given name.
+func NewWorkflowNameAggregate(name string) fes.Aggregate {
+	return fes.Aggregate{
+		Id:   name,
+		Type: types.TypeWorkflowName,
+	}
+}
@@ -1,24 +1,50 @@
-// package store provides typed, centralized access to the event-sourced workflow and invocation models
+// package store provides typed, centralized access to the event-sourced workflow, invocation and trigger models
 package store
 
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/util/labels"
 	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
 )
 
 type Workflows struct {
 	fes.CacheReader // Currently needed for pubsub publisher interface, should be exposed here
+	names           Index
+}
+
+// Index provides the aggregates of the entities that are indexed under a value.
+type Index interface {
+	Lookup(value string) []fes.Aggregate
 }
 
 func NewWorkflowsStore(workflows fes.CacheReader) *Workflows {
 	return &Workflows{
-		workflows,
+		CacheReader: workflows,
+	}
+}
+
+// WithNameIndex makes the store look up the revisions of a workflow in the index, instead of scanning all workflows
+// in the cache. The index should index the workflows of the cache with WorkflowNameIndex.
+func (s *Workflows) WithNameIndex(names Index) *Workflows {
+	s.names = names
+	return s
+}
+
+// WorkflowNameIndex returns the name of the workflow entity if it is a revision of a named workflow.
+func WorkflowNameIndex(entity fes.Entity) string {
+	wf, ok := entity.(*types.Workflow)
+	if !ok || wf.GetMetadata().GetRevision() == 0 {
+		return ""
 	}
+	return wf.GetMetadata().GetName()
 }
 
 // GetWorkflow returns an event-sourced workflow.
@@ -42,6 +68,76 @@ func (s *Workflows) GetWorkflow(workflowID string) (*types.Workflow, error) {
 	return wf, nil
 }
 
+// GetWorkflowByRef returns the workflow referenced by ref, which is either the id of a workflow or a reference to a
+// revision of a named workflow (see types.WorkflowRef). Ids take precedence over names.
+func (s *Workflows) GetWorkflowByRef(ref string) (*types.Workflow, error) {
+	wf, err := s.GetWorkflow(ref)
+	if err != nil && !fes.ErrEntityNotFound.Is(err) {
+		return nil, err
+	}
+	if wf != nil {
+		return wf, nil
+	}
+
+	wfRef, perr := types.ParseWorkflowRef(ref)
+	if perr != nil {
+		return nil, err
+	}
+	wf, rerr := s.GetWorkflowRevision(wfRef)
+	if rerr != nil || wf == nil {
+		// Report the original error, as the reference was not necessarily meant to be a name.
+		return nil, err
+	}
+	return wf, nil
+}
+
+// GetWorkflowRevision returns the referenced revision of a named workflow. If the reference does not specify a
+// revision, the latest revision that has not been deleted is returned.
+// If no workflow was found both return values are nil.
+func (s *Workflows) GetWorkflowRevision(ref types.WorkflowRef) (*types.Workflow, error) {
+	revisions, err := s.GetWorkflowRevisions(ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(revisions) - 1; i >= 0; i-- {
+		wf := revisions[i]
+		if ref.Latest() && wf.GetStatus().GetStatus() != types.WorkflowStatus_DELETED {
+			return wf, nil
+		}
+		if wf.GetMetadata().GetRevision() == ref.Revision {
+			return wf, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetWorkflowRevisions returns all revisions of the workflow with the given name, ordered by revision.
+//
+// Without a name index, this scans all workflows in the cache.
+func (s *Workflows) GetWorkflowRevisions(name string) ([]*types.Workflow, error) {
+	keys := s.List()
+	if s.names != nil {
+		keys = s.names.Lookup(name)
+	}
+	var revisions []*types.Workflow
+	for _, key := range keys {
+		if key.Type != types.TypeWorkflow {
+			continue
+		}
+		wf, err := s.GetWorkflow(key.Id)
+		if err != nil {
+			return nil, err
+		}
+		if wf.GetMetadata().GetName() == name && wf.GetMetadata().GetRevision() > 0 {
+			revisions = append(revisions, wf)
+		}
+	}
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].GetMetadata().GetRevision() < revisions[j].GetMetadata().GetRevision()
+	})
+	return revisions, nil
+}
+
 // GetWorkflowNotifications returns a subscription to the updates of the workflow cache.
 // Returns nil if the cache does not support pubsub.
 //
@@ -98,13 +194,107 @@ func (s *Invocations) GetInvocation(invocationID string) (*types.WorkflowInvocat
 	return wfi, nil
 }
 
+// InvocationQuery selects invocations based on their workflow, status, creation time and labels. The fields that are
+// not set do not restrict the invocations, so the zero value selects all invocations.
+type InvocationQuery struct {
+	// Workflows limits the invocations to the invocations of these workflows (by id).
+	Workflows []string
+
+	// Statuses limits the invocations to the invocations that have one of these statuses.
+	Statuses []types.WorkflowInvocationStatus_Status
+
+	// CreatedAfter and CreatedBefore limit the invocations to the invocations created in this (exclusive) range.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// Selector limits the invocations to the invocations of which the InvocationLabels match the selector.
+	Selector labels.Matcher
+}
+
+// IsEmpty checks if the query selects all invocations, in which case the invocations do not need to be fetched.
+func (q InvocationQuery) IsEmpty() bool {
+	return len(q.Workflows) == 0 && len(q.Statuses) == 0 && q.CreatedAfter.IsZero() && q.CreatedBefore.IsZero() &&
+		q.Selector == nil
+}
+
+// Matches checks if the invocation is selected by the query.
+func (q InvocationQuery) Matches(wfi *types.WorkflowInvocation) bool {
+	if len(q.Workflows) > 0 && !contains(q.Workflows, wfi.GetSpec().GetWorkflowId()) {
+		return false
+	}
+	if len(q.Statuses) > 0 && !containsStatus(q.Statuses, wfi.GetStatus().GetStatus()) {
+		return false
+	}
+	createdAt, _ := ptypes.Timestamp(wfi.GetMetadata().GetCreatedAt())
+	if (!q.CreatedAfter.IsZero() && !createdAt.After(q.CreatedAfter)) ||
+		(!q.CreatedBefore.IsZero() && !createdAt.Before(q.CreatedBefore)) {
+		return false
+	}
+	return q.Selector == nil || q.Selector.Matches(InvocationLabels(wfi))
+}
+
+// ListInvocations returns the invocations in the cache that match the query, in no particular order. Invocations that
+// cannot be fetched from the cache are skipped.
+func (s *Invocations) ListInvocations(query InvocationQuery) []*types.WorkflowInvocation {
+	var invocations []*types.WorkflowInvocation
+	for _, aggregate := range s.List() {
+		if aggregate.Type != types.TypeInvocation {
+			continue
+		}
+		wfi, err := s.GetInvocation(aggregate.Id)
+		if err != nil {
+			logrus.Errorf("Failed to fetch invocation %v: %v", aggregate.Id, err)
+			continue
+		}
+		if wfi != nil && query.Matches(wfi) {
+			invocations = append(invocations, wfi)
+		}
+	}
+	return invocations
+}
+
+// InvocationLabels returns the labels of the invocation that label selectors are matched against, which are the
+// labels in its metadata extended with labels derived from its spec:
+// - workflow: the id of the invoked workflow.
+// - parent: the id of the parent invocation, if the invocation is a child of another invocation.
+// - trigger: the id of the trigger that started the invocation, if any.
+func InvocationLabels(wfi *types.WorkflowInvocation) labels.Set {
+	set := labels.Merge(wfi.GetMetadata().GetLabels(), map[string]string{
+		"workflow": wfi.GetSpec().GetWorkflowId(),
+	})
+	delete(set, "parent")
+	delete(set, "trigger")
+	if parentID := wfi.GetSpec().GetParentId(); len(parentID) > 0 {
+		set["parent"] = parentID
+	}
+	if triggerID := wfi.GetSpec().GetTriggerId(); len(triggerID) > 0 {
+		set["trigger"] = triggerID
+	}
+	return set
+}
+
 // GetInvocationSubscription returns a subscription to the updates of the invocation cache.
 // Returns nil if the cache does not support pubsub.
 //
 // Future: Currently this assumes the presence of a pubsub.Publisher interface in the cache.
 // In the future we can fallback to pull-based mechanisms
 func (s *Invocations) GetInvocationUpdates() *InvocationSubscription {
-	selector := labels.In(fes.PubSubLabelAggregateType, types.TypeInvocation, types.TypeTaskRun)
+	return s.subscribe(labels.In(fes.PubSubLabelAggregateType, types.TypeInvocation, types.TypeTaskRun))
+}
+
+// GetInvocationUpdatesFor returns a subscription to the updates of a single invocation, including the updates of its
+// task runs. Returns nil if the cache does not support pubsub.
+func (s *Invocations) GetInvocationUpdatesFor(invocationID string) *InvocationSubscription {
+	return s.subscribe(labels.And(
+		labels.In(fes.PubSubLabelAggregateType, types.TypeInvocation, types.TypeTaskRun),
+		labels.Or(
+			labels.In(fes.PubSubLabelAggregateID, invocationID),
+			labels.In(fes.PubSubLabelParentID, invocationID),
+		),
+	))
+}
+
+func (s *Invocations) subscribe(selector labels.Matcher) *InvocationSubscription {
 	invocationPub, ok := s.CacheReader.(pubsub.Publisher)
 	if !ok {
 		return nil
@@ -122,6 +312,57 @@ func (s *Invocations) GetInvocationUpdates() *InvocationSubscription {
 	return sub
 }
 
+type Triggers struct {
+	fes.CacheReader
+}
+
+func NewTriggersStore(triggers fes.CacheReader) *Triggers {
+	return &Triggers{
+		triggers,
+	}
+}
+
+// GetTrigger returns an event-sourced trigger.
+// If an error occurred the error is returned, if no trigger was found both return values are nil.
+func (s *Triggers) GetTrigger(triggerID string) (*types.Trigger, error) {
+	key := fes.Aggregate{Type: types.TypeTrigger, Id: triggerID}
+	entity, err := s.GetAggregate(key)
+	if err != nil {
+		return nil, err
+	}
+	if entity == nil {
+		return nil, nil
+	}
+
+	trigger, ok := entity.(*types.Trigger)
+	if !ok {
+		panic(fmt.Sprintf("aggregate type mismatch for key %s (expected: %T, got %T)", key.Format(),
+			&types.Trigger{}, trigger))
+	}
+
+	return trigger, nil
+}
+
+// GetTriggerUpdates returns a subscription to the updates of the trigger cache.
+// Returns nil if the cache does not support pubsub.
+func (s *Triggers) GetTriggerUpdates() *TriggerSubscription {
+	triggerPub, ok := s.CacheReader.(pubsub.Publisher)
+	if !ok {
+		return nil
+	}
+
+	sub := &TriggerSubscription{
+		Subscription: triggerPub.Subscribe(pubsub.SubscriptionOptions{
+			Buffer:       fes.DefaultNotificationBuffer,
+			LabelMatcher: labels.In(fes.PubSubLabelAggregateType, types.TypeTrigger),
+		}),
+	}
+	sub.closeFn = func() error {
+		return triggerPub.Unsubscribe(sub.Subscription)
+	}
+	return sub
+}
+
 type WorkflowSubscription struct {
 	*pubsub.Subscription
 	closeFn func() error
@@ -162,6 +403,26 @@ func (sub *InvocationSubscription) Close() error {
 	return sub.closeFn()
 }
 
+type TriggerSubscription struct {
+	*pubsub.Subscription
+	closeFn func() error
+}
+
+func (sub *TriggerSubscription) ToNotification(msg pubsub.Msg) (*fes.Notification, error) {
+	update, ok := msg.(*fes.Notification)
+	if !ok {
+		return nil, errors.New("received message is not a notification")
+	}
+	return update, nil
+}
+
+func (sub *TriggerSubscription) Close() error {
+	if sub.closeFn == nil {
+		return nil
+	}
+	return sub.closeFn()
+}
+
 func ParseNotificationToWorkflow(update *fes.Notification) (*types.Workflow, error) {
 	entity, ok := update.Updated.(*types.Workflow)
 	if !ok {
@@ -177,3 +438,29 @@ func ParseNotificationToInvocation(update *fes.Notification) (*types.WorkflowInv
 	}
 	return entity, nil
 }
+
+func ParseNotificationToTrigger(update *fes.Notification) (*types.Trigger, error) {
+	entity, ok := update.Updated.(*types.Trigger)
+	if !ok {
+		return nil, errors.New("received message does not include trigger as payload")
+	}
+	return entity, nil
+}
+
+func containsStatus(haystack []types.WorkflowInvocationStatus_Status, needle types.WorkflowInvocationStatus_Status) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(haystack []string, needle string) bool {
+	for i := 0; i < len(haystack); i++ {
+		if haystack[i] == needle {
+			return true
+		}
+	}
+	return false
+}
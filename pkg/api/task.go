@@ -12,6 +12,7 @@ import (
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues/controlflow"
 	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus"
 )
@@ -35,6 +36,10 @@ func NewTaskAPI(runtime map[string]fnenv.Runtime, esClient fes.Backend, api *Dyn
 
 // Invoke starts the execution of a task, changing the state of the task into RUNNING.
 // Currently it executes the underlying function synchronously and manage the execution until completion.
+//
+// The task run is claimed by recording that it has started before the function is invoked. If an expected generation
+// is provided, concurrent callers that claim the same task run receive a fes.ErrGenerationConflict, without invoking
+// the function.
 func (ap *Task) Invoke(spec *types.TaskInvocationSpec, opts ...CallOption) (*types.TaskInvocation, error) {
 	log := logrus.WithField("fn", spec.FnRef).WithField("wi", spec.InvocationId).WithField("task", spec.TaskId)
 	cfg := parseCallOptions(opts)
@@ -65,10 +70,18 @@ func (ap *Task) Invoke(spec *types.TaskInvocationSpec, opts ...CallOption) (*typ
 	event, err := fes.NewEvent(projectors.NewTaskRunAggregate(taskID), &events.TaskStarted{
 		Spec: spec,
 	})
-	event.Parent = &aggregate
 	if err != nil {
 		return nil, err
 	}
+	event.Parent = &aggregate
+	if err := ap.es.Append(event, cfg.appendOptions()...); err != nil {
+		return nil, err
+	}
+	// The result of the task run is expected to follow the claim.
+	if cfg.expectedGeneration != fes.AnyGeneration {
+		cfg.expectedGeneration++
+		opts = append(opts, ExpectGeneration(cfg.expectedGeneration))
+	}
 
 	fnResult, err := ap.runtime[spec.FnRef.Runtime].Invoke(spec, fnenv.WithContext(cfg.ctx),
 		fnenv.AwaitWorkflow(cfg.awaitWorkflow))
@@ -78,11 +91,7 @@ func (ap *Task) Invoke(spec *types.TaskInvocationSpec, opts ...CallOption) (*typ
 	if err != nil {
 		// TODO improve error handling here (retries? internal or task related error?)
 		log.Infof("Failed to invoke task: %v", err)
-		esErr := ap.Fail(spec.InvocationId, taskID, err.Error())
-		if esErr != nil {
-			return nil, esErr
-		}
-		return nil, err
+		return nil, ap.failClaimed(spec, err, opts...)
 	}
 
 	// TODO to a middleware component
@@ -90,11 +99,11 @@ func (ap *Task) Invoke(spec *types.TaskInvocationSpec, opts ...CallOption) (*typ
 		log.Info("Adding dynamic flow")
 		flow, err := controlflow.UnwrapControlFlow(fnResult.GetOutput())
 		if err != nil {
-			return nil, err
+			return nil, ap.failClaimed(spec, fmt.Errorf("invalid dynamic flow: %v", err), opts...)
 		}
 		err = ap.dynamicAPI.AddDynamicFlow(spec.InvocationId, taskID, *flow)
 		if err != nil {
-			return nil, err
+			return nil, ap.failClaimed(spec, fmt.Errorf("failed to add dynamic flow: %v", err), opts...)
 		}
 	}
 	task.Status = fnResult
@@ -102,31 +111,72 @@ func (ap *Task) Invoke(spec *types.TaskInvocationSpec, opts ...CallOption) (*typ
 	if cfg.postTransformer != nil {
 		err = cfg.postTransformer(task)
 		if err != nil {
-			return nil, err
+			return nil, ap.failClaimed(spec, err, opts...)
 		}
 	}
 
-	if fnResult.Status == types.TaskInvocationStatus_SUCCEEDED {
-		event, err := fes.NewEvent(projectors.NewTaskRunAggregate(taskID), &events.TaskSucceeded{
+	var result proto.Message
+	switch fnResult.Status {
+	case types.TaskInvocationStatus_SUCCEEDED:
+		result = &events.TaskSucceeded{
 			Result: fnResult,
-		})
-		if err != nil {
+		}
+	case types.TaskInvocationStatus_IN_PROGRESS:
+		log.Info("Task is awaiting a signal")
+		result = &events.TaskAwaitingSignal{}
+	default:
+		if err := ap.Fail(spec.InvocationId, taskID, fnResult.Error.GetMessage(), opts...); err != nil {
 			return nil, err
 		}
-		event.Parent = &aggregate
-		err = ap.es.Append(event)
-	} else {
-		err = ap.Fail(spec.InvocationId, taskID, fnResult.Error.GetMessage())
+		return task, nil
 	}
+	event, err = fes.NewEvent(projectors.NewTaskRunAggregate(taskID), result)
 	if err != nil {
 		return nil, err
 	}
+	event.Parent = &aggregate
+	if err := ap.es.Append(event, cfg.appendOptions()...); err != nil {
+		return nil, err
+	}
 	return task, nil
 }
 
+// failClaimed fails the task run that has been claimed by Invoke, as otherwise it would remain in progress. The
+// original error is returned, unless the failure could not be recorded.
+func (ap *Task) failClaimed(spec *types.TaskInvocationSpec, err error, opts ...CallOption) error {
+	if esErr := ap.Fail(spec.InvocationId, spec.TaskId, err.Error(), opts...); esErr != nil {
+		return esErr
+	}
+	return err
+}
+
+// Succeed completes a task that has been started with the result, such as a task that has received the signal that it
+// was awaiting. If the API fails to append the event to the event store, it will return an error.
+func (ap *Task) Succeed(invocationID string, taskID string, result *types.TaskInvocationStatus,
+	opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+	if len(taskID) == 0 {
+		return validate.NewError("taskID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(projectors.NewTaskRunAggregate(taskID), &events.TaskSucceeded{
+		Result: result,
+	})
+	if err != nil {
+		return err
+	}
+	aggregate := projectors.NewInvocationAggregate(invocationID)
+	event.Parent = &aggregate
+	return ap.es.Append(event, cfg.appendOptions()...)
+}
+
 // Fail forces the failure of a task. This turns the state of a task into FAILED.
 // If the API fails to append the event to the event store, it will return an error.
-func (ap *Task) Fail(invocationID string, taskID string, errMsg string) error {
+func (ap *Task) Fail(invocationID string, taskID string, errMsg string, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
 	if len(invocationID) == 0 {
 		return validate.NewError("invocationID", errors.New("id should not be empty"))
 	}
@@ -142,7 +192,50 @@ func (ap *Task) Fail(invocationID string, taskID string, errMsg string) error {
 	}
 	aggregate := projectors.NewInvocationAggregate(invocationID)
 	event.Parent = &aggregate
-	return ap.es.Append(event)
+	return ap.es.Append(event, cfg.appendOptions()...)
+}
+
+// Skip marks a task as skipped, which indicates that the task will not be run as part of the invocation.
+func (ap *Task) Skip(invocationID string, taskID string, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+	if len(taskID) == 0 {
+		return validate.NewError("taskID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(projectors.NewTaskRunAggregate(taskID), &events.TaskSkipped{})
+	if err != nil {
+		return err
+	}
+	aggregate := projectors.NewInvocationAggregate(invocationID)
+	event.Parent = &aggregate
+	return ap.es.Append(event, cfg.appendOptions()...)
+}
+
+// Retry resets a failed task, allowing it to be scheduled again. The attempt is the sequence number of the retry; the
+// error of the failed attempt is recorded in the event to keep it in the history of the task.
+func (ap *Task) Retry(invocationID string, taskID string, attempt int32, cause *types.Error,
+	opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(invocationID) == 0 {
+		return validate.NewError("invocationID", errors.New("id should not be empty"))
+	}
+	if len(taskID) == 0 {
+		return validate.NewError("taskID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(projectors.NewTaskRunAggregate(taskID), &events.TaskRetried{
+		Error:   cause,
+		Attempt: attempt,
+	})
+	if err != nil {
+		return err
+	}
+	aggregate := projectors.NewInvocationAggregate(invocationID)
+	event.Parent = &aggregate
+	return ap.es.Append(event, cfg.appendOptions()...)
 }
 
 func (ap *Task) Prepare(spec *types.TaskInvocationSpec, expectedAt time.Time, opts ...CallOption) error {
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/fnenv"
+	"github.com/fission/fission-workflows/pkg/fnenv/mock"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTask_InvokeClaimed(t *testing.T) {
+	backend := mem.NewBackend()
+	runtime := mock.NewRuntime()
+	var calls int
+	runtime.Functions["fn"] = func(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+		calls++
+		return typedvalues.MustWrap("output"), nil
+	}
+	taskAPI := NewTaskAPI(map[string]fnenv.Runtime{mock.Name: runtime}, backend, nil)
+
+	wf := types.NewWorkflow("wf")
+	wf.Spec.Tasks = types.Tasks{"task": types.NewTaskSpec("fn")}
+	spec := types.NewWorkflowInvocationSpec(wf.ID(), time.Now().Add(time.Minute))
+	spec.Workflow = wf
+	invocationID, err := NewInvocationAPI(backend).Invoke(spec)
+	assert.NoError(t, err)
+
+	taskRunSpec := &types.TaskInvocationSpec{
+		InvocationId: invocationID,
+		TaskId:       "task",
+		FnRef:        &types.FnRef{Runtime: mock.Name, ID: "fn"},
+		Task:         &types.Task{Metadata: types.NewObjectMetadata("task"), Spec: wf.Spec.Tasks["task"]},
+	}
+
+	// Callers that observed the same state of the task run invoke the function once.
+	_, err = taskAPI.Invoke(taskRunSpec, ExpectGeneration(0))
+	assert.NoError(t, err)
+	_, err = taskAPI.Invoke(taskRunSpec, ExpectGeneration(0))
+	assert.True(t, fes.ErrGenerationConflict.Is(err), "expected a generation conflict, but was %v", err)
+	assert.Equal(t, 1, calls)
+
+	events, err := backend.Get(projectors.NewInvocationAggregate(invocationID))
+	assert.NoError(t, err)
+	entity, err := projectors.NewWorkflowInvocation().Project(nil, events...)
+	assert.NoError(t, err)
+	taskRun, _ := entity.(*types.WorkflowInvocation).TaskInvocation("task")
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, taskRun.GetStatus().GetStatus())
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/golang/protobuf/ptypes"
+)
+
+// Trigger contains the API functionality for managing triggers, which start invocations of workflows on a schedule.
+type Trigger struct {
+	es fes.Backend
+}
+
+// NewTriggerAPI creates the Trigger API.
+func NewTriggerAPI(esClient fes.Backend) *Trigger {
+	return &Trigger{esClient}
+}
+
+// Create creates a new trigger based on the provided spec.
+// The function either returns the id of the trigger or an error.
+// The error can be a validate.Err, proto marshall error, or a fes error.
+func (ta *Trigger) Create(spec *types.TriggerSpec) (string, error) {
+	err := validate.TriggerSpec(spec)
+	if err != nil {
+		return "", err
+	}
+
+	id := fmt.Sprintf("tr-%s", util.UID())
+	event, err := fes.NewEvent(projectors.NewTriggerAggregate(id), &events.TriggerCreated{
+		Spec: spec,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	err = ta.es.Append(event)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// Delete marks a trigger as deleted, which prevents it from firing again.
+// Invocations that have already been started by the trigger are not affected.
+func (ta *Trigger) Delete(triggerID string) error {
+	if len(triggerID) == 0 {
+		return validate.NewError("triggerID", errors.New("id should not be empty"))
+	}
+
+	event, err := fes.NewEvent(projectors.NewTriggerAggregate(triggerID), &events.TriggerDeleted{})
+	if err != nil {
+		return err
+	}
+	event.Hints = &fes.EventHints{Completed: true}
+	return ta.es.Append(event)
+}
+
+// Fire claims the firing of the trigger for the scheduled time. Provide the generation of the trigger that the
+// schedule was based on with ExpectGeneration to ensure that only one caller fires the trigger for that time; the
+// others receive a fes.ErrGenerationConflict. Once the invocation has been started, record it with Invoked.
+func (ta *Trigger) Fire(triggerID string, scheduledAt time.Time, opts ...CallOption) error {
+	cfg := parseCallOptions(opts)
+	if len(triggerID) == 0 {
+		return validate.NewError("triggerID", errors.New("id should not be empty"))
+	}
+
+	ts, err := ptypes.TimestampProto(scheduledAt)
+	if err != nil {
+		return err
+	}
+	event, err := fes.NewEvent(projectors.NewTriggerAggregate(triggerID), &events.TriggerFired{
+		ScheduledAt: ts,
+	})
+	if err != nil {
+		return err
+	}
+	return ta.es.Append(event, cfg.appendOptions()...)
+}
+
+// Invoked records the invocation that the trigger started after it fired. The invocationID is the id of the
+// invocation, or empty if starting the invocation failed with invokeErr.
+func (ta *Trigger) Invoked(triggerID string, invocationID string, invokeErr error) error {
+	if len(triggerID) == 0 {
+		return validate.NewError("triggerID", errors.New("id should not be empty"))
+	}
+
+	invoked := &events.TriggerInvoked{
+		InvocationId: invocationID,
+	}
+	if invokeErr != nil {
+		invoked.Error = &types.Error{
+			Message: invokeErr.Error(),
+		}
+	}
+
+	event, err := fes.NewEvent(projectors.NewTriggerAggregate(triggerID), invoked)
+	if err != nil {
+		return err
+	}
+	return ta.es.Append(event)
+}
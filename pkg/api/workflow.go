@@ -33,6 +33,15 @@ func NewWorkflowAPI(esClient fes.Backend, resolver fnenv.Resolver) *Workflow {
 // The error can be a validate.Err, proto marshall error, or a fes error.
 // TODO check if id already exists
 func (wa *Workflow) Create(workflow *types.WorkflowSpec, opts ...CallOption) (string, error) {
+	return wa.CreateRevision(workflow, 0, opts...)
+}
+
+// CreateRevision creates a new workflow that is the given revision of the named workflow.
+//
+// The revision is first reserved on the stream of the workflow name, which is appended to with the expected
+// generation of the call options. Provide the generation returned by LatestRevision to ensure that concurrent callers
+// cannot create the same revision.
+func (wa *Workflow) CreateRevision(workflow *types.WorkflowSpec, revision int64, opts ...CallOption) (string, error) {
 	cfg := parseCallOptions(opts)
 	err := validate.WorkflowSpec(workflow)
 	if err != nil {
@@ -45,8 +54,28 @@ func (wa *Workflow) Create(workflow *types.WorkflowSpec, opts ...CallOption) (st
 		id = fmt.Sprintf("wf-%s", util.UID())
 	}
 
+	if revision > 0 && len(workflow.GetName()) == 0 {
+		return "", validate.NewError("name", errors.New("revisions require the workflow to have a name"))
+	}
+
+	if revision > 0 {
+		reservation, err := fes.NewEvent(projectors.NewWorkflowNameAggregate(workflow.GetName()),
+			&events.WorkflowRevisionReserved{
+				Revision:   revision,
+				WorkflowId: id,
+			})
+		if err != nil {
+			return "", err
+		}
+		err = wa.es.Append(reservation, cfg.appendOptions()...)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	event, err := fes.NewEvent(projectors.NewWorkflowAggregate(id), &events.WorkflowCreated{
-		Spec: workflow,
+		Spec:     workflow,
+		Revision: revision,
 	})
 	if err != nil {
 		return "", err
@@ -70,6 +99,31 @@ func (wa *Workflow) Create(workflow *types.WorkflowSpec, opts ...CallOption) (st
 	return id, nil
 }
 
+// LatestRevision returns the latest revision that has been reserved for the workflows with the given name, along
+// with the generation of the stream of the name. The revision is 0 if no revision has been reserved yet.
+func (wa *Workflow) LatestRevision(name string) (revision int64, generation int64, err error) {
+	if len(name) == 0 {
+		return 0, 0, validate.NewError("name", errors.New("name should not be empty"))
+	}
+
+	reservations, err := wa.es.Get(projectors.NewWorkflowNameAggregate(name))
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(reservations) == 0 {
+		return 0, 0, nil
+	}
+	data, err := fes.ParseEventData(reservations[len(reservations)-1])
+	if err != nil {
+		return 0, 0, err
+	}
+	reserved, ok := data.(*events.WorkflowRevisionReserved)
+	if !ok {
+		return 0, 0, fmt.Errorf("unexpected event in the stream of workflow name %s: %T", name, data)
+	}
+	return reserved.GetRevision(), int64(len(reservations)), nil
+}
+
 // Delete marks a workflow as deleted, making it unavailable to any future interactions.
 // This also means that subsequent invocations for this workflow will fail.
 // If the API fails to append the event to the event store, it will return an error.
@@ -90,8 +144,20 @@ func (wa *Workflow) Delete(workflowID string) error {
 // Currently, this means that all the function references are resolved to function identifiers. For convenience
 // this function returns the new WorkflowStatus. If the API fails to append the event to the event store,
 // it will return an error.
+//
+// If the workflow is invalid, for example because one of its expressions references a task that does not exist,
+// parsing it again will not help. Therefore, the workflow is marked as failed with a WorkflowParsingFailed event.
 func (wa *Workflow) Parse(workflow *types.Workflow) (map[string]*types.TaskStatus, error) {
 	if err := validate.WorkflowSpec(workflow.Spec); err != nil {
+		event, eventErr := fes.NewEvent(projectors.NewWorkflowAggregate(workflow.ID()), &events.WorkflowParsingFailed{
+			Error: &types.Error{Message: err.Error()},
+		})
+		if eventErr != nil {
+			return nil, eventErr
+		}
+		if eventErr = wa.es.Append(event); eventErr != nil {
+			return nil, eventErr
+		}
 		return nil, err
 	}
 
@@ -4,6 +4,7 @@ Package apiserver contains all request handlers for gRPC and HTTP servers.
 It has these top-level request handlers:
 	Admin	   - Administrative functionality related to managing the workflow engine.
 	Invocation - Functionality related to managing invocations.
+	Trigger    - Functionality related to managing triggers.
 	Workflow   - functionality related to managing workflows.
 
 The purpose of this package is purely to provide handlers to gRPC and HTTP servers. Therefore,
@@ -14,6 +15,7 @@ packages that are responsible for the business logic, such as `api`.
 package apiserver
 
 import (
+	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/types/validate"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/sirupsen/logrus"
@@ -28,6 +30,13 @@ func toErrorStatus(err error) error {
 	case validate.Error:
 		logrus.Errorf("Request error: %v", validate.FormatConcise(err))
 		return status.Error(codes.InvalidArgument, validate.Format(err))
+	case fes.EventStoreErr:
+		if fes.ErrGenerationConflict.Is(err) {
+			logrus.Infof("Request conflicted with a concurrent modification: %v", err)
+			return status.Error(codes.Aborted, err.Error())
+		}
+		logrus.Errorf("Request error: %v", err)
+		return err
 	default:
 		logrus.Errorf("Request error: %v", err)
 		return err
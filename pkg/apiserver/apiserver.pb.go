@@ -8,11 +8,19 @@ It is generated from these files:
 	pkg/apiserver/apiserver.proto
 
 It has these top-level messages:
+	WorkflowListQuery
 	WorkflowList
+	WorkflowHistory
+	GraphRequest
+	Graph
 	AddTaskRequest
+	SignalRequest
 	InvocationListQuery
 	WorkflowInvocationList
+	InvocationSummary
 	ObjectEvents
+	TriggerListQuery
+	TriggerList
 	Health
 */
 package apiserver
@@ -21,9 +29,11 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import fission_workflows_types1 "github.com/fission/fission-workflows/pkg/types"
+import fission_workflows_types "github.com/fission/fission-workflows/pkg/types/typedvalues"
 import fission_workflows_version "github.com/fission/fission-workflows/pkg/version"
 import fission_workflows_eventstore "github.com/fission/fission-workflows/pkg/fes"
 import google_protobuf3 "github.com/golang/protobuf/ptypes/empty"
+import google_protobuf "github.com/golang/protobuf/ptypes/timestamp"
 import _ "google.golang.org/genproto/googleapis/api/annotations"
 
 import (
@@ -42,6 +52,24 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type WorkflowListQuery struct {
+	// LabelSelector limits the workflows to the workflows of which the labels match the selector, such as
+	// 'team=payments,env in (staging, prod)'.
+	LabelSelector string `protobuf:"bytes,1,opt,name=labelSelector" json:"labelSelector,omitempty"`
+}
+
+func (m *WorkflowListQuery) Reset()                    { *m = WorkflowListQuery{} }
+func (m *WorkflowListQuery) String() string            { return proto.CompactTextString(m) }
+func (*WorkflowListQuery) ProtoMessage()               {}
+func (*WorkflowListQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+func (m *WorkflowListQuery) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
 type WorkflowList struct {
 	Workflows []string `protobuf:"bytes,1,rep,name=workflows" json:"workflows,omitempty"`
 }
@@ -49,7 +77,7 @@ type WorkflowList struct {
 func (m *WorkflowList) Reset()                    { *m = WorkflowList{} }
 func (m *WorkflowList) String() string            { return proto.CompactTextString(m) }
 func (*WorkflowList) ProtoMessage()               {}
-func (*WorkflowList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+func (*WorkflowList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
 
 func (m *WorkflowList) GetWorkflows() []string {
 	if m != nil {
@@ -58,6 +86,70 @@ func (m *WorkflowList) GetWorkflows() []string {
 	return nil
 }
 
+type WorkflowHistory struct {
+	Revisions []*fission_workflows_types1.Workflow `protobuf:"bytes,1,rep,name=revisions" json:"revisions,omitempty"`
+}
+
+func (m *WorkflowHistory) Reset()                    { *m = WorkflowHistory{} }
+func (m *WorkflowHistory) String() string            { return proto.CompactTextString(m) }
+func (*WorkflowHistory) ProtoMessage()               {}
+func (*WorkflowHistory) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *WorkflowHistory) GetRevisions() []*fission_workflows_types1.Workflow {
+	if m != nil {
+		return m.Revisions
+	}
+	return nil
+}
+
+type GraphRequest struct {
+	Id     string `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	Format string `protobuf:"bytes,2,opt,name=format" json:"format,omitempty"`
+}
+
+func (m *GraphRequest) Reset()                    { *m = GraphRequest{} }
+func (m *GraphRequest) String() string            { return proto.CompactTextString(m) }
+func (*GraphRequest) ProtoMessage()               {}
+func (*GraphRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *GraphRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *GraphRequest) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+type Graph struct {
+	Format  string `protobuf:"bytes,1,opt,name=format" json:"format,omitempty"`
+	Content string `protobuf:"bytes,2,opt,name=content" json:"content,omitempty"`
+}
+
+func (m *Graph) Reset()                    { *m = Graph{} }
+func (m *Graph) String() string            { return proto.CompactTextString(m) }
+func (*Graph) ProtoMessage()               {}
+func (*Graph) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *Graph) GetFormat() string {
+	if m != nil {
+		return m.Format
+	}
+	return ""
+}
+
+func (m *Graph) GetContent() string {
+	if m != nil {
+		return m.Content
+	}
+	return ""
+}
+
 type AddTaskRequest struct {
 	InvocationID string                         `protobuf:"bytes,1,opt,name=invocationID" json:"invocationID,omitempty"`
 	Task         *fission_workflows_types1.Task `protobuf:"bytes,2,opt,name=task" json:"task,omitempty"`
@@ -66,7 +158,7 @@ type AddTaskRequest struct {
 func (m *AddTaskRequest) Reset()                    { *m = AddTaskRequest{} }
 func (m *AddTaskRequest) String() string            { return proto.CompactTextString(m) }
 func (*AddTaskRequest) ProtoMessage()               {}
-func (*AddTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+func (*AddTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *AddTaskRequest) GetInvocationID() string {
 	if m != nil {
@@ -82,14 +174,65 @@ func (m *AddTaskRequest) GetTask() *fission_workflows_types1.Task {
 	return nil
 }
 
+type SignalRequest struct {
+	InvocationID string                              `protobuf:"bytes,1,opt,name=invocationID" json:"invocationID,omitempty"`
+	TaskID       string                              `protobuf:"bytes,2,opt,name=taskID" json:"taskID,omitempty"`
+	Payload      *fission_workflows_types.TypedValue `protobuf:"bytes,3,opt,name=payload" json:"payload,omitempty"`
+}
+
+func (m *SignalRequest) Reset()                    { *m = SignalRequest{} }
+func (m *SignalRequest) String() string            { return proto.CompactTextString(m) }
+func (*SignalRequest) ProtoMessage()               {}
+func (*SignalRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *SignalRequest) GetInvocationID() string {
+	if m != nil {
+		return m.InvocationID
+	}
+	return ""
+}
+
+func (m *SignalRequest) GetTaskID() string {
+	if m != nil {
+		return m.TaskID
+	}
+	return ""
+}
+
+func (m *SignalRequest) GetPayload() *fission_workflows_types.TypedValue {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
 type InvocationListQuery struct {
+	// Workflows limits the invocations to the invocations of these workflows (by id).
 	Workflows []string `protobuf:"bytes,1,rep,name=workflows" json:"workflows,omitempty"`
+	// Statuses limits the invocations to the invocations that have one of these statuses.
+	Statuses []fission_workflows_types1.WorkflowInvocationStatus_Status `protobuf:"varint,2,rep,packed,name=statuses,enum=fission.workflows.types.WorkflowInvocationStatus_Status" json:"statuses,omitempty"`
+	// CreatedAfter and createdBefore limit the invocations to the invocations created in this (exclusive) range.
+	CreatedAfter  *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=createdAfter" json:"createdAfter,omitempty"`
+	CreatedBefore *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=createdBefore" json:"createdBefore,omitempty"`
+	// LabelSelector limits the invocations to the invocations of which the labels match the selector, such as
+	// 'workflow=<id>,!parent'. Besides the labels in their metadata, invocations have the following labels, which take
+	// precedence over the labels in the metadata:
+	// - workflow: the id of the invoked workflow.
+	// - parent: the id of the parent invocation, if the invocation is a child of another invocation.
+	// - trigger: the id of the trigger that started the invocation, if any.
+	LabelSelector string `protobuf:"bytes,5,opt,name=labelSelector" json:"labelSelector,omitempty"`
+	// PageSize is the maximum number of invocations to return. If 0, all matching invocations are returned.
+	PageSize int32 `protobuf:"varint,6,opt,name=pageSize" json:"pageSize,omitempty"`
+	// PageToken is the nextPageToken of the previous page; it is empty for the first page.
+	PageToken string `protobuf:"bytes,7,opt,name=pageToken" json:"pageToken,omitempty"`
+	// Summaries includes summaries of the invocations in the response, in addition to their ids.
+	Summaries bool `protobuf:"varint,8,opt,name=summaries" json:"summaries,omitempty"`
 }
 
 func (m *InvocationListQuery) Reset()                    { *m = InvocationListQuery{} }
 func (m *InvocationListQuery) String() string            { return proto.CompactTextString(m) }
 func (*InvocationListQuery) ProtoMessage()               {}
-func (*InvocationListQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*InvocationListQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *InvocationListQuery) GetWorkflows() []string {
 	if m != nil {
@@ -98,14 +241,67 @@ func (m *InvocationListQuery) GetWorkflows() []string {
 	return nil
 }
 
+func (m *InvocationListQuery) GetStatuses() []fission_workflows_types1.WorkflowInvocationStatus_Status {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+func (m *InvocationListQuery) GetCreatedAfter() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.CreatedAfter
+	}
+	return nil
+}
+
+func (m *InvocationListQuery) GetCreatedBefore() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.CreatedBefore
+	}
+	return nil
+}
+
+func (m *InvocationListQuery) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
+func (m *InvocationListQuery) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+func (m *InvocationListQuery) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *InvocationListQuery) GetSummaries() bool {
+	if m != nil {
+		return m.Summaries
+	}
+	return false
+}
+
 type WorkflowInvocationList struct {
 	Invocations []string `protobuf:"bytes,1,rep,name=invocations" json:"invocations,omitempty"`
+	// Summaries contains the summaries of the invocations, in the same order as the invocations, if requested.
+	Summaries []*InvocationSummary `protobuf:"bytes,2,rep,name=summaries" json:"summaries,omitempty"`
+	// NextPageToken can be used to fetch the next page of invocations. It is empty if this is the last page.
+	NextPageToken string `protobuf:"bytes,3,opt,name=nextPageToken" json:"nextPageToken,omitempty"`
 }
 
 func (m *WorkflowInvocationList) Reset()                    { *m = WorkflowInvocationList{} }
 func (m *WorkflowInvocationList) String() string            { return proto.CompactTextString(m) }
 func (*WorkflowInvocationList) ProtoMessage()               {}
-func (*WorkflowInvocationList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*WorkflowInvocationList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *WorkflowInvocationList) GetInvocations() []string {
 	if m != nil {
@@ -114,6 +310,85 @@ func (m *WorkflowInvocationList) GetInvocations() []string {
 	return nil
 }
 
+func (m *WorkflowInvocationList) GetSummaries() []*InvocationSummary {
+	if m != nil {
+		return m.Summaries
+	}
+	return nil
+}
+
+func (m *WorkflowInvocationList) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// InvocationSummary is a concise overview of a workflow invocation, which leaves out the spec and task runs.
+type InvocationSummary struct {
+	Metadata     *fission_workflows_types1.ObjectMetadata                 `protobuf:"bytes,1,opt,name=metadata" json:"metadata,omitempty"`
+	Status       fission_workflows_types1.WorkflowInvocationStatus_Status `protobuf:"varint,2,opt,name=status,enum=fission.workflows.types.WorkflowInvocationStatus_Status" json:"status,omitempty"`
+	UpdatedAt    *google_protobuf.Timestamp                               `protobuf:"bytes,3,opt,name=updatedAt" json:"updatedAt,omitempty"`
+	WorkflowId   string                                                   `protobuf:"bytes,4,opt,name=workflowId" json:"workflowId,omitempty"`
+	WorkflowName string                                                   `protobuf:"bytes,5,opt,name=workflowName" json:"workflowName,omitempty"`
+	ParentId     string                                                   `protobuf:"bytes,6,opt,name=parentId" json:"parentId,omitempty"`
+	TriggerId    string                                                   `protobuf:"bytes,7,opt,name=triggerId" json:"triggerId,omitempty"`
+}
+
+func (m *InvocationSummary) Reset()                    { *m = InvocationSummary{} }
+func (m *InvocationSummary) String() string            { return proto.CompactTextString(m) }
+func (*InvocationSummary) ProtoMessage()               {}
+func (*InvocationSummary) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+
+func (m *InvocationSummary) GetMetadata() *fission_workflows_types1.ObjectMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *InvocationSummary) GetStatus() fission_workflows_types1.WorkflowInvocationStatus_Status {
+	if m != nil {
+		return m.Status
+	}
+	return fission_workflows_types1.WorkflowInvocationStatus_UNKNOWN
+}
+
+func (m *InvocationSummary) GetUpdatedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *InvocationSummary) GetWorkflowId() string {
+	if m != nil {
+		return m.WorkflowId
+	}
+	return ""
+}
+
+func (m *InvocationSummary) GetWorkflowName() string {
+	if m != nil {
+		return m.WorkflowName
+	}
+	return ""
+}
+
+func (m *InvocationSummary) GetParentId() string {
+	if m != nil {
+		return m.ParentId
+	}
+	return ""
+}
+
+func (m *InvocationSummary) GetTriggerId() string {
+	if m != nil {
+		return m.TriggerId
+	}
+	return ""
+}
+
 type ObjectEvents struct {
 	Metadata *fission_workflows_types1.ObjectMetadata `protobuf:"bytes,1,opt,name=metadata" json:"metadata,omitempty"`
 	Events   []*fission_workflows_eventstore.Event    `protobuf:"bytes,2,rep,name=events" json:"events,omitempty"`
@@ -122,7 +397,7 @@ type ObjectEvents struct {
 func (m *ObjectEvents) Reset()                    { *m = ObjectEvents{} }
 func (m *ObjectEvents) String() string            { return proto.CompactTextString(m) }
 func (*ObjectEvents) ProtoMessage()               {}
-func (*ObjectEvents) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*ObjectEvents) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *ObjectEvents) GetMetadata() *fission_workflows_types1.ObjectMetadata {
 	if m != nil {
@@ -138,6 +413,39 @@ func (m *ObjectEvents) GetEvents() []*fission_workflows_eventstore.Event {
 	return nil
 }
 
+type TriggerListQuery struct {
+	// LabelSelector limits the triggers to the triggers of which the labels match the selector.
+	LabelSelector string `protobuf:"bytes,1,opt,name=labelSelector" json:"labelSelector,omitempty"`
+}
+
+func (m *TriggerListQuery) Reset()                    { *m = TriggerListQuery{} }
+func (m *TriggerListQuery) String() string            { return proto.CompactTextString(m) }
+func (*TriggerListQuery) ProtoMessage()               {}
+func (*TriggerListQuery) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *TriggerListQuery) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
+type TriggerList struct {
+	Triggers []string `protobuf:"bytes,1,rep,name=triggers" json:"triggers,omitempty"`
+}
+
+func (m *TriggerList) Reset()                    { *m = TriggerList{} }
+func (m *TriggerList) String() string            { return proto.CompactTextString(m) }
+func (*TriggerList) ProtoMessage()               {}
+func (*TriggerList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+
+func (m *TriggerList) GetTriggers() []string {
+	if m != nil {
+		return m.Triggers
+	}
+	return nil
+}
+
 type Health struct {
 	Status string `protobuf:"bytes,1,opt,name=status" json:"status,omitempty"`
 }
@@ -145,7 +453,7 @@ type Health struct {
 func (m *Health) Reset()                    { *m = Health{} }
 func (m *Health) String() string            { return proto.CompactTextString(m) }
 func (*Health) ProtoMessage()               {}
-func (*Health) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*Health) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *Health) GetStatus() string {
 	if m != nil {
@@ -155,11 +463,19 @@ func (m *Health) GetStatus() string {
 }
 
 func init() {
+	proto.RegisterType((*WorkflowListQuery)(nil), "fission.workflows.apiserver.WorkflowListQuery")
 	proto.RegisterType((*WorkflowList)(nil), "fission.workflows.apiserver.WorkflowList")
+	proto.RegisterType((*WorkflowHistory)(nil), "fission.workflows.apiserver.WorkflowHistory")
+	proto.RegisterType((*GraphRequest)(nil), "fission.workflows.apiserver.GraphRequest")
+	proto.RegisterType((*Graph)(nil), "fission.workflows.apiserver.Graph")
 	proto.RegisterType((*AddTaskRequest)(nil), "fission.workflows.apiserver.AddTaskRequest")
+	proto.RegisterType((*SignalRequest)(nil), "fission.workflows.apiserver.SignalRequest")
 	proto.RegisterType((*InvocationListQuery)(nil), "fission.workflows.apiserver.InvocationListQuery")
 	proto.RegisterType((*WorkflowInvocationList)(nil), "fission.workflows.apiserver.WorkflowInvocationList")
+	proto.RegisterType((*InvocationSummary)(nil), "fission.workflows.apiserver.InvocationSummary")
 	proto.RegisterType((*ObjectEvents)(nil), "fission.workflows.apiserver.ObjectEvents")
+	proto.RegisterType((*TriggerListQuery)(nil), "fission.workflows.apiserver.TriggerListQuery")
+	proto.RegisterType((*TriggerList)(nil), "fission.workflows.apiserver.TriggerList")
 	proto.RegisterType((*Health)(nil), "fission.workflows.apiserver.Health")
 }
 
@@ -176,11 +492,27 @@ const _ = grpc.SupportPackageIsVersion4
 type WorkflowAPIClient interface {
 	Create(ctx context.Context, in *fission_workflows_types1.WorkflowSpec, opts ...grpc.CallOption) (*fission_workflows_types1.ObjectMetadata, error)
 	CreateSync(ctx context.Context, in *fission_workflows_types1.WorkflowSpec, opts ...grpc.CallOption) (*fission_workflows_types1.Workflow, error)
-	List(ctx context.Context, in *google_protobuf3.Empty, opts ...grpc.CallOption) (*WorkflowList, error)
+	// Update creates a new revision of the existing workflow with the name of the spec.
+	//
+	// Revisions are immutable, so invocations of previous revisions are not affected by the update. If no workflow
+	// with the name exists, a HTTP 404 is returned.
+	Update(ctx context.Context, in *fission_workflows_types1.WorkflowSpec, opts ...grpc.CallOption) (*fission_workflows_types1.ObjectMetadata, error)
+	// List the ids of the workflows that match the query
+	//
+	// In case the query contains an invalid label selector, a HTTP 400 error status is returned.
+	List(ctx context.Context, in *WorkflowListQuery, opts ...grpc.CallOption) (*WorkflowList, error)
+	// Get returns the workflow identified by either the id or the name and revision of the metadata. The id can
+	// also be a reference of the form <name>[@<revision>]. If no revision is specified, the latest revision is
+	// returned.
 	Get(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*fission_workflows_types1.Workflow, error)
+	// History returns all revisions of the workflow with the given name, ordered from old to new.
+	History(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*WorkflowHistory, error)
 	Delete(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
 	Validate(ctx context.Context, in *fission_workflows_types1.WorkflowSpec, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
 	Events(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*ObjectEvents, error)
+	// Graph renders the dependency graph of the tasks of the workflow in the requested format: dot (default),
+	// mermaid or json.
+	Graph(ctx context.Context, in *GraphRequest, opts ...grpc.CallOption) (*Graph, error)
 }
 
 type workflowAPIClient struct {
@@ -209,7 +541,16 @@ func (c *workflowAPIClient) CreateSync(ctx context.Context, in *fission_workflow
 	return out, nil
 }
 
-func (c *workflowAPIClient) List(ctx context.Context, in *google_protobuf3.Empty, opts ...grpc.CallOption) (*WorkflowList, error) {
+func (c *workflowAPIClient) Update(ctx context.Context, in *fission_workflows_types1.WorkflowSpec, opts ...grpc.CallOption) (*fission_workflows_types1.ObjectMetadata, error) {
+	out := new(fission_workflows_types1.ObjectMetadata)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowAPI/Update", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowAPIClient) List(ctx context.Context, in *WorkflowListQuery, opts ...grpc.CallOption) (*WorkflowList, error) {
 	out := new(WorkflowList)
 	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowAPI/List", in, out, c.cc, opts...)
 	if err != nil {
@@ -227,6 +568,15 @@ func (c *workflowAPIClient) Get(ctx context.Context, in *fission_workflows_types
 	return out, nil
 }
 
+func (c *workflowAPIClient) History(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*WorkflowHistory, error) {
+	out := new(WorkflowHistory)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowAPI/History", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *workflowAPIClient) Delete(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error) {
 	out := new(google_protobuf3.Empty)
 	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowAPI/Delete", in, out, c.cc, opts...)
@@ -254,16 +604,41 @@ func (c *workflowAPIClient) Events(ctx context.Context, in *fission_workflows_ty
 	return out, nil
 }
 
+func (c *workflowAPIClient) Graph(ctx context.Context, in *GraphRequest, opts ...grpc.CallOption) (*Graph, error) {
+	out := new(Graph)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowAPI/Graph", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for WorkflowAPI service
 
 type WorkflowAPIServer interface {
 	Create(context.Context, *fission_workflows_types1.WorkflowSpec) (*fission_workflows_types1.ObjectMetadata, error)
 	CreateSync(context.Context, *fission_workflows_types1.WorkflowSpec) (*fission_workflows_types1.Workflow, error)
-	List(context.Context, *google_protobuf3.Empty) (*WorkflowList, error)
+	// Update creates a new revision of the existing workflow with the name of the spec.
+	//
+	// Revisions are immutable, so invocations of previous revisions are not affected by the update. If no workflow
+	// with the name exists, a HTTP 404 is returned.
+	Update(context.Context, *fission_workflows_types1.WorkflowSpec) (*fission_workflows_types1.ObjectMetadata, error)
+	// List the ids of the workflows that match the query
+	//
+	// In case the query contains an invalid label selector, a HTTP 400 error status is returned.
+	List(context.Context, *WorkflowListQuery) (*WorkflowList, error)
+	// Get returns the workflow identified by either the id or the name and revision of the metadata. The id can
+	// also be a reference of the form <name>[@<revision>]. If no revision is specified, the latest revision is
+	// returned.
 	Get(context.Context, *fission_workflows_types1.ObjectMetadata) (*fission_workflows_types1.Workflow, error)
+	// History returns all revisions of the workflow with the given name, ordered from old to new.
+	History(context.Context, *fission_workflows_types1.ObjectMetadata) (*WorkflowHistory, error)
 	Delete(context.Context, *fission_workflows_types1.ObjectMetadata) (*google_protobuf3.Empty, error)
 	Validate(context.Context, *fission_workflows_types1.WorkflowSpec) (*google_protobuf3.Empty, error)
 	Events(context.Context, *fission_workflows_types1.ObjectMetadata) (*ObjectEvents, error)
+	// Graph renders the dependency graph of the tasks of the workflow in the requested format: dot (default),
+	// mermaid or json.
+	Graph(context.Context, *GraphRequest) (*Graph, error)
 }
 
 func RegisterWorkflowAPIServer(s *grpc.Server, srv WorkflowAPIServer) {
@@ -306,8 +681,26 @@ func _WorkflowAPI_CreateSync_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowAPI_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(fission_workflows_types1.WorkflowSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowAPIServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowAPI/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowAPIServer).Update(ctx, req.(*fission_workflows_types1.WorkflowSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WorkflowAPI_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(google_protobuf3.Empty)
+	in := new(WorkflowListQuery)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -319,7 +712,7 @@ func _WorkflowAPI_List_Handler(srv interface{}, ctx context.Context, dec func(in
 		FullMethod: "/fission.workflows.apiserver.WorkflowAPI/List",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(WorkflowAPIServer).List(ctx, req.(*google_protobuf3.Empty))
+		return srv.(WorkflowAPIServer).List(ctx, req.(*WorkflowListQuery))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -342,6 +735,24 @@ func _WorkflowAPI_Get_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowAPI_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(fission_workflows_types1.ObjectMetadata)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowAPIServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowAPI/History",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowAPIServer).History(ctx, req.(*fission_workflows_types1.ObjectMetadata))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WorkflowAPI_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(fission_workflows_types1.ObjectMetadata)
 	if err := dec(in); err != nil {
@@ -396,6 +807,24 @@ func _WorkflowAPI_Events_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowAPI_Graph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowAPIServer).Graph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowAPI/Graph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowAPIServer).Graph(ctx, req.(*GraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _WorkflowAPI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "fission.workflows.apiserver.WorkflowAPI",
 	HandlerType: (*WorkflowAPIServer)(nil),
@@ -408,6 +837,10 @@ var _WorkflowAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSync",
 			Handler:    _WorkflowAPI_CreateSync_Handler,
 		},
+		{
+			MethodName: "Update",
+			Handler:    _WorkflowAPI_Update_Handler,
+		},
 		{
 			MethodName: "List",
 			Handler:    _WorkflowAPI_List_Handler,
@@ -416,6 +849,10 @@ var _WorkflowAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _WorkflowAPI_Get_Handler,
 		},
+		{
+			MethodName: "History",
+			Handler:    _WorkflowAPI_History_Handler,
+		},
 		{
 			MethodName: "Delete",
 			Handler:    _WorkflowAPI_Delete_Handler,
@@ -428,6 +865,10 @@ var _WorkflowAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Events",
 			Handler:    _WorkflowAPI_Events_Handler,
 		},
+		{
+			MethodName: "Graph",
+			Handler:    _WorkflowAPI_Graph_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/apiserver/apiserver.proto",
@@ -444,10 +885,23 @@ type WorkflowInvocationAPIClient interface {
 	AddTask(ctx context.Context, in *AddTaskRequest, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
 	// Cancel a workflow invocation
 	//
-	// This action is irreverisble. A canceled invocation cannot be resumed or restarted.
+	// A canceled invocation can only be resumed using Retry.
 	// In case that an invocation already is canceled, has failed or has completed, nothing happens.
 	// In case that an invocation does not exist a HTTP 404 error status is returned.
 	Cancel(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
+	// Retry a failed or canceled workflow invocation
+	//
+	// The invocation is resumed from the point of failure; only the tasks that failed, were aborted or were skipped are
+	// executed again. The outputs of the tasks that already succeeded are reused. If the deadline of the invocation
+	// has been exceeded, the invocation is granted the same runtime as it originally had.
+	// In case that the invocation is still in progress or has succeeded, a HTTP 400 error status is returned.
+	Retry(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
+	// List the workflow invocations that match the query
+	//
+	// The invocations are returned in pages if a page size is set in the query, from the most recently created to the
+	// oldest; the nextPageToken of the response can be passed as the pageToken of the next query to fetch the next
+	// page. Without a page size, all matching invocations are returned in no particular order. If the query has an
+	// invalid label selector or page token, a HTTP 400 error status is returned.
 	List(ctx context.Context, in *InvocationListQuery, opts ...grpc.CallOption) (*WorkflowInvocationList, error)
 	// Get the specification and status of a workflow invocation
 	//
@@ -455,7 +909,23 @@ type WorkflowInvocationAPIClient interface {
 	// To lighten the request load, consider using a more specific request.
 	Get(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*fission_workflows_types1.WorkflowInvocation, error)
 	Events(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*ObjectEvents, error)
+	// Watch the events of a workflow invocation
+	//
+	// The stream starts with the events that have already occurred, after which new events are streamed as they
+	// occur. The stream ends once the invocation has finished. Over HTTP the stream is offered as Server-Sent Events
+	// at GET /invocation/{id}/watch, which is not generated by the gateway (see RegisterInvocationWatchHandler).
+	Watch(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (WorkflowInvocationAPI_WatchClient, error)
 	Validate(ctx context.Context, in *fission_workflows_types1.WorkflowInvocationSpec, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
+	// Signal a task of a workflow invocation that is awaiting a signal
+	//
+	// The task, such as a task running the wait function, completes with the payload of the signal as its output,
+	// which is transformed by the output and outputHeaders of the task like the output of any other task.
+	// In case that the invocation or task does not exist a HTTP 404 error status is returned.
+	// In case that the task is not awaiting a signal, a HTTP 400 error status is returned.
+	Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
+	// Graph renders the dependency graph of the tasks of the invocation, including the dynamic tasks, in which the
+	// tasks are colored by their status. See WorkflowAPI.Graph for the supported formats.
+	Graph(ctx context.Context, in *GraphRequest, opts ...grpc.CallOption) (*Graph, error)
 }
 
 type workflowInvocationAPIClient struct {
@@ -502,6 +972,15 @@ func (c *workflowInvocationAPIClient) Cancel(ctx context.Context, in *fission_wo
 	return out, nil
 }
 
+func (c *workflowInvocationAPIClient) Retry(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error) {
+	out := new(google_protobuf3.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/Retry", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *workflowInvocationAPIClient) List(ctx context.Context, in *InvocationListQuery, opts ...grpc.CallOption) (*WorkflowInvocationList, error) {
 	out := new(WorkflowInvocationList)
 	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/List", in, out, c.cc, opts...)
@@ -529,6 +1008,38 @@ func (c *workflowInvocationAPIClient) Events(ctx context.Context, in *fission_wo
 	return out, nil
 }
 
+func (c *workflowInvocationAPIClient) Watch(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (WorkflowInvocationAPI_WatchClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_WorkflowInvocationAPI_serviceDesc.Streams[0], c.cc, "/fission.workflows.apiserver.WorkflowInvocationAPI/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &workflowInvocationAPIWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type WorkflowInvocationAPI_WatchClient interface {
+	Recv() (*fission_workflows_eventstore.Event, error)
+	grpc.ClientStream
+}
+
+type workflowInvocationAPIWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *workflowInvocationAPIWatchClient) Recv() (*fission_workflows_eventstore.Event, error) {
+	m := new(fission_workflows_eventstore.Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *workflowInvocationAPIClient) Validate(ctx context.Context, in *fission_workflows_types1.WorkflowInvocationSpec, opts ...grpc.CallOption) (*google_protobuf3.Empty, error) {
 	out := new(google_protobuf3.Empty)
 	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/Validate", in, out, c.cc, opts...)
@@ -538,6 +1049,24 @@ func (c *workflowInvocationAPIClient) Validate(ctx context.Context, in *fission_
 	return out, nil
 }
 
+func (c *workflowInvocationAPIClient) Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (*google_protobuf3.Empty, error) {
+	out := new(google_protobuf3.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/Signal", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workflowInvocationAPIClient) Graph(ctx context.Context, in *GraphRequest, opts ...grpc.CallOption) (*Graph, error) {
+	out := new(Graph)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.WorkflowInvocationAPI/Graph", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for WorkflowInvocationAPI service
 
 type WorkflowInvocationAPIServer interface {
@@ -549,10 +1078,23 @@ type WorkflowInvocationAPIServer interface {
 	AddTask(context.Context, *AddTaskRequest) (*google_protobuf3.Empty, error)
 	// Cancel a workflow invocation
 	//
-	// This action is irreverisble. A canceled invocation cannot be resumed or restarted.
+	// A canceled invocation can only be resumed using Retry.
 	// In case that an invocation already is canceled, has failed or has completed, nothing happens.
 	// In case that an invocation does not exist a HTTP 404 error status is returned.
 	Cancel(context.Context, *fission_workflows_types1.ObjectMetadata) (*google_protobuf3.Empty, error)
+	// Retry a failed or canceled workflow invocation
+	//
+	// The invocation is resumed from the point of failure; only the tasks that failed, were aborted or were skipped are
+	// executed again. The outputs of the tasks that already succeeded are reused. If the deadline of the invocation
+	// has been exceeded, the invocation is granted the same runtime as it originally had.
+	// In case that the invocation is still in progress or has succeeded, a HTTP 400 error status is returned.
+	Retry(context.Context, *fission_workflows_types1.ObjectMetadata) (*google_protobuf3.Empty, error)
+	// List the workflow invocations that match the query
+	//
+	// The invocations are returned in pages if a page size is set in the query, from the most recently created to the
+	// oldest; the nextPageToken of the response can be passed as the pageToken of the next query to fetch the next
+	// page. Without a page size, all matching invocations are returned in no particular order. If the query has an
+	// invalid label selector or page token, a HTTP 400 error status is returned.
 	List(context.Context, *InvocationListQuery) (*WorkflowInvocationList, error)
 	// Get the specification and status of a workflow invocation
 	//
@@ -560,7 +1102,23 @@ type WorkflowInvocationAPIServer interface {
 	// To lighten the request load, consider using a more specific request.
 	Get(context.Context, *fission_workflows_types1.ObjectMetadata) (*fission_workflows_types1.WorkflowInvocation, error)
 	Events(context.Context, *fission_workflows_types1.ObjectMetadata) (*ObjectEvents, error)
+	// Watch the events of a workflow invocation
+	//
+	// The stream starts with the events that have already occurred, after which new events are streamed as they
+	// occur. The stream ends once the invocation has finished. Over HTTP the stream is offered as Server-Sent Events
+	// at GET /invocation/{id}/watch, which is not generated by the gateway (see RegisterInvocationWatchHandler).
+	Watch(*fission_workflows_types1.ObjectMetadata, WorkflowInvocationAPI_WatchServer) error
 	Validate(context.Context, *fission_workflows_types1.WorkflowInvocationSpec) (*google_protobuf3.Empty, error)
+	// Signal a task of a workflow invocation that is awaiting a signal
+	//
+	// The task, such as a task running the wait function, completes with the payload of the signal as its output,
+	// which is transformed by the output and outputHeaders of the task like the output of any other task.
+	// In case that the invocation or task does not exist a HTTP 404 error status is returned.
+	// In case that the task is not awaiting a signal, a HTTP 400 error status is returned.
+	Signal(context.Context, *SignalRequest) (*google_protobuf3.Empty, error)
+	// Graph renders the dependency graph of the tasks of the invocation, including the dynamic tasks, in which the
+	// tasks are colored by their status. See WorkflowAPI.Graph for the supported formats.
+	Graph(context.Context, *GraphRequest) (*Graph, error)
 }
 
 func RegisterWorkflowInvocationAPIServer(s *grpc.Server, srv WorkflowInvocationAPIServer) {
@@ -639,6 +1197,24 @@ func _WorkflowInvocationAPI_Cancel_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowInvocationAPI_Retry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(fission_workflows_types1.ObjectMetadata)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowInvocationAPIServer).Retry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowInvocationAPI/Retry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowInvocationAPIServer).Retry(ctx, req.(*fission_workflows_types1.ObjectMetadata))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _WorkflowInvocationAPI_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(InvocationListQuery)
 	if err := dec(in); err != nil {
@@ -693,6 +1269,27 @@ func _WorkflowInvocationAPI_Events_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowInvocationAPI_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(fission_workflows_types1.ObjectMetadata)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(WorkflowInvocationAPIServer).Watch(m, &workflowInvocationAPIWatchServer{stream})
+}
+
+type WorkflowInvocationAPI_WatchServer interface {
+	Send(*fission_workflows_eventstore.Event) error
+	grpc.ServerStream
+}
+
+type workflowInvocationAPIWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *workflowInvocationAPIWatchServer) Send(m *fission_workflows_eventstore.Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _WorkflowInvocationAPI_Validate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(fission_workflows_types1.WorkflowInvocationSpec)
 	if err := dec(in); err != nil {
@@ -711,6 +1308,42 @@ func _WorkflowInvocationAPI_Validate_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkflowInvocationAPI_Signal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SignalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowInvocationAPIServer).Signal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowInvocationAPI/Signal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowInvocationAPIServer).Signal(ctx, req.(*SignalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WorkflowInvocationAPI_Graph_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GraphRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkflowInvocationAPIServer).Graph(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.WorkflowInvocationAPI/Graph",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkflowInvocationAPIServer).Graph(ctx, req.(*GraphRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _WorkflowInvocationAPI_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "fission.workflows.apiserver.WorkflowInvocationAPI",
 	HandlerType: (*WorkflowInvocationAPIServer)(nil),
@@ -731,6 +1364,10 @@ var _WorkflowInvocationAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Cancel",
 			Handler:    _WorkflowInvocationAPI_Cancel_Handler,
 		},
+		{
+			MethodName: "Retry",
+			Handler:    _WorkflowInvocationAPI_Retry_Handler,
+		},
 		{
 			MethodName: "List",
 			Handler:    _WorkflowInvocationAPI_List_Handler,
@@ -747,6 +1384,201 @@ var _WorkflowInvocationAPI_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Validate",
 			Handler:    _WorkflowInvocationAPI_Validate_Handler,
 		},
+		{
+			MethodName: "Signal",
+			Handler:    _WorkflowInvocationAPI_Signal_Handler,
+		},
+		{
+			MethodName: "Graph",
+			Handler:    _WorkflowInvocationAPI_Graph_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Watch",
+			Handler:       _WorkflowInvocationAPI_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/apiserver/apiserver.proto",
+}
+
+// Client API for TriggerAPI service
+
+type TriggerAPIClient interface {
+	// Create a new trigger
+	//
+	// In case the trigger specification is missing fields or contains invalid fields, such as an invalid cron
+	// expression, a HTTP 400 is returned.
+	Create(ctx context.Context, in *fission_workflows_types1.TriggerSpec, opts ...grpc.CallOption) (*fission_workflows_types1.ObjectMetadata, error)
+	// List the ids of the triggers that have not been deleted and match the query.
+	List(ctx context.Context, in *TriggerListQuery, opts ...grpc.CallOption) (*TriggerList, error)
+	Get(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*fission_workflows_types1.Trigger, error)
+	// Delete a trigger
+	//
+	// Invocations that have already been started by the trigger are not affected.
+	// In case that the trigger does not exist a HTTP 404 error status is returned.
+	Delete(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error)
+}
+
+type triggerAPIClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewTriggerAPIClient(cc *grpc.ClientConn) TriggerAPIClient {
+	return &triggerAPIClient{cc}
+}
+
+func (c *triggerAPIClient) Create(ctx context.Context, in *fission_workflows_types1.TriggerSpec, opts ...grpc.CallOption) (*fission_workflows_types1.ObjectMetadata, error) {
+	out := new(fission_workflows_types1.ObjectMetadata)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.TriggerAPI/Create", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *triggerAPIClient) List(ctx context.Context, in *TriggerListQuery, opts ...grpc.CallOption) (*TriggerList, error) {
+	out := new(TriggerList)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.TriggerAPI/List", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *triggerAPIClient) Get(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*fission_workflows_types1.Trigger, error) {
+	out := new(fission_workflows_types1.Trigger)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.TriggerAPI/Get", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *triggerAPIClient) Delete(ctx context.Context, in *fission_workflows_types1.ObjectMetadata, opts ...grpc.CallOption) (*google_protobuf3.Empty, error) {
+	out := new(google_protobuf3.Empty)
+	err := grpc.Invoke(ctx, "/fission.workflows.apiserver.TriggerAPI/Delete", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for TriggerAPI service
+
+type TriggerAPIServer interface {
+	// Create a new trigger
+	//
+	// In case the trigger specification is missing fields or contains invalid fields, such as an invalid cron
+	// expression, a HTTP 400 is returned.
+	Create(context.Context, *fission_workflows_types1.TriggerSpec) (*fission_workflows_types1.ObjectMetadata, error)
+	// List the ids of the triggers that have not been deleted and match the query.
+	List(context.Context, *TriggerListQuery) (*TriggerList, error)
+	Get(context.Context, *fission_workflows_types1.ObjectMetadata) (*fission_workflows_types1.Trigger, error)
+	// Delete a trigger
+	//
+	// Invocations that have already been started by the trigger are not affected.
+	// In case that the trigger does not exist a HTTP 404 error status is returned.
+	Delete(context.Context, *fission_workflows_types1.ObjectMetadata) (*google_protobuf3.Empty, error)
+}
+
+func RegisterTriggerAPIServer(s *grpc.Server, srv TriggerAPIServer) {
+	s.RegisterService(&_TriggerAPI_serviceDesc, srv)
+}
+
+func _TriggerAPI_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(fission_workflows_types1.TriggerSpec)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerAPIServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.TriggerAPI/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerAPIServer).Create(ctx, req.(*fission_workflows_types1.TriggerSpec))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TriggerAPI_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerListQuery)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerAPIServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.TriggerAPI/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerAPIServer).List(ctx, req.(*TriggerListQuery))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TriggerAPI_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(fission_workflows_types1.ObjectMetadata)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerAPIServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.TriggerAPI/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerAPIServer).Get(ctx, req.(*fission_workflows_types1.ObjectMetadata))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TriggerAPI_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(fission_workflows_types1.ObjectMetadata)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TriggerAPIServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/fission.workflows.apiserver.TriggerAPI/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TriggerAPIServer).Delete(ctx, req.(*fission_workflows_types1.ObjectMetadata))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TriggerAPI_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "fission.workflows.apiserver.TriggerAPI",
+	HandlerType: (*TriggerAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _TriggerAPI_Create_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _TriggerAPI_List_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _TriggerAPI_Get_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _TriggerAPI_Delete_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/apiserver/apiserver.proto",
@@ -852,58 +1684,98 @@ var _AdminAPI_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/apiserver/apiserver.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 835 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x56, 0xd1, 0x4e, 0xe3, 0x56,
-	0x10, 0x95, 0x01, 0x99, 0x64, 0x4c, 0x51, 0x3a, 0x40, 0x08, 0x01, 0x44, 0x7a, 0x51, 0xd5, 0x00,
-	0xad, 0xdd, 0x06, 0xa9, 0x0f, 0x54, 0xaa, 0x44, 0x01, 0xb5, 0x91, 0x5a, 0xd1, 0x0d, 0x08, 0x24,
-	0xb4, 0x2f, 0x8e, 0x73, 0x93, 0x78, 0x93, 0xd8, 0xc1, 0xbe, 0x09, 0x0a, 0x28, 0x2f, 0xec, 0x0f,
-	0xac, 0xb4, 0x8f, 0xfb, 0xb0, 0xcf, 0xfb, 0x09, 0xfb, 0x1d, 0xfb, 0x0b, 0xfb, 0x21, 0x2b, 0x5f,
-	0x5f, 0x27, 0x0e, 0xc1, 0x89, 0xa3, 0xd5, 0x3e, 0x40, 0x92, 0xf1, 0xcc, 0x9c, 0x39, 0x73, 0xe7,
-	0xcc, 0x35, 0x6c, 0xb7, 0x1b, 0x35, 0x4d, 0x6f, 0x9b, 0x2e, 0x75, 0xba, 0xd4, 0x19, 0x7e, 0x53,
-	0xdb, 0x8e, 0xcd, 0x6c, 0xdc, 0xac, 0x9a, 0xae, 0x6b, 0xda, 0x96, 0x7a, 0x67, 0x3b, 0x8d, 0x6a,
-	0xd3, 0xbe, 0x73, 0xd5, 0x81, 0x4b, 0xf6, 0xa8, 0x66, 0xb2, 0x7a, 0xa7, 0xac, 0x1a, 0x76, 0x4b,
-	0x13, 0x7e, 0xc1, 0xe7, 0x2f, 0x03, 0x7f, 0xcd, 0x03, 0x60, 0xbd, 0x36, 0x75, 0xfd, 0xff, 0x7e,
-	0xe2, 0xec, 0x9f, 0xb1, 0x63, 0xbb, 0xd4, 0xe1, 0x4f, 0xc5, 0xa7, 0x88, 0xff, 0x3d, 0x76, 0x7c,
-	0x95, 0xba, 0xde, 0x9f, 0x88, 0xdb, 0xac, 0xd9, 0x76, 0xad, 0x49, 0x35, 0xfe, 0xab, 0xdc, 0xa9,
-	0x6a, 0xb4, 0xd5, 0x66, 0x3d, 0xf1, 0x70, 0x4b, 0x3c, 0xd4, 0xdb, 0xa6, 0xa6, 0x5b, 0x96, 0xcd,
-	0x74, 0x66, 0xda, 0x96, 0x08, 0x25, 0x3f, 0xc3, 0xd2, 0xb5, 0xc8, 0xfc, 0xaf, 0xe9, 0x32, 0xdc,
-	0x82, 0xe4, 0x00, 0x29, 0x23, 0xe5, 0xe6, 0xf3, 0xc9, 0xd2, 0xd0, 0x40, 0x6a, 0xb0, 0x7c, 0x5c,
-	0xa9, 0x5c, 0xea, 0x6e, 0xa3, 0x44, 0x6f, 0x3b, 0xd4, 0x65, 0x48, 0x60, 0xc9, 0xb4, 0xba, 0xb6,
-	0xc1, 0x93, 0x16, 0x4f, 0x33, 0x52, 0x4e, 0xca, 0x27, 0x4b, 0x23, 0x36, 0xfc, 0x0d, 0x16, 0x98,
-	0xee, 0x36, 0x32, 0x73, 0x39, 0x29, 0xaf, 0x14, 0xb6, 0xd5, 0xf1, 0xf6, 0xfb, 0x4d, 0xe4, 0x79,
-	0xb9, 0x2b, 0x39, 0x84, 0x95, 0xe2, 0x20, 0x85, 0x57, 0xd8, 0x8b, 0x0e, 0x75, 0x7a, 0x53, 0xaa,
-	0x3b, 0x82, 0x74, 0xc0, 0x65, 0x34, 0x18, 0x73, 0xa0, 0x0c, 0x2b, 0x0a, 0x22, 0xc3, 0x26, 0xf2,
-	0x46, 0x82, 0xa5, 0xf3, 0xf2, 0x2b, 0x6a, 0xb0, 0xb3, 0x2e, 0xb5, 0x98, 0x8b, 0x27, 0x90, 0x68,
-	0x51, 0xa6, 0x57, 0x74, 0xa6, 0x73, 0x52, 0x4a, 0xe1, 0xa7, 0xc8, 0xc2, 0xfd, 0xc0, 0xff, 0x84,
-	0x7b, 0x69, 0x10, 0x88, 0x7f, 0x80, 0x4c, 0x79, 0xba, 0xcc, 0x5c, 0x6e, 0x3e, 0xaf, 0x14, 0x76,
-	0x9f, 0x49, 0xe1, 0x3b, 0x30, 0xdb, 0xa1, 0x2a, 0x87, 0x2e, 0x89, 0x10, 0x92, 0x03, 0xf9, 0x1f,
-	0xaa, 0x37, 0x59, 0x1d, 0xd3, 0x20, 0xbb, 0x4c, 0x67, 0x1d, 0x57, 0xb4, 0x57, 0xfc, 0x2a, 0xbc,
-	0x96, 0x41, 0x09, 0x18, 0x1f, 0xff, 0x5f, 0x44, 0x0b, 0xe4, 0x13, 0x87, 0xea, 0x8c, 0xe2, 0x8f,
-	0x91, 0xb5, 0x06, 0xfe, 0x17, 0x6d, 0x6a, 0x64, 0xe3, 0x52, 0x22, 0xab, 0x8f, 0x9f, 0x3e, 0xbf,
-	0x9d, 0x5b, 0x26, 0x49, 0x2d, 0x70, 0x3c, 0x92, 0xf6, 0xf1, 0x16, 0xc0, 0xc7, 0xbb, 0xe8, 0x59,
-	0x46, 0x5c, 0xcc, 0x1f, 0xa6, 0xba, 0x91, 0x0d, 0x8e, 0xb6, 0x42, 0x96, 0x07, 0x68, 0x9a, 0xdb,
-	0xb3, 0x0c, 0x0f, 0xf2, 0x25, 0x2c, 0xf0, 0x13, 0x4d, 0xab, 0xfe, 0x58, 0xab, 0xc1, 0xcc, 0xab,
-	0x67, 0xde, 0xcc, 0x67, 0xf7, 0xd4, 0x09, 0xe2, 0x56, 0xc3, 0xa3, 0x4e, 0xbe, 0xe7, 0x28, 0x0a,
-	0x0e, 0x39, 0xa1, 0x09, 0xf3, 0x7f, 0x53, 0x86, 0x71, 0xdb, 0x12, 0x87, 0x4b, 0x9a, 0xa3, 0xa4,
-	0x30, 0xc4, 0xe5, 0xc1, 0xac, 0xf4, 0x51, 0x07, 0xf9, 0x94, 0x36, 0x29, 0xa3, 0xf1, 0xd1, 0x22,
-	0x38, 0x07, 0x10, 0xfb, 0x4f, 0x21, 0xea, 0x90, 0xb8, 0xd2, 0x9b, 0x66, 0x65, 0x86, 0x81, 0x88,
-	0x82, 0xd8, 0xe6, 0x10, 0xeb, 0x04, 0x87, 0x10, 0x5d, 0x91, 0xda, 0x3b, 0x95, 0x07, 0x90, 0x85,
-	0x6c, 0x62, 0x93, 0x99, 0x7c, 0x50, 0x61, 0x29, 0x06, 0xe0, 0xb8, 0x36, 0xca, 0x4f, 0xf3, 0x75,
-	0x52, 0xf8, 0x90, 0x80, 0xb5, 0x71, 0xdd, 0x7b, 0x7a, 0xb8, 0x07, 0xd9, 0x33, 0x34, 0x28, 0x6a,
-	0x53, 0xe9, 0x0f, 0x23, 0x67, 0x53, 0x86, 0x68, 0x3e, 0x51, 0xb4, 0xe1, 0x3a, 0xf1, 0x5a, 0xf2,
-	0x4e, 0x02, 0xf0, 0xc1, 0xb9, 0x38, 0x66, 0x2e, 0xe0, 0x60, 0x86, 0x00, 0xa2, 0xf1, 0x22, 0xf6,
-	0x48, 0x2a, 0x54, 0x44, 0x20, 0x99, 0x1b, 0xc4, 0x31, 0x33, 0xbe, 0x97, 0x60, 0x51, 0x6c, 0x72,
-	0x3c, 0x98, 0x78, 0x12, 0xa3, 0xfb, 0x3e, 0x72, 0x40, 0xce, 0x79, 0x05, 0x45, 0x92, 0x0b, 0x43,
-	0x3d, 0x84, 0xaf, 0x81, 0xbe, 0xe6, 0x6d, 0x76, 0xd7, 0xab, 0x88, 0x64, 0xa7, 0xba, 0xa1, 0x01,
-	0xf2, 0x89, 0x6e, 0x19, 0xb4, 0xf9, 0xf5, 0xfa, 0xc8, 0xf0, 0xda, 0x70, 0x3f, 0x35, 0x0a, 0x5a,
-	0xe9, 0xe3, 0xa3, 0x24, 0xd6, 0xc9, 0xaf, 0x13, 0x7b, 0xf0, 0xcc, 0x55, 0x94, 0x3d, 0x8c, 0xb5,
-	0x68, 0x46, 0x23, 0xc9, 0x0a, 0xaf, 0xe4, 0x3b, 0x0c, 0x0f, 0x0b, 0x76, 0x66, 0x5c, 0x3a, 0x33,
-	0x4d, 0x86, 0xe0, 0x8e, 0xe3, 0xdc, 0xfb, 0xdf, 0x54, 0xb3, 0x3b, 0x1c, 0x77, 0x03, 0xd7, 0x9f,
-	0xe2, 0x0a, 0xd5, 0x22, 0x0b, 0x2d, 0xa7, 0x99, 0xc5, 0x11, 0x75, 0xd2, 0x02, 0x95, 0xac, 0x86,
-	0x51, 0x43, 0x8b, 0xaa, 0xf0, 0x51, 0x82, 0xc4, 0x71, 0xa5, 0x65, 0xf2, 0xf5, 0x70, 0x0d, 0xf2,
-	0x05, 0xbf, 0x48, 0x23, 0x6f, 0x93, 0xdd, 0x89, 0x84, 0xfd, 0xdb, 0x99, 0xa4, 0x38, 0x28, 0x60,
-	0x42, 0xab, 0x73, 0xc3, 0x3d, 0x5e, 0xc2, 0xe2, 0x95, 0xff, 0x62, 0x17, 0x99, 0x79, 0xe7, 0x99,
-	0xcc, 0xc1, 0xcb, 0x60, 0xd1, 0xaa, 0xda, 0xa1, 0xac, 0xc2, 0xfc, 0x97, 0x72, 0x93, 0x1c, 0x60,
-	0x97, 0x65, 0x9e, 0xef, 0xf0, 0x4b, 0x00, 0x00, 0x00, 0xff, 0xff, 0x8f, 0xab, 0xd0, 0x49, 0xeb,
-	0x0a, 0x00, 0x00,
+	// 1477 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x6e, 0x13, 0x47,
+	0x14, 0xd6, 0xda, 0x64, 0x63, 0x1f, 0x27, 0x21, 0x0c, 0xc4, 0x2c, 0xe6, 0xcf, 0x1d, 0xa8, 0x1a,
+	0x02, 0xec, 0x52, 0xa3, 0x22, 0x7e, 0x54, 0xda, 0xf0, 0x23, 0xb0, 0x44, 0x0b, 0xdd, 0xa4, 0x44,
+	0xe2, 0xaa, 0x13, 0xef, 0xd8, 0xd9, 0x66, 0xbd, 0x6b, 0x76, 0xc7, 0xa6, 0x26, 0xca, 0x0d, 0x77,
+	0x54, 0xaa, 0x54, 0xa9, 0x97, 0xbd, 0xe8, 0x1b, 0xf4, 0xba, 0x7d, 0x8e, 0xbe, 0x41, 0xd5, 0x07,
+	0xe8, 0x23, 0x54, 0x3b, 0x33, 0xfb, 0x17, 0x63, 0x7b, 0x5d, 0xc2, 0x45, 0x62, 0xcf, 0xd9, 0x73,
+	0xce, 0x37, 0xe7, 0xcc, 0x77, 0xce, 0x9e, 0x31, 0x9c, 0xed, 0xed, 0x76, 0x0c, 0xd2, 0xb3, 0x03,
+	0xea, 0x0f, 0xa8, 0x9f, 0x7c, 0xd3, 0x7b, 0xbe, 0xc7, 0x3c, 0x74, 0xba, 0x6d, 0x07, 0x81, 0xed,
+	0xb9, 0xfa, 0x2b, 0xcf, 0xdf, 0x6d, 0x3b, 0xde, 0xab, 0x40, 0x8f, 0x55, 0x6a, 0xb7, 0x3b, 0x36,
+	0xdb, 0xe9, 0x6f, 0xeb, 0x2d, 0xaf, 0x6b, 0x48, 0xbd, 0xe8, 0xf3, 0x6a, 0xac, 0x6f, 0x84, 0x00,
+	0x6c, 0xd8, 0xa3, 0x81, 0xf8, 0x2f, 0x1c, 0xd7, 0x9e, 0xfc, 0x0f, 0x5b, 0x6b, 0x40, 0x9c, 0x7e,
+	0xf6, 0xbb, 0xf4, 0x76, 0x37, 0xb7, 0xb7, 0x01, 0xf5, 0xf9, 0x53, 0xf9, 0x29, 0xed, 0x6f, 0xe4,
+	0xb6, 0x6f, 0xd3, 0x20, 0xfc, 0x93, 0x76, 0xa7, 0x3b, 0x9e, 0xd7, 0x71, 0xa8, 0xc1, 0x57, 0xdb,
+	0xfd, 0xb6, 0x41, 0xbb, 0x3d, 0x36, 0x94, 0x0f, 0xcf, 0x1f, 0x7c, 0xc8, 0xec, 0x2e, 0x0d, 0x18,
+	0xe9, 0xf6, 0xa4, 0xc2, 0x19, 0xa9, 0x40, 0x7a, 0xb6, 0x41, 0x5c, 0xd7, 0x63, 0x84, 0xd9, 0x9e,
+	0x2b, 0x7d, 0xe3, 0x5b, 0x70, 0x6c, 0x4b, 0x42, 0x3f, 0xb1, 0x03, 0xf6, 0x4d, 0x9f, 0xfa, 0x43,
+	0x74, 0x11, 0x16, 0x1d, 0xb2, 0x4d, 0x9d, 0x0d, 0xea, 0xd0, 0x16, 0xf3, 0x7c, 0x4d, 0xa9, 0x2b,
+	0xab, 0x65, 0x33, 0x2b, 0xc4, 0x57, 0x60, 0x21, 0x6d, 0x8a, 0xce, 0x40, 0x39, 0x8e, 0x42, 0x53,
+	0xea, 0xc5, 0xd5, 0xb2, 0x99, 0x08, 0xb0, 0x09, 0x47, 0x23, 0xed, 0xc7, 0x76, 0xc0, 0x3c, 0x7f,
+	0x88, 0xbe, 0x80, 0xb2, 0x4f, 0x07, 0x76, 0x18, 0xbf, 0x30, 0xa8, 0x34, 0x3e, 0xd2, 0x47, 0xa9,
+	0x20, 0x0e, 0x34, 0x32, 0x36, 0x13, 0x1b, 0x7c, 0x03, 0x16, 0x1e, 0xf9, 0xa4, 0xb7, 0x63, 0xd2,
+	0x97, 0x7d, 0x1a, 0x30, 0xb4, 0x04, 0x05, 0xdb, 0x92, 0x9b, 0x2d, 0xd8, 0x16, 0xaa, 0x82, 0xda,
+	0xf6, 0xfc, 0x2e, 0x61, 0x5a, 0x81, 0xcb, 0xe4, 0x0a, 0xdf, 0x82, 0x39, 0x6e, 0x97, 0x52, 0x50,
+	0xd2, 0x0a, 0x48, 0x83, 0xf9, 0x96, 0xe7, 0x32, 0xea, 0x46, 0x96, 0xd1, 0x12, 0x77, 0x60, 0x69,
+	0xdd, 0xb2, 0x36, 0x49, 0xb0, 0x1b, 0x81, 0x62, 0x58, 0xb0, 0xdd, 0x81, 0xd7, 0xe2, 0x69, 0x6d,
+	0x3e, 0x90, 0x9e, 0x32, 0x32, 0xf4, 0x29, 0x1c, 0x61, 0x24, 0xd8, 0xe5, 0xce, 0x2a, 0x8d, 0xb3,
+	0x63, 0x83, 0xe4, 0x7e, 0xb9, 0x2a, 0xfe, 0x51, 0x81, 0xc5, 0x0d, 0xbb, 0xe3, 0x12, 0x67, 0x16,
+	0xa0, 0x2a, 0xa8, 0xa1, 0x75, 0xf3, 0x41, 0x14, 0xb1, 0x58, 0xa1, 0xcf, 0x61, 0xbe, 0x47, 0x86,
+	0x8e, 0x47, 0x2c, 0xad, 0xc8, 0xf7, 0x70, 0x61, 0xfc, 0x1e, 0x42, 0xde, 0x3f, 0x0f, 0x79, 0x6f,
+	0x46, 0x36, 0xf8, 0x6d, 0x11, 0x8e, 0x37, 0x63, 0x9c, 0x84, 0x28, 0x13, 0x8f, 0x1c, 0x6d, 0x42,
+	0x29, 0x60, 0x84, 0xf5, 0x03, 0x1a, 0x68, 0x85, 0x7a, 0x71, 0x75, 0xa9, 0x71, 0x73, 0xea, 0xf1,
+	0x26, 0x28, 0x1b, 0xdc, 0x54, 0x17, 0x1f, 0x66, 0xec, 0x09, 0xdd, 0x85, 0x85, 0x96, 0x4f, 0x09,
+	0xa3, 0xd6, 0x7a, 0x9b, 0x51, 0x5f, 0xc6, 0x53, 0xd3, 0x05, 0xcd, 0xf5, 0xa8, 0x0e, 0xf4, 0xcd,
+	0xa8, 0x0e, 0xcc, 0x8c, 0x3e, 0xfa, 0x12, 0x16, 0xe5, 0xfa, 0x1e, 0x6d, 0x7b, 0x3e, 0xd5, 0x8e,
+	0x4c, 0x75, 0x90, 0x35, 0x18, 0x2d, 0x8f, 0xb9, 0x77, 0x94, 0x07, 0xaa, 0x41, 0xa9, 0x47, 0x3a,
+	0x74, 0xc3, 0x7e, 0x4d, 0x35, 0xb5, 0xae, 0xac, 0xce, 0x99, 0xf1, 0x3a, 0xcc, 0x5b, 0xf8, 0x7d,
+	0xd3, 0xdb, 0xa5, 0xae, 0x36, 0xcf, 0xad, 0x13, 0x41, 0xf8, 0x34, 0xe8, 0x77, 0xbb, 0xc4, 0xb7,
+	0x69, 0xa0, 0x95, 0xea, 0xca, 0x6a, 0xc9, 0x4c, 0x04, 0xf8, 0x77, 0x05, 0xaa, 0xa3, 0xd9, 0xe2,
+	0x15, 0x58, 0x87, 0x4a, 0xc2, 0x86, 0xe8, 0x40, 0xd2, 0x22, 0xf4, 0x24, 0xed, 0xba, 0xc0, 0x4b,
+	0x4e, 0xd7, 0x27, 0x74, 0x5f, 0x3d, 0x75, 0x1e, 0xdc, 0x6e, 0x98, 0xda, 0x4a, 0x98, 0x08, 0x97,
+	0xfe, 0xc0, 0x9e, 0xc5, 0xa1, 0x14, 0x45, 0x22, 0x32, 0x42, 0xfc, 0x77, 0x01, 0x8e, 0x8d, 0xb8,
+	0x41, 0xf7, 0xa1, 0xd4, 0xa5, 0x8c, 0x58, 0x84, 0x11, 0xce, 0xe4, 0x4a, 0xe3, 0x93, 0xb1, 0xe4,
+	0x78, 0xba, 0xfd, 0x3d, 0x6d, 0xb1, 0xaf, 0xa4, 0xba, 0x19, 0x1b, 0xa2, 0x67, 0xa0, 0x0a, 0x5e,
+	0x70, 0xba, 0xbf, 0x0f, 0xbf, 0xa4, 0x1f, 0x74, 0x13, 0xca, 0xfd, 0x9e, 0xc5, 0xd9, 0xc2, 0x72,
+	0x50, 0x2b, 0x51, 0x46, 0xe7, 0x00, 0x22, 0xd0, 0xa6, 0xc5, 0x49, 0x55, 0x36, 0x53, 0x92, 0xb0,
+	0x7c, 0xa3, 0xd5, 0xd7, 0xa4, 0x4b, 0x25, 0x69, 0x32, 0x32, 0xc1, 0x19, 0x9f, 0xba, 0xac, 0x69,
+	0x71, 0xce, 0x94, 0xcd, 0x78, 0x1d, 0xb2, 0x82, 0xf9, 0x76, 0xa7, 0x43, 0xfd, 0xa6, 0x15, 0x71,
+	0x26, 0x16, 0xe0, 0x9f, 0x15, 0x58, 0x10, 0x69, 0x7a, 0x38, 0xa0, 0x2e, 0x0b, 0x0e, 0x27, 0xbf,
+	0x77, 0x40, 0xa5, 0xdc, 0x9d, 0xe4, 0xca, 0xbb, 0xba, 0x86, 0x50, 0x60, 0x9e, 0x4f, 0x75, 0x0e,
+	0x6d, 0x4a, 0x13, 0x7c, 0x13, 0x96, 0x37, 0xc5, 0xfe, 0x66, 0x7d, 0xb3, 0x5c, 0x82, 0x4a, 0xca,
+	0x32, 0xcc, 0x8a, 0x0c, 0x34, 0xe2, 0x74, 0xbc, 0xc6, 0x75, 0x50, 0x1f, 0x53, 0xe2, 0x30, 0xde,
+	0xcb, 0x25, 0x17, 0x64, 0x2f, 0x17, 0xab, 0xc6, 0x4f, 0x65, 0xa8, 0x44, 0xa7, 0xbf, 0xfe, 0xac,
+	0x89, 0x5c, 0x50, 0xef, 0xf3, 0x72, 0x46, 0x1f, 0x4f, 0x65, 0xcb, 0x46, 0x8f, 0xb6, 0x6a, 0x79,
+	0xf3, 0x86, 0x4f, 0xbc, 0xf9, 0xeb, 0x9f, 0x5f, 0x0a, 0x4b, 0xb8, 0x6c, 0x44, 0x8a, 0xb7, 0x95,
+	0x35, 0xf4, 0x12, 0x40, 0xe0, 0x6d, 0x0c, 0xdd, 0x56, 0x5e, 0xcc, 0xe9, 0xef, 0x41, 0x7c, 0x8a,
+	0xa3, 0x1d, 0xc7, 0x4b, 0x31, 0x9a, 0x11, 0x0c, 0xdd, 0x56, 0x08, 0xd9, 0x07, 0xf5, 0x5b, 0xce,
+	0xcb, 0x43, 0x0f, 0xf1, 0x34, 0x07, 0x5d, 0xa9, 0x2d, 0x27, 0xa0, 0x7b, 0x2e, 0xe9, 0xd2, 0xfd,
+	0x10, 0x96, 0xc1, 0x11, 0x7e, 0x5e, 0x93, 0x3b, 0xca, 0xc8, 0xb8, 0x51, 0xbb, 0x94, 0x5b, 0x1f,
+	0x1f, 0xe3, 0xf8, 0x15, 0x94, 0xa4, 0x18, 0xd9, 0x50, 0x7c, 0x44, 0x19, 0xca, 0x1b, 0x42, 0x9e,
+	0xd4, 0x56, 0x39, 0xca, 0x32, 0x4a, 0xa5, 0x76, 0xcf, 0xb6, 0xf6, 0xd1, 0x1b, 0x05, 0xe6, 0xa3,
+	0xe1, 0x25, 0x37, 0xde, 0x95, 0x5c, 0xd1, 0x49, 0xb7, 0xb8, 0xce, 0xa1, 0x6b, 0x48, 0x3b, 0x98,
+	0x60, 0x63, 0x47, 0x02, 0x13, 0x50, 0x1f, 0x50, 0x87, 0x32, 0x9a, 0x7f, 0x0b, 0xd5, 0x91, 0x0e,
+	0xf6, 0x30, 0x9c, 0x20, 0xa3, 0x38, 0xd7, 0x0e, 0xc6, 0xb9, 0x03, 0xa5, 0xe7, 0xc4, 0xb1, 0x67,
+	0x61, 0xd0, 0x38, 0x88, 0xb3, 0x1c, 0xe2, 0x24, 0x46, 0x09, 0xc4, 0x40, 0xba, 0x0e, 0x29, 0xb3,
+	0x07, 0xaa, 0xec, 0x57, 0xb9, 0x83, 0x99, 0xcc, 0x96, 0x74, 0x0f, 0x8c, 0xc0, 0xd1, 0x4a, 0x36,
+	0x3e, 0x43, 0x34, 0x28, 0xc4, 0xa2, 0x31, 0x70, 0xb2, 0xcb, 0xf4, 0x88, 0x59, 0xc3, 0xd3, 0x55,
+	0xf1, 0x19, 0x0e, 0x5b, 0x45, 0x27, 0x0e, 0xc0, 0x76, 0xc2, 0xa7, 0x8d, 0x7f, 0x2b, 0xb0, 0x32,
+	0xfa, 0x36, 0x0a, 0x3b, 0xd3, 0x6b, 0x50, 0x43, 0xc1, 0x2e, 0x45, 0xc6, 0x2c, 0xef, 0xb1, 0x99,
+	0x0a, 0x58, 0x1e, 0x39, 0xae, 0x18, 0xc9, 0x58, 0x10, 0x1e, 0xc4, 0xaf, 0x0a, 0x80, 0x00, 0xe7,
+	0x6d, 0x6a, 0xe6, 0x0d, 0x5c, 0x9e, 0xc1, 0x00, 0x1b, 0x7c, 0x13, 0x97, 0xf0, 0x72, 0x6a, 0x13,
+	0x51, 0xf3, 0x7a, 0x81, 0xd0, 0x88, 0x18, 0xfd, 0xa6, 0xc0, 0xbc, 0x1c, 0xbb, 0xd1, 0xe5, 0x89,
+	0x27, 0x90, 0x1d, 0xce, 0xc7, 0xd2, 0xf2, 0x29, 0xdf, 0x41, 0x13, 0xd7, 0xd3, 0x50, 0x7b, 0xe9,
+	0x51, 0x7a, 0xdf, 0x08, 0x47, 0xe7, 0x20, 0xdc, 0x11, 0xae, 0x4d, 0x55, 0x43, 0x2d, 0x50, 0xef,
+	0x13, 0xb7, 0x45, 0x9d, 0xf7, 0xaf, 0x4a, 0x8d, 0xef, 0x0d, 0xad, 0x2d, 0x67, 0x41, 0x79, 0x5d,
+	0xce, 0x99, 0x94, 0xf9, 0xc3, 0xf7, 0xc7, 0x38, 0xc7, 0x31, 0x34, 0x5c, 0x3d, 0x88, 0x61, 0xf8,
+	0x1c, 0xe0, 0x8d, 0x22, 0x7b, 0xf9, 0xb5, 0x9c, 0xd3, 0x61, 0xd2, 0xcd, 0xaf, 0xe7, 0xea, 0x77,
+	0x59, 0x4b, 0x7c, 0x9c, 0xef, 0x67, 0x11, 0xa5, 0x69, 0x89, 0xfa, 0x33, 0x76, 0xf6, 0x99, 0x38,
+	0x28, 0xb3, 0x8c, 0x46, 0xb3, 0xbc, 0xff, 0x41, 0x7b, 0xd2, 0x79, 0x8e, 0x7b, 0x0a, 0x9d, 0x1c,
+	0xc9, 0xbc, 0xec, 0x4a, 0x5b, 0x30, 0xb7, 0x45, 0x58, 0x6b, 0x27, 0x3f, 0x7a, 0x9e, 0xa9, 0xec,
+	0x9a, 0x82, 0x58, 0xaa, 0xab, 0xcf, 0x5c, 0xdf, 0xe3, 0x88, 0x24, 0xc3, 0xc1, 0x27, 0xd2, 0xe1,
+	0xa4, 0x3b, 0xfc, 0x5b, 0x05, 0x54, 0x71, 0x8f, 0x45, 0x6b, 0x13, 0xb3, 0x94, 0xb9, 0xec, 0x8e,
+	0xc5, 0xbb, 0xc3, 0xf1, 0x3e, 0xc3, 0xd7, 0xa6, 0x55, 0xa4, 0xb1, 0x27, 0xae, 0xbe, 0xfb, 0x46,
+	0xc0, 0x1d, 0x87, 0x7b, 0x19, 0x7c, 0xa0, 0x86, 0x2f, 0xab, 0x09, 0x8d, 0x56, 0x93, 0x68, 0xf9,
+	0x7f, 0x14, 0x01, 0xe4, 0x40, 0x1b, 0xf6, 0x79, 0x27, 0x9e, 0x40, 0x2f, 0x8e, 0xbf, 0x85, 0x0b,
+	0xf5, 0xd9, 0x9a, 0xbb, 0xac, 0x22, 0x5c, 0x32, 0xe4, 0x7c, 0x2c, 0xe6, 0x4f, 0x51, 0xc9, 0x57,
+	0x27, 0x06, 0x72, 0x70, 0x52, 0xaf, 0xad, 0xe6, 0x55, 0xc7, 0xcb, 0x1c, 0x15, 0x50, 0x8c, 0x8a,
+	0x3a, 0x33, 0x16, 0x6e, 0x7d, 0x5a, 0x1a, 0xf0, 0x0a, 0xc7, 0x38, 0x8a, 0x16, 0x23, 0x0c, 0x51,
+	0xaa, 0xdf, 0x1d, 0xde, 0x2c, 0x24, 0x11, 0xd6, 0xb2, 0x08, 0x8d, 0x3f, 0x15, 0x28, 0xad, 0x5b,
+	0x5d, 0x9b, 0xbf, 0xa0, 0xb7, 0x40, 0x15, 0xd7, 0x45, 0x34, 0xc6, 0x4b, 0xed, 0xc2, 0xc4, 0xac,
+	0x89, 0x9b, 0x4a, 0x2a, 0x61, 0x3b, 0x5c, 0xf0, 0x1a, 0x6d, 0xc2, 0xfc, 0x73, 0xf1, 0x53, 0xe1,
+	0x58, 0xcf, 0xe7, 0xdf, 0xe1, 0x39, 0xfa, 0x79, 0xb1, 0xe9, 0xb6, 0xbd, 0x94, 0x57, 0x29, 0xbe,
+	0x57, 0x79, 0x51, 0x8e, 0xb1, 0xb7, 0x55, 0xee, 0xef, 0xfa, 0x7f, 0x03, 0x00, 0x37, 0x64, 0x94,
+	0x8e, 0x8b, 0x15, 0x00, 0x00,
 }
@@ -2,6 +2,7 @@
 package httpclient
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"errors"
@@ -9,6 +10,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -104,6 +106,52 @@ func callWithJSON(ctx context.Context, method string, url string, in proto.Messa
 	return nil
 }
 
+// readSSE parses a stream of Server-Sent Events, calling the handler for each event until the stream ends or the
+// handler returns an error.
+func readSSE(src io.Reader, handler func(eventType string, data []byte) error) error {
+	r := bufio.NewReader(src)
+	var eventType string
+	data := bytes.NewBuffer(nil)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case len(line) == 0:
+			// An empty line dispatches the event
+			if data.Len() > 0 || len(eventType) > 0 {
+				if err := handler(eventType, data.Bytes()); err != nil {
+					return err
+				}
+			}
+			eventType = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+			// Comment
+		default:
+			field, value := line, ""
+			if i := strings.IndexByte(line, ':'); i >= 0 {
+				field = line[:i]
+				value = strings.TrimPrefix(line[i+1:], " ")
+			}
+			switch field {
+			case "event":
+				eventType = value
+			case "data":
+				if data.Len() > 0 {
+					data.WriteByte('\n')
+				}
+				data.WriteString(value)
+			}
+		}
+	}
+}
+
 type baseAPI struct {
 	endpoint string
 	client   http.Client
@@ -112,3 +160,11 @@ type baseAPI struct {
 func (api *baseAPI) formatURL(path string) string {
 	return api.endpoint + path
 }
+
+// withLabelSelector adds the label selector, if any, to the query of the path.
+func withLabelSelector(path string, selector string) string {
+	if len(selector) == 0 {
+		return path
+	}
+	return path + "?" + url.Values{"labelSelector": {selector}}.Encode()
+}
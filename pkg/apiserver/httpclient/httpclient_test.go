@@ -0,0 +1,43 @@
+package httpclient
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadSSE(t *testing.T) {
+	stream := ": comment\n" +
+		"id: 1\n" +
+		"event: first\n" +
+		"data: {\"foo\":\n" +
+		"data: \"bar\"}\n" +
+		"\n" +
+		"event: second\r\n" +
+		"data:baz\r\n" +
+		"\r\n" +
+		"event: incomplete\n"
+
+	var types, data []string
+	err := readSSE(strings.NewReader(stream), func(eventType string, eventData []byte) error {
+		types = append(types, eventType)
+		data = append(data, string(eventData))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second"}, types)
+	assert.Equal(t, []string{"{\"foo\":\n\"bar\"}", "baz"}, data)
+}
+
+func TestReadSSEHandlerError(t *testing.T) {
+	expected := errors.New("expected error")
+	var calls int
+	err := readSSE(strings.NewReader("data: 1\n\ndata: 2\n\n"), func(eventType string, eventData []byte) error {
+		calls++
+		return expected
+	})
+	assert.Equal(t, expected, err)
+	assert.Equal(t, 1, calls)
+}
@@ -1,11 +1,20 @@
 package httpclient
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/fission/fission-workflows/pkg/apiserver"
+	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/sirupsen/logrus"
 )
 
 type InvocationAPI struct {
@@ -39,9 +48,59 @@ func (api *InvocationAPI) Cancel(ctx context.Context, id string) error {
 	return callWithJSON(ctx, http.MethodDelete, api.formatURL("/invocation/"+id), nil, nil)
 }
 
-func (api *InvocationAPI) List(ctx context.Context) (*apiserver.WorkflowInvocationList, error) {
+func (api *InvocationAPI) Retry(ctx context.Context, id string) error {
+	return callWithJSON(ctx, http.MethodPost, api.formatURL("/invocation/"+id+"/retry"), nil, nil)
+}
+
+func (api *InvocationAPI) Signal(ctx context.Context, id string, taskID string,
+	payload *typedvalues.TypedValue) error {
+	req := &apiserver.SignalRequest{
+		InvocationID: id,
+		TaskID:       taskID,
+		Payload:      payload,
+	}
+	return callWithJSON(ctx, http.MethodPost, api.formatURL("/invocation/"+id+"/tasks/"+taskID+"/signal"), req, nil)
+}
+
+func (api *InvocationAPI) Graph(ctx context.Context, id string, format string) (*apiserver.Graph, error) {
+	result := &apiserver.Graph{}
+	err := callWithJSON(ctx, http.MethodGet, api.formatURL("/invocation/"+id+"/graph?format="+url.QueryEscape(format)),
+		nil, result)
+	return result, err
+}
+
+func (api *InvocationAPI) List(ctx context.Context, query *apiserver.InvocationListQuery) (
+	*apiserver.WorkflowInvocationList, error) {
+	params := url.Values{}
+	for _, workflowID := range query.GetWorkflows() {
+		params.Add("workflows", workflowID)
+	}
+	for _, status := range query.GetStatuses() {
+		params.Add("statuses", status.String())
+	}
+	// Timestamps are passed by their fields, which the gateway populates like any other nested message.
+	if ts := query.GetCreatedAfter(); ts != nil {
+		params.Set("createdAfter.seconds", strconv.FormatInt(ts.Seconds, 10))
+		params.Set("createdAfter.nanos", strconv.Itoa(int(ts.Nanos)))
+	}
+	if ts := query.GetCreatedBefore(); ts != nil {
+		params.Set("createdBefore.seconds", strconv.FormatInt(ts.Seconds, 10))
+		params.Set("createdBefore.nanos", strconv.Itoa(int(ts.Nanos)))
+	}
+	if len(query.GetLabelSelector()) > 0 {
+		params.Set("labelSelector", query.GetLabelSelector())
+	}
+	if query.GetPageSize() > 0 {
+		params.Set("pageSize", strconv.Itoa(int(query.GetPageSize())))
+	}
+	if len(query.GetPageToken()) > 0 {
+		params.Set("pageToken", query.GetPageToken())
+	}
+	if query.GetSummaries() {
+		params.Set("summaries", "true")
+	}
 	result := &apiserver.WorkflowInvocationList{}
-	err := callWithJSON(ctx, http.MethodGet, api.formatURL("/invocation"), nil, result)
+	err := callWithJSON(ctx, http.MethodGet, api.formatURL("/invocation?"+params.Encode()), nil, result)
 	return result, err
 }
 
@@ -60,3 +119,38 @@ func (api *InvocationAPI) Events(ctx context.Context, id string) (*apiserver.Obj
 	err := callWithJSON(ctx, http.MethodGet, api.formatURL("/invocation/"+id+"/events"), nil, result)
 	return result, err
 }
+
+// Watch follows the events of the invocation using the Server-Sent Events endpoint of the gateway. The handler is
+// called for each event. Watch returns once the invocation has finished, the context is done, or the handler returns
+// an error.
+func (api *InvocationAPI) Watch(ctx context.Context, id string, handler func(event *fes.Event) error) error {
+	url := api.formatURL("/invocation/" + id + "/watch")
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("%v: %v", ErrRequestCreate, err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	logrus.Debugf("--> %s %s", http.MethodGet, url)
+	resp, err := defaultHTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("%v: %v", ErrRequestSend, err)
+	}
+	defer resp.Body.Close()
+	logrus.Debugf("<-- %s - %s", resp.Status, url)
+	if resp.StatusCode >= 400 {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%v (%s): %s", ErrResponseError, resp.Status, strings.TrimSpace(string(respBody)))
+	}
+
+	return readSSE(resp.Body, func(eventType string, data []byte) error {
+		if eventType == apiserver.SSEEventError {
+			return fmt.Errorf("%v: %s", ErrResponseError, data)
+		}
+		event := &fes.Event{}
+		if err := fromJSON(bytes.NewReader(data), event); err != nil {
+			return fmt.Errorf("%v: %v", ErrDeserialize, err)
+		}
+		return handler(event)
+	})
+}
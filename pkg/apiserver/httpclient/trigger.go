@@ -0,0 +1,46 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fission/fission-workflows/pkg/apiserver"
+	"github.com/fission/fission-workflows/pkg/types"
+)
+
+type TriggerAPI struct {
+	baseAPI
+}
+
+func NewTriggerAPI(endpoint string, client http.Client) *TriggerAPI {
+	return &TriggerAPI{
+		baseAPI: baseAPI{
+			endpoint: endpoint,
+			client:   client,
+		},
+	}
+}
+
+func (api *TriggerAPI) Create(ctx context.Context, spec *types.TriggerSpec) (*types.ObjectMetadata, error) {
+	result := &types.ObjectMetadata{}
+	err := callWithJSON(ctx, http.MethodPost, api.formatURL("/trigger"), spec, result)
+	return result, err
+}
+
+func (api *TriggerAPI) List(ctx context.Context, query *apiserver.TriggerListQuery) (*apiserver.TriggerList, error) {
+	result := &apiserver.TriggerList{}
+	err := callWithJSON(ctx, http.MethodGet, api.formatURL(withLabelSelector("/trigger", query.GetLabelSelector())),
+		nil, result)
+	return result, err
+}
+
+func (api *TriggerAPI) Get(ctx context.Context, id string) (*types.Trigger, error) {
+	result := &types.Trigger{}
+	err := callWithJSON(ctx, http.MethodGet, api.formatURL("/trigger/"+id), nil, result)
+	return result, err
+}
+
+func (api *TriggerAPI) Delete(ctx context.Context, id string) error {
+	err := callWithJSON(ctx, http.MethodDelete, api.formatURL("/trigger/"+id), nil, nil)
+	return err
+}
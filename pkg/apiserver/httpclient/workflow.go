@@ -3,6 +3,7 @@ package httpclient
 import (
 	"context"
 	"net/http"
+	"net/url"
 
 	"github.com/fission/fission-workflows/pkg/apiserver"
 	"github.com/fission/fission-workflows/pkg/types"
@@ -33,9 +34,17 @@ func (api *WorkflowAPI) CreateSync(ctx context.Context, spec *types.WorkflowSpec
 	return wf, err
 }
 
-func (api *WorkflowAPI) List(ctx context.Context) (*apiserver.WorkflowList, error) {
+func (api *WorkflowAPI) Update(ctx context.Context, spec *types.WorkflowSpec) (*types.ObjectMetadata, error) {
+	result := &types.ObjectMetadata{}
+	err := callWithJSON(ctx, http.MethodPut, api.formatURL("/workflow/"+spec.GetName()), spec, result)
+	return result, err
+}
+
+func (api *WorkflowAPI) List(ctx context.Context, query *apiserver.WorkflowListQuery) (*apiserver.WorkflowList,
+	error) {
 	result := &apiserver.WorkflowList{}
-	err := callWithJSON(ctx, http.MethodGet, api.formatURL("/workflow"), nil, result)
+	err := callWithJSON(ctx, http.MethodGet, api.formatURL(withLabelSelector("/workflow", query.GetLabelSelector())),
+		nil, result)
 	return result, err
 }
 
@@ -45,6 +54,12 @@ func (api *WorkflowAPI) Get(ctx context.Context, id string) (*types.Workflow, er
 	return result, err
 }
 
+func (api *WorkflowAPI) History(ctx context.Context, name string) (*apiserver.WorkflowHistory, error) {
+	result := &apiserver.WorkflowHistory{}
+	err := callWithJSON(ctx, http.MethodGet, api.formatURL("/workflow/"+name+"/history"), nil, result)
+	return result, err
+}
+
 func (api *WorkflowAPI) Delete(ctx context.Context, id string) error {
 	err := callWithJSON(ctx, http.MethodDelete, api.formatURL("/workflow/"+id), nil, nil)
 	return err
@@ -55,3 +70,10 @@ func (api *WorkflowAPI) Events(ctx context.Context, id string) (*apiserver.Objec
 	err := callWithJSON(ctx, http.MethodGet, api.formatURL("/workflow/"+id+"/events"), nil, result)
 	return result, err
 }
+
+func (api *WorkflowAPI) Graph(ctx context.Context, id string, format string) (*apiserver.Graph, error) {
+	result := &apiserver.Graph{}
+	err := callWithJSON(ctx, http.MethodGet, api.formatURL("/workflow/"+id+"/graph?format="+url.QueryEscape(format)),
+		nil, result)
+	return result, err
+}
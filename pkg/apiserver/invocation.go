@@ -1,8 +1,13 @@
 package apiserver
 
 import (
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/api"
 	"github.com/fission/fission-workflows/pkg/api/projectors"
@@ -11,13 +16,22 @@ import (
 	"github.com/fission/fission-workflows/pkg/fnenv"
 	workflowFnenv "github.com/fission/fission-workflows/pkg/fnenv/workflows"
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/graph"
 	"github.com/fission/fission-workflows/pkg/types/validate"
 	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/fission/fission-workflows/pkg/util/labels"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// watchResyncInterval is the interval at which Watch checks for new events in absence of notifications.
+const watchResyncInterval = time.Second
+
 // Invocation is responsible for all functionality related to managing invocations.
 type Invocation struct {
 	api         *api.Invocation
@@ -25,15 +39,20 @@ type Invocation struct {
 	workflows   *store.Workflows
 	fnenv       *workflowFnenv.Runtime
 	backend     fes.Backend
+	maxRuntime  time.Duration
 }
 
-func NewInvocation(api *api.Invocation, invocations *store.Invocations, workflows *store.Workflows, backend fes.Backend) WorkflowInvocationAPIServer {
+// NewInvocation creates the invocation API server. The maxRuntime is the runtime of invocations that do not specify a
+// deadline, which the invocation controller enforces as well.
+func NewInvocation(api *api.Invocation, invocations *store.Invocations, workflows *store.Workflows, backend fes.Backend,
+	maxRuntime time.Duration) WorkflowInvocationAPIServer {
 	return &Invocation{
 		api:         api,
 		invocations: invocations,
 		workflows:   workflows,
 		fnenv:       workflowFnenv.NewRuntime(api, invocations, workflows),
 		backend:     backend,
+		maxRuntime:  maxRuntime,
 	}
 }
 
@@ -48,10 +67,14 @@ func (gi *Invocation) Validate(ctx context.Context, spec *types.WorkflowInvocati
 func (gi *Invocation) Invoke(ctx context.Context, spec *types.WorkflowInvocationSpec) (*types.ObjectMetadata, error) {
 	// TODO go through same runtime as InvokeSync
 	// Check if the workflow required by the invocation exists
-	wf, err := gi.workflows.GetWorkflow(spec.GetWorkflowId())
+	wf, err := gi.workflows.GetWorkflowByRef(spec.GetWorkflowId())
 	if err != nil {
 		return nil, err
 	}
+	if wf == nil {
+		return nil, status.Errorf(codes.NotFound, "workflow %s not found", spec.GetWorkflowId())
+	}
+	spec.WorkflowId = wf.ID()
 	spec.Workflow = wf
 
 	eventID, err := gi.api.Invoke(spec, api.WithContext(ctx))
@@ -79,6 +102,40 @@ func (gi *Invocation) Cancel(ctx context.Context, objectMetadata *types.ObjectMe
 	return &empty.Empty{}, nil
 }
 
+func (gi *Invocation) Retry(ctx context.Context, objectMetadata *types.ObjectMetadata) (*empty.Empty, error) {
+	invocation, err := gi.invocations.GetInvocation(objectMetadata.GetId())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	switch invocation.GetStatus().GetStatus() {
+	case types.WorkflowInvocationStatus_FAILED, types.WorkflowInvocationStatus_ABORTED:
+	default:
+		return nil, status.Errorf(codes.FailedPrecondition, "invocation %s cannot be retried, because it is %v",
+			invocation.ID(), invocation.GetStatus().GetStatus())
+	}
+
+	// Grant the invocation the same runtime again if the deadline has been exceeded.
+	var deadline time.Time
+	originalDeadline, err := invocation.Deadline(gi.maxRuntime)
+	if err == nil && time.Now().After(originalDeadline) {
+		createdAt, err := ptypes.Timestamp(invocation.GetMetadata().GetCreatedAt())
+		if err == nil {
+			deadline = time.Now().Add(originalDeadline.Sub(createdAt))
+		}
+	}
+
+	// Only one of the concurrent retries of the invocation succeeds, as each is based on the checked generation.
+	err = gi.api.Retry(invocation.ID(), deadline, api.ExpectGeneration(invocation.GetMetadata().GetGeneration()))
+	if fes.ErrGenerationConflict.Is(err) {
+		return nil, status.Errorf(codes.Aborted, "invocation %s was modified while retrying it, such as by another retry",
+			invocation.ID())
+	}
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return &empty.Empty{}, nil
+}
+
 func (gi *Invocation) Get(ctx context.Context, objectMetadata *types.ObjectMetadata) (*types.WorkflowInvocation, error) {
 	wi, err := gi.invocations.GetInvocation(objectMetadata.GetId())
 	if err != nil {
@@ -88,30 +145,86 @@ func (gi *Invocation) Get(ctx context.Context, objectMetadata *types.ObjectMetad
 }
 
 func (gi *Invocation) List(ctx context.Context, query *InvocationListQuery) (*WorkflowInvocationList, error) {
-	var invocations []string
-	as := gi.invocations.List()
-	for _, aggregate := range as {
-		if aggregate.Type != types.TypeInvocation {
-			logrus.Errorf("Invalid type in invocation invocations: %v", aggregate.Format())
-			continue
+	selector, err := labels.Parse(query.GetLabelSelector())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var cursor *invocationCursor
+	if len(query.GetPageToken()) > 0 {
+		cursor, err = parseInvocationCursor(query.GetPageToken())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
 		}
+	}
+	filter := store.InvocationQuery{
+		Workflows: query.GetWorkflows(),
+		Statuses:  query.GetStatuses(),
+	}
+	if query.GetCreatedAfter() != nil {
+		filter.CreatedAfter, _ = ptypes.Timestamp(query.GetCreatedAfter())
+	}
+	if query.GetCreatedBefore() != nil {
+		filter.CreatedBefore, _ = ptypes.Timestamp(query.GetCreatedBefore())
+	}
+	if len(query.GetLabelSelector()) > 0 {
+		filter.Selector = selector
+	}
 
-		if len(query.Workflows) > 0 {
-			// TODO make more efficient (by moving list queries to invocations)
-			entity, err := gi.invocations.GetAggregate(aggregate)
-			if err != nil {
-				logrus.Errorf("List: failed to fetch %v from invocations: %v", aggregate, err)
-				continue
-			}
-			wfi := entity.(*types.WorkflowInvocation)
-			if !contains(query.Workflows, wfi.GetSpec().GetWorkflowId()) {
+	// Without filters, paging or summaries, the invocations do not need to be fetched to list their ids.
+	if filter.IsEmpty() && cursor == nil && query.GetPageSize() <= 0 && !query.GetSummaries() {
+		result := &WorkflowInvocationList{}
+		for _, aggregate := range gi.invocations.List() {
+			if aggregate.Type != types.TypeInvocation {
+				logrus.Errorf("Invalid type in invocation invocations: %v", aggregate.Format())
 				continue
 			}
+			result.Invocations = append(result.Invocations, aggregate.Id)
+		}
+		return result, nil
+	}
+
+	var invocations []*types.WorkflowInvocation
+	for _, wfi := range gi.invocations.ListInvocations(filter) {
+		if cursor == nil || cursor.before(wfi) {
+			invocations = append(invocations, wfi)
 		}
+	}
 
-		invocations = append(invocations, aggregate.Id)
+	// Order the pages from new to old, which keeps them stable while new invocations are created.
+	result := &WorkflowInvocationList{}
+	if cursor != nil || query.GetPageSize() > 0 {
+		sort.Slice(invocations, func(i, j int) bool {
+			return newInvocationCursor(invocations[i]).before(invocations[j])
+		})
+	}
+	if query.GetPageSize() > 0 && len(invocations) > int(query.GetPageSize()) {
+		invocations = invocations[:query.GetPageSize()]
+		result.NextPageToken = newInvocationCursor(invocations[len(invocations)-1]).String()
+	}
+	for _, wfi := range invocations {
+		result.Invocations = append(result.Invocations, wfi.ID())
+		if query.GetSummaries() {
+			result.Summaries = append(result.Summaries, gi.summarize(wfi))
+		}
+	}
+	return result, nil
+}
+
+// summarize creates the summary of the invocation, looking up the name of its workflow if needed.
+func (gi *Invocation) summarize(wfi *types.WorkflowInvocation) *InvocationSummary {
+	wf := wfi.Workflow()
+	if wf == nil && len(wfi.GetSpec().GetWorkflowId()) > 0 {
+		wf, _ = gi.workflows.GetWorkflow(wfi.GetSpec().GetWorkflowId())
+	}
+	return &InvocationSummary{
+		Metadata:     wfi.GetMetadata(),
+		Status:       wfi.GetStatus().GetStatus(),
+		UpdatedAt:    wfi.GetStatus().GetUpdatedAt(),
+		WorkflowId:   wfi.GetSpec().GetWorkflowId(),
+		WorkflowName: wf.GetMetadata().GetName(),
+		ParentId:     wfi.GetSpec().GetParentId(),
+		TriggerId:    wfi.GetSpec().GetTriggerId(),
 	}
-	return &WorkflowInvocationList{Invocations: invocations}, nil
 }
 
 func (gi *Invocation) AddTask(ctx context.Context, req *AddTaskRequest) (*empty.Empty, error) {
@@ -125,23 +238,132 @@ func (gi *Invocation) AddTask(ctx context.Context, req *AddTaskRequest) (*empty.
 	return &empty.Empty{}, nil
 }
 
+func (gi *Invocation) Signal(ctx context.Context, req *SignalRequest) (*empty.Empty, error) {
+	invocation, err := gi.invocations.GetInvocation(req.GetInvocationID())
+	if err != nil && !fes.ErrEntityNotFound.Is(err) {
+		return nil, toErrorStatus(err)
+	}
+	if invocation == nil {
+		return nil, status.Errorf(codes.NotFound, "invocation %s not found", req.GetInvocationID())
+	}
+	if _, ok := invocation.Task(req.GetTaskID()); !ok {
+		return nil, status.Errorf(codes.NotFound, "task %s not found in invocation %s", req.GetTaskID(),
+			invocation.ID())
+	}
+	taskRun, _ := invocation.TaskInvocation(req.GetTaskID())
+	if taskRun.GetStatus().GetStatus() != types.TaskInvocationStatus_IN_PROGRESS ||
+		!taskRun.GetStatus().GetAwaitingSignal() || taskRun.GetStatus().GetSignaled() {
+		return nil, status.Errorf(codes.FailedPrecondition, "task %s of invocation %s is not awaiting a signal",
+			req.GetTaskID(), invocation.ID())
+	}
+
+	err = gi.api.Signal(invocation.ID(), req.GetTaskID(), req.GetPayload(),
+		api.ExpectGeneration(taskRun.GetMetadata().GetGeneration()))
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return &empty.Empty{}, nil
+}
+
+func (gi *Invocation) Graph(ctx context.Context, req *GraphRequest) (*Graph, error) {
+	wi, err := gi.invocations.GetInvocation(req.GetId())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	if wi.Workflow() == nil && wi.GetSpec() != nil {
+		wf, err := gi.workflows.GetWorkflow(wi.GetSpec().GetWorkflowId())
+		if err != nil {
+			return nil, toErrorStatus(err)
+		}
+		wi = wi.Copy()
+		wi.Spec.Workflow = wf
+	}
+	return renderGraph(graph.NewInvocationDiagram(wi), req.GetFormat())
+}
+
 func (gi *Invocation) Events(ctx context.Context, md *types.ObjectMetadata) (*ObjectEvents, error) {
-	events, err := gi.backend.Get(projectors.NewWorkflowAggregate(md.Id))
+	events, err := gi.invocationEvents(md.GetId())
 	if err != nil {
 		return nil, toErrorStatus(err)
 	}
+	return &ObjectEvents{
+		Metadata: md,
+		Events:   events,
+	}, nil
+}
+
+// Watch streams the events of the invocation until it has finished. Rather than relying on the contents of the
+// notifications, which are dropped if the subscriber cannot keep up, every notification (or resync) triggers a
+// comparison of the event history with the events that have been sent already.
+func (gi *Invocation) Watch(md *types.ObjectMetadata, stream WorkflowInvocationAPI_WatchServer) error {
+	invocationID := md.GetId()
+	var updates <-chan pubsub.Msg
+	if sub := gi.invocations.GetInvocationUpdatesFor(invocationID); sub != nil {
+		defer sub.Close()
+		updates = sub.Ch
+	}
+	resync := time.NewTicker(watchResyncInterval)
+	defer resync.Stop()
+
+	sent := map[string]struct{}{}
+	for {
+		// Check the status before fetching the events, to ensure that the final events are included.
+		invocation, err := gi.invocations.GetInvocation(invocationID)
+		if err != nil {
+			return toErrorStatus(err)
+		}
+		if invocation == nil {
+			return status.Errorf(codes.NotFound, "invocation %s not found", invocationID)
+		}
+
+		events, err := gi.invocationEvents(invocationID)
+		if err != nil {
+			return toErrorStatus(err)
+		}
+		for _, event := range events {
+			key := eventKey(event)
+			if _, ok := sent[key]; ok {
+				continue
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+			sent[key] = struct{}{}
+		}
+		if invocation.GetStatus().Finished() {
+			return nil
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case _, ok := <-updates:
+			if !ok {
+				updates = nil
+			}
+		case <-resync.C:
+		}
+	}
+}
+
+// invocationEvents returns the events of the invocation, including the events of its task runs, ordered by time.
+func (gi *Invocation) invocationEvents(invocationID string) ([]*fes.Event, error) {
+	events, err := gi.backend.Get(projectors.NewInvocationAggregate(invocationID))
+	if err != nil {
+		return nil, err
+	}
 
 	// TODO this should not be this cumbersome
-	wi, err := gi.invocations.GetInvocation(md.Id)
+	wi, err := gi.invocations.GetInvocation(invocationID)
 	if err != nil {
-		return nil, toErrorStatus(err)
+		return nil, err
 	}
 
 	// Fold task events into invocation events
 	for _, task := range wi.GetStatus().GetTasks() {
 		taskEvents, err := gi.taskEvents(task.ID())
 		if err != nil {
-			return nil, toErrorStatus(fmt.Errorf("failed to fetch task events: %v", err))
+			return nil, fmt.Errorf("failed to fetch task events: %v", err)
 		}
 		events = append(events, taskEvents...)
 	}
@@ -149,22 +371,65 @@ func (gi *Invocation) Events(ctx context.Context, md *types.ObjectMetadata) (*Ob
 	sort.SliceStable(events, func(i, j int) bool {
 		return util.CmpProtoTimestamps(events[i].GetTimestamp(), events[j].GetTimestamp())
 	})
-
-	return &ObjectEvents{
-		Metadata: md,
-		Events:   events,
-	}, nil
+	return events, nil
 }
 
 func (gi *Invocation) taskEvents(taskRunID string) ([]*fes.Event, error) {
 	return gi.backend.Get(projectors.NewTaskRunAggregate(taskRunID))
 }
 
-func contains(haystack []string, needle string) bool {
-	for i := 0; i < len(haystack); i++ {
-		if haystack[i] == needle {
-			return true
-		}
+// eventKey identifies an event. Not all backends assign IDs to events, in which case the aggregate, type and
+// timestamp of the event are used instead.
+func eventKey(event *fes.Event) string {
+	if len(event.GetId()) > 0 {
+		return event.GetAggregate().Format() + "/" + event.GetId()
+	}
+	return fmt.Sprintf("%s/%s/%s", event.GetAggregate().Format(), event.GetType(), event.GetTimestamp().String())
+}
+
+// invocationCursor is the position of an invocation in the list of invocations, which is ordered by the creation
+// time and id of the invocations. It is used as the page token of the list.
+type invocationCursor struct {
+	createdAt int64
+	id        string
+}
+
+func newInvocationCursor(wfi *types.WorkflowInvocation) *invocationCursor {
+	createdAt, _ := ptypes.Timestamp(wfi.GetMetadata().GetCreatedAt())
+	return &invocationCursor{
+		createdAt: createdAt.UnixNano(),
+		id:        wfi.ID(),
+	}
+}
+
+func parseInvocationCursor(token string) (*invocationCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+	parts := strings.SplitN(string(data), "/", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("missing invocation id")
+	}
+	createdAt, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, err
 	}
-	return false
+	return &invocationCursor{
+		createdAt: createdAt,
+		id:        parts[1],
+	}, nil
+}
+
+// before checks if the cursor comes before the invocation in the list, which means that the invocation is older.
+func (c *invocationCursor) before(wfi *types.WorkflowInvocation) bool {
+	other := newInvocationCursor(wfi)
+	if c.createdAt != other.createdAt {
+		return c.createdAt > other.createdAt
+	}
+	return c.id > other.id
+}
+
+func (c *invocationCursor) String() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d/%s", c.createdAt, c.id)))
 }
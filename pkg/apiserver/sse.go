@@ -0,0 +1,112 @@
+package apiserver
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/grpclog"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// SSEEventError is the type of the Server-Sent Event that is sent if the stream ends with an error.
+	SSEEventError = "error"
+)
+
+// GET /invocation/{id}/watch
+var patternInvocationWatch = runtime.MustPattern(runtime.NewPattern(1, []int{2, 0, 1, 0, 4, 1, 5, 1, 2, 2},
+	[]string{"invocation", "id", "watch"}, ""))
+
+// RegisterInvocationWatchHandlerFromEndpoint is the equivalent of RegisterInvocationWatchHandler, which dials the
+// gRPC endpoint itself.
+func RegisterInvocationWatchHandlerFromEndpoint(ctx context.Context, mux *runtime.ServeMux, endpoint string,
+	opts []grpc.DialOption) (err error) {
+	conn, err := grpc.Dial(endpoint, opts...)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-ctx.Done()
+		if cerr := conn.Close(); cerr != nil {
+			grpclog.Printf("Failed to close conn to %s: %v", endpoint, cerr)
+		}
+	}()
+	RegisterInvocationWatchHandler(mux, NewWorkflowInvocationAPIClient(conn))
+	return nil
+}
+
+// RegisterInvocationWatchHandler registers the Server-Sent Events (SSE) endpoint for WorkflowInvocationAPI.Watch to
+// the gateway mux. The grpc-gateway is not able to generate this handler, as it only supports streaming responses
+// as newline-delimited JSON.
+//
+// Each event of the invocation is sent as a SSE with the event type as the SSE type and the JSON-encoded event as
+// the data. If the stream ends with an error after the response has been started, a final SSE with SSEEventError as
+// the type is sent.
+func RegisterInvocationWatchHandler(mux *runtime.ServeMux, client WorkflowInvocationAPIClient) {
+	mux.Handle(http.MethodGet, patternInvocationWatch, func(w http.ResponseWriter, req *http.Request,
+		pathParams map[string]string) {
+		ctx, cancel := context.WithCancel(req.Context())
+		defer cancel()
+		_, outboundMarshaler := runtime.MarshalerForRequest(mux, req)
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req,
+				status.Error(codes.Unimplemented, "response writer does not support streaming"))
+			return
+		}
+		rctx, err := runtime.AnnotateContext(ctx, mux, req)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		stream, err := client.Watch(rctx, &types.ObjectMetadata{Id: pathParams["id"]})
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+
+		// Errors of the call itself (such as a non-existent invocation) are only returned on the first receive.
+		event, err := stream.Recv()
+		if err != nil && err != io.EOF {
+			runtime.HTTPError(ctx, mux, outboundMarshaler, w, req, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+		for ; err == nil; event, err = stream.Recv() {
+			data, merr := outboundMarshaler.Marshal(event)
+			if merr != nil {
+				err = merr
+				break
+			}
+			writeSSE(w, event.GetType(), event.GetId(), string(data))
+			flusher.Flush()
+		}
+		if err != io.EOF {
+			logrus.Errorf("Failed to watch invocation %s: %v", pathParams["id"], err)
+			writeSSE(w, SSEEventError, "", err.Error())
+		}
+		flusher.Flush()
+	})
+}
+
+// writeSSE writes a single Server-Sent Event. Multi-line data is split over multiple data fields.
+func writeSSE(w io.Writer, eventType string, id string, data string) {
+	if len(id) > 0 {
+		fmt.Fprintf(w, "id: %s\n", id)
+	}
+	fmt.Fprintf(w, "event: %s\n", eventType)
+	for _, line := range strings.Split(data, "\n") {
+		fmt.Fprintf(w, "data: %s\n", line)
+	}
+	fmt.Fprint(w, "\n")
+}
@@ -0,0 +1,96 @@
+package apiserver
+
+import (
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/store"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/fission/fission-workflows/pkg/util/labels"
+	"github.com/golang/protobuf/ptypes/empty"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Trigger is responsible for all functionality related to managing triggers.
+type Trigger struct {
+	api       *api.Trigger
+	triggers  *store.Triggers
+	workflows *store.Workflows
+}
+
+func NewTrigger(api *api.Trigger, triggers *store.Triggers, workflows *store.Workflows) *Trigger {
+	return &Trigger{
+		api:       api,
+		triggers:  triggers,
+		workflows: workflows,
+	}
+}
+
+func (ta *Trigger) Create(ctx context.Context, spec *types.TriggerSpec) (*types.ObjectMetadata, error) {
+	if err := validate.TriggerSpec(spec); err != nil {
+		return nil, toErrorStatus(err)
+	}
+
+	// The workflow is resolved again each time the trigger fires; this only guards against obvious mistakes.
+	wf, err := ta.workflows.GetWorkflowByRef(spec.GetWorkflowId())
+	if err != nil && !fes.ErrEntityNotFound.Is(err) {
+		return nil, toErrorStatus(err)
+	}
+	if wf == nil {
+		return nil, status.Errorf(codes.NotFound, "workflow %s not found", spec.GetWorkflowId())
+	}
+
+	id, err := ta.api.Create(spec)
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return &types.ObjectMetadata{Id: id}, nil
+}
+
+func (ta *Trigger) List(ctx context.Context, query *TriggerListQuery) (*TriggerList, error) {
+	selector, err := labels.Parse(query.GetLabelSelector())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var results []string
+	for _, key := range ta.triggers.List() {
+		if key.Type != types.TypeTrigger {
+			continue
+		}
+		trigger, err := ta.triggers.GetTrigger(key.Id)
+		if err != nil {
+			return nil, toErrorStatus(err)
+		}
+		if trigger.GetStatus().Active() && selector.Matches(labels.Set(trigger.GetMetadata().GetLabels())) {
+			results = append(results, key.Id)
+		}
+	}
+	return &TriggerList{Triggers: results}, nil
+}
+
+func (ta *Trigger) Get(ctx context.Context, md *types.ObjectMetadata) (*types.Trigger, error) {
+	trigger, err := ta.triggers.GetTrigger(md.GetId())
+	if err != nil && !fes.ErrEntityNotFound.Is(err) {
+		return nil, toErrorStatus(err)
+	}
+	if trigger == nil {
+		return nil, status.Errorf(codes.NotFound, "trigger %s not found", md.GetId())
+	}
+	return trigger, nil
+}
+
+func (ta *Trigger) Delete(ctx context.Context, md *types.ObjectMetadata) (*empty.Empty, error) {
+	trigger, err := ta.Get(ctx, md)
+	if err != nil {
+		return nil, err
+	}
+	if trigger.GetStatus().GetStatus() == types.TriggerStatus_DELETED {
+		return &empty.Empty{}, nil
+	}
+	if err := ta.api.Delete(md.GetId()); err != nil {
+		return nil, toErrorStatus(err)
+	}
+	return &empty.Empty{}, nil
+}
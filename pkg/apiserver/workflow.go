@@ -9,13 +9,22 @@ import (
 	"github.com/fission/fission-workflows/pkg/api/store"
 	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/graph"
 	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/fission/fission-workflows/pkg/util/labels"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
 	CreateSyncPollInterval = 100 * time.Millisecond
+
+	// maxRevisionAttempts is the number of times that creating a revision is attempted when other revisions of the
+	// workflow are created concurrently.
+	maxRevisionAttempts = 10
 )
 
 // Workflow is responsible for all functionality related to managing workflows.
@@ -33,13 +42,73 @@ func NewWorkflow(api *api.Workflow, store *store.Workflows, backend fes.Backend)
 	}
 }
 
+// Create creates a new workflow. If the workflow has a name, it is created as the next revision of the workflows
+// with that name.
 func (ga *Workflow) Create(ctx context.Context, spec *types.WorkflowSpec) (*types.ObjectMetadata, error) {
-	id, err := ga.api.Create(spec, api.WithContext(ctx))
-	if err != nil {
-		return nil, toErrorStatus(err)
+	if len(spec.GetName()) == 0 {
+		id, err := ga.api.Create(spec, api.WithContext(ctx))
+		if err != nil {
+			return nil, toErrorStatus(err)
+		}
+		return &types.ObjectMetadata{Id: id}, nil
+	}
+	return ga.createRevision(ctx, spec, false)
+}
+
+// Update creates a new revision of an existing, named workflow.
+func (ga *Workflow) Update(ctx context.Context, spec *types.WorkflowSpec) (*types.ObjectMetadata, error) {
+	if len(spec.GetName()) == 0 {
+		return nil, toErrorStatus(validate.NewError("name", errors.New("name is required to update a workflow")))
 	}
+	return ga.createRevision(ctx, spec, true)
+}
+
+// createRevision creates the workflow as the next revision of the workflows with the same name.
+//
+// The revision is reserved with the generation of the stream of the workflow name, so if another server reserves
+// the same revision concurrently, the reservation is retried with the next revision.
+func (ga *Workflow) createRevision(ctx context.Context, spec *types.WorkflowSpec,
+	mustExist bool) (*types.ObjectMetadata, error) {
+	var err error
+	for attempt := 0; attempt < maxRevisionAttempts; attempt++ {
+		var latest, generation int64
+		latest, generation, err = ga.api.LatestRevision(spec.GetName())
+		if err != nil {
+			return nil, toErrorStatus(err)
+		}
+		// Workflows created before revisions were reserved are only known to the store.
+		var revisions []*types.Workflow
+		revisions, err = ga.store.GetWorkflowRevisions(spec.GetName())
+		if err != nil {
+			return nil, toErrorStatus(err)
+		}
+		if len(revisions) > 0 {
+			if stored := revisions[len(revisions)-1].GetMetadata().GetRevision(); stored > latest {
+				latest = stored
+			}
+		}
+		if mustExist && latest == 0 {
+			return nil, status.Errorf(codes.NotFound, "workflow %s does not exist", spec.GetName())
+		}
 
-	return &types.ObjectMetadata{Id: id}, nil
+		revision := latest + 1
+		var id string
+		id, err = ga.api.CreateRevision(spec, revision, api.WithContext(ctx), api.ExpectGeneration(generation))
+		if fes.ErrGenerationConflict.Is(err) {
+			logrus.Debugf("Revision %d of workflow %s was reserved concurrently; retrying.", revision,
+				spec.GetName())
+			continue
+		}
+		if err != nil {
+			return nil, toErrorStatus(err)
+		}
+		return &types.ObjectMetadata{
+			Id:       id,
+			Name:     spec.GetName(),
+			Revision: revision,
+		}, nil
+	}
+	return nil, toErrorStatus(err)
 }
 
 func (ga *Workflow) CreateSync(ctx context.Context, spec *types.WorkflowSpec) (*types.Workflow, error) {
@@ -83,13 +152,39 @@ func (ga *Workflow) CreateSync(ctx context.Context, spec *types.WorkflowSpec) (*
 }
 
 func (ga *Workflow) Get(ctx context.Context, workflowID *types.ObjectMetadata) (*types.Workflow, error) {
-	wf, err := ga.store.GetWorkflow(workflowID.GetId())
+	var wf *types.Workflow
+	var err error
+	if len(workflowID.GetId()) == 0 && len(workflowID.GetName()) > 0 {
+		wf, err = ga.store.GetWorkflowRevision(types.WorkflowRef{
+			Name:     workflowID.GetName(),
+			Revision: workflowID.GetRevision(),
+		})
+	} else {
+		wf, err = ga.store.GetWorkflowByRef(workflowID.GetId())
+	}
 	if err != nil {
 		return nil, toErrorStatus(err)
 	}
+	if wf == nil {
+		return nil, status.Errorf(codes.NotFound, "workflow %s not found", formatWorkflowID(workflowID))
+	}
 	return wf, nil
 }
 
+func (ga *Workflow) History(ctx context.Context, md *types.ObjectMetadata) (*WorkflowHistory, error) {
+	if len(md.GetName()) == 0 {
+		return nil, toErrorStatus(validate.NewError("name", errors.New("name is required")))
+	}
+	revisions, err := ga.store.GetWorkflowRevisions(md.GetName())
+	if err != nil {
+		return nil, toErrorStatus(err)
+	}
+	if len(revisions) == 0 {
+		return nil, status.Errorf(codes.NotFound, "workflow %s not found", md.GetName())
+	}
+	return &WorkflowHistory{Revisions: revisions}, nil
+}
+
 func (ga *Workflow) Delete(ctx context.Context, workflowID *types.ObjectMetadata) (*empty.Empty, error) {
 	err := ga.api.Delete(workflowID.GetId())
 	if err != nil {
@@ -98,10 +193,24 @@ func (ga *Workflow) Delete(ctx context.Context, workflowID *types.ObjectMetadata
 	return &empty.Empty{}, nil
 }
 
-func (ga *Workflow) List(ctx context.Context, req *empty.Empty) (*WorkflowList, error) {
+func (ga *Workflow) List(ctx context.Context, query *WorkflowListQuery) (*WorkflowList, error) {
+	selector, err := labels.Parse(query.GetLabelSelector())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	var results []string
 	wfs := ga.store.List()
 	for _, result := range wfs {
+		if len(query.GetLabelSelector()) > 0 {
+			wf, err := ga.store.GetWorkflow(result.Id)
+			if err != nil {
+				logrus.Errorf("List: failed to fetch %v from workflows: %v", result, err)
+				continue
+			}
+			if !selector.Matches(labels.Set(wf.GetMetadata().GetLabels())) {
+				continue
+			}
+		}
 		results = append(results, result.Id)
 	}
 	return &WorkflowList{Workflows: results}, nil
@@ -125,3 +234,33 @@ func (ga *Workflow) Events(ctx context.Context, md *types.ObjectMetadata) (*Obje
 		Events:   events,
 	}, nil
 }
+
+func (ga *Workflow) Graph(ctx context.Context, req *GraphRequest) (*Graph, error) {
+	wf, err := ga.Get(ctx, &types.ObjectMetadata{Id: req.GetId()})
+	if err != nil {
+		return nil, err
+	}
+	return renderGraph(graph.NewWorkflowDiagram(wf.GetSpec()), req.GetFormat())
+}
+
+// renderGraph renders the diagram in the format, defaulting to DOT if no format is specified.
+func renderGraph(diagram *graph.Diagram, format string) (*Graph, error) {
+	if len(format) == 0 {
+		format = graph.FormatDot
+	}
+	content, err := diagram.Render(format)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return &Graph{
+		Format:  format,
+		Content: content,
+	}, nil
+}
+
+func formatWorkflowID(md *types.ObjectMetadata) string {
+	if len(md.GetId()) > 0 {
+		return md.GetId()
+	}
+	return types.WorkflowRef{Name: md.GetName(), Revision: md.GetRevision()}.Format()
+}
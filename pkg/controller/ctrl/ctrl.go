@@ -98,19 +98,53 @@ type System struct {
 	close       func()
 	runOnce     *sync.Once
 	logger      *log.Logger
+	filter      func(ctrlKey string) bool
+	filterMu    *sync.RWMutex
 }
 
 func NewSystem(factory ControllerFactory) *System {
+	return NewSystemWithQueue(factory, workqueue.NewWorkQueue(workqueue.DefaultMaxSize, true))
+}
+
+// NewSystemWithQueue creates a control system that uses the provided queue to order the evaluations, such as a
+// WeightedFairQueue to prioritize some controllers over others. The queue should replace the events of controllers
+// that are already queued.
+func NewSystemWithQueue(factory ControllerFactory, evalQueue workqueue.Interface) *System {
 	return &System{
 		factory:     factory,
 		ctrlsMu:     &sync.RWMutex{},
 		ctrls:       make(map[string]Controller),
-		evalQueue:   workqueue.NewWorkQueue(workqueue.DefaultMaxSize, true),
+		evalQueue:   evalQueue,
 		runOnce:     &sync.Once{},
 		logger:      log.StandardLogger(),
 		ctrlStats:   make(map[string]ControllerStats),
 		ctrlStatsMu: &sync.RWMutex{},
+		filterMu:    &sync.RWMutex{},
+	}
+}
+
+// SetFilter limits the system to the controllers of the keys accepted by the filter, such as when the keys are divided
+// between multiple systems. Evaluations of other keys are ignored, and their controllers are removed; once the filter
+// accepts a key again, a new controller is created for its next evaluation.
+func (s *System) SetFilter(filter func(ctrlKey string) bool) {
+	s.filterMu.Lock()
+	s.filter = filter
+	s.filterMu.Unlock()
+}
+
+// accepts checks if the system is responsible for the controller of the key, removing the controller if it is not.
+func (s *System) accepts(ctrlKey string) bool {
+	s.filterMu.RLock()
+	filter := s.filter
+	s.filterMu.RUnlock()
+	if filter == nil || filter(ctrlKey) {
+		return true
+	}
+	if _, ok := s.GetController(ctrlKey); ok {
+		s.LoggerFor(ctrlKey).Debug("Removing controller of a key that is no longer accepted")
+		s.DeleteController(ctrlKey)
 	}
+	return false
 }
 
 func (s *System) DeleteController(key string) {
@@ -151,6 +185,9 @@ func (s *System) LoggerFor(entityID string) *log.Entry {
 }
 
 func (s *System) Submit(event *Event) bool {
+	if !s.accepts(event.Aggregate.Id) {
+		return false
+	}
 	return s.evalQueue.Add(event)
 }
 
@@ -176,6 +213,11 @@ func (s *System) run() {
 			continue
 		}
 		ctrlKey := event.Aggregate.Id
+		// The key might no longer be accepted by the time that its evaluation is dequeued.
+		if !s.accepts(ctrlKey) {
+			s.evalQueue.Done(item)
+			continue
+		}
 		s.LoggerFor(ctrlKey).Debugf("starting evaluation (reason: %v)", event.Event.GetType())
 
 		// Get or create controller for item
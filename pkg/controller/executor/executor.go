@@ -35,6 +35,9 @@ type Task struct {
 
 	// Apply is the work that the task comprises.
 	Apply func() error
+
+	// Class is the class, such as the priority, of the task. It is only used by executors with class weights.
+	Class string
 }
 
 func (t *Task) ID() interface{} {
@@ -42,15 +45,31 @@ func (t *Task) ID() interface{} {
 }
 
 func NewLocalExecutor(maxParallelism, maxQueueSize int) *LocalExecutor {
-	if maxParallelism <= 0 {
-		panic("LocalExecutor: parallelism should be larger than 0")
+	if maxQueueSize <= 0 {
+		panic("LocalExecutor: queue size should be larger than 0")
 	}
+	return newLocalExecutor(maxParallelism, workqueue.NewDelayingQueue(maxQueueSize))
+}
+
+// NewWeightedLocalExecutor creates an executor that orders the queued tasks using weighted-fair queueing based on
+// the class of the tasks, rather than in FIFO order.
+func NewWeightedLocalExecutor(maxParallelism, maxQueueSize int, weights map[string]int) *LocalExecutor {
 	if maxQueueSize <= 0 {
 		panic("LocalExecutor: queue size should be larger than 0")
 	}
+	queue := workqueue.NewWeightedFairQueue("executor", maxQueueSize, false, weights, func(item interface{}) string {
+		return item.(*Task).Class
+	})
+	return newLocalExecutor(maxParallelism, workqueue.NewDelayingQueueFrom(queue))
+}
+
+func newLocalExecutor(maxParallelism int, queue workqueue.DelayingInterface) *LocalExecutor {
+	if maxParallelism <= 0 {
+		panic("LocalExecutor: parallelism should be larger than 0")
+	}
 	return &LocalExecutor{
 		maxParallelism: maxParallelism,
-		queue:          workqueue.NewDelayingQueue(maxQueueSize),
+		queue:          queue,
 		groups:         make(map[interface{}]int),
 		groupsMu:       &sync.RWMutex{},
 	}
@@ -86,7 +105,7 @@ func (ex *LocalExecutor) GetGroupTasks(groupID interface{}) int {
 
 func (ex *LocalExecutor) SubmitAfter(t *Task, after time.Duration) bool {
 	// Add to the queue
-	if after <= 0 {
+	if after > 0 {
 		accepted := ex.queue.TryAddAfter(t, after)
 		if !accepted {
 			return false
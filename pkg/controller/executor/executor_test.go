@@ -1,6 +1,7 @@
 package executor
 
 import (
+	"sync"
 	"testing"
 	"time"
 
@@ -40,6 +41,59 @@ func TestLocalExecutor(t *testing.T) {
 	assert.Equal(t, int32(3), t3.n.Load())
 }
 
+func TestLocalExecutor_SubmitAfter(t *testing.T) {
+	executor := NewLocalExecutor(1, 3)
+	executor.Start()
+	defer executor.Close()
+
+	task := &testTask{atomic.NewInt32(0)}
+	accepted := executor.SubmitAfter(&Task{
+		TaskID:  "delayed",
+		GroupID: "group",
+		Apply:   task.Apply,
+	}, 200*time.Millisecond)
+	assert.True(t, accepted)
+	assert.Equal(t, 1, executor.GetGroupTasks("group"))
+
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, int32(0), task.n.Load())
+	time.Sleep(time.Second)
+	assert.Equal(t, int32(1), task.n.Load())
+	assert.Equal(t, 0, executor.GetGroupTasks("group"))
+}
+
+func TestWeightedLocalExecutor(t *testing.T) {
+	executor := NewWeightedLocalExecutor(1, 10, map[string]int{"high": 2, "low": 1})
+
+	var mu sync.Mutex
+	var order []string
+	submit := func(id, class string) {
+		accepted := executor.Submit(&Task{
+			TaskID: id,
+			Class:  class,
+			Apply: func() error {
+				mu.Lock()
+				order = append(order, id)
+				mu.Unlock()
+				return nil
+			},
+		})
+		assert.True(t, accepted)
+	}
+	submit("low-1", "low")
+	submit("low-2", "low")
+	submit("high-1", "high")
+	submit("high-2", "high")
+	submit("high-3", "high")
+
+	executor.Start()
+	defer executor.Close()
+	time.Sleep(100 * time.Millisecond) // wait to complete
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"high-1", "high-2", "low-1", "high-3", "low-2"}, order)
+}
+
 type testTask struct {
 	n *atomic.Int32
 }
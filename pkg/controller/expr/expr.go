@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/fatih/structs"
+	exprsyntax "github.com/fission/fission-workflows/pkg/types/expr"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/fission/fission-workflows/pkg/util"
 	"github.com/robertkrimen/otto"
@@ -16,25 +17,118 @@ import (
 )
 
 const (
-	varScope         = "$"
-	varCurrentTask   = "taskId"
+	varScope         = exprsyntax.VarScope
+	varCurrentTask   = exprsyntax.VarCurrentTask
 	ResolvingTimeout = time.Duration(100) * time.Millisecond
 )
 
 var (
 	ErrTimeOut      = errors.New("expression resolver timed out")
-	DefaultResolver = NewJavascriptExpressionParser()
+	DefaultResolver = NewMetaResolver(map[string]Resolver{
+		exprsyntax.LangJavascript: NewJavascriptExpressionParser(),
+		exprsyntax.LangPath:       NewPathExpressionParser(),
+	}, exprsyntax.LangJavascript)
 )
 
+func init() {
+	for lang := range DefaultResolver.languages {
+		exprsyntax.RegisterLanguage(lang)
+	}
+}
+
 func Resolve(rootScope interface{}, currentTask string, expr *typedvalues.TypedValue) (*typedvalues.TypedValue, error) {
 	return DefaultResolver.Resolve(rootScope, currentTask, expr)
 }
 
+// ResolveWithLanguage resolves the expression like Resolve, except that expressions without a language prefix are
+// resolved using the provided language, such as the expression language of a workflow. If the language is empty,
+// the default language of the DefaultResolver is used.
+func ResolveWithLanguage(lang string, rootScope interface{}, currentTask string,
+	expr *typedvalues.TypedValue) (*typedvalues.TypedValue, error) {
+	return DefaultResolver.WithDefaultLanguage(lang).Resolve(rootScope, currentTask, expr)
+}
+
 // resolver resolves an expression within a given context/scope.
 type Resolver interface {
 	Resolve(rootScope interface{}, currentTask string, expr *typedvalues.TypedValue) (*typedvalues.TypedValue, error)
 }
 
+// MetaResolver resolves expressions using one of the registered expression languages.
+//
+// An expression can select the language that it is written in with a prefix, such as `{path: output('task').field}`.
+// Expressions without a prefix are resolved using the default language. A prefix that does not match any of the
+// registered languages is considered to be part of the expression itself.
+type MetaResolver struct {
+	languages   map[string]Resolver
+	defaultLang string
+}
+
+func NewMetaResolver(languages map[string]Resolver, defaultLang string) *MetaResolver {
+	return &MetaResolver{
+		languages:   languages,
+		defaultLang: defaultLang,
+	}
+}
+
+// WithDefaultLanguage returns a resolver with the same languages, which resolves expressions without a prefix using
+// the provided language. If the language is empty, the resolver itself is returned.
+func (mr *MetaResolver) WithDefaultLanguage(lang string) *MetaResolver {
+	if len(lang) == 0 {
+		return mr
+	}
+	return NewMetaResolver(mr.languages, lang)
+}
+
+func (mr *MetaResolver) Resolve(rootScope interface{}, currentTask string,
+	expr *typedvalues.TypedValue) (*typedvalues.TypedValue, error) {
+
+	switch expr.ValueType() {
+	case typedvalues.TypeList:
+		return resolveList(mr, rootScope, currentTask, expr)
+	case typedvalues.TypeMap:
+		return resolveMap(mr, rootScope, currentTask, expr)
+	case typedvalues.TypeExpression:
+		return mr.resolveExpr(rootScope, currentTask, expr)
+	default:
+		return expr, nil
+	}
+}
+
+func (mr *MetaResolver) resolveExpr(rootScope interface{}, currentTask string,
+	expr *typedvalues.TypedValue) (*typedvalues.TypedValue, error) {
+
+	e, err := typedvalues.UnwrapExpression(expr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format expression for resolving (%v)", err)
+	}
+
+	lang := mr.defaultLang
+	if prefix, src, ok := exprsyntax.SplitLanguage(typedvalues.RemoveExpressionDelimiters(e)); ok {
+		if _, ok := mr.languages[prefix]; ok {
+			lang = prefix
+			// Without its prefix, a multi-line expression is no longer recognized as one from its source.
+			expr, err = typedvalues.Wrap(&typedvalues.Expression{Value: "{" + src + "}"})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	resolver, ok := mr.languages[lang]
+	if !ok {
+		return nil, fmt.Errorf("unknown expression language '%s'", lang)
+	}
+
+	result, err := resolver.Resolve(rootScope, currentTask, expr)
+	if err != nil {
+		return nil, err
+	}
+	// Keep the original expression, including the language prefix, as the source.
+	if result != nil {
+		result.SetMetadata("src", e)
+	}
+	return result, nil
+}
+
 // Function is an interface for providing functions that are able to be injected into the Otto runtime.
 type Function interface {
 	Apply(vm *otto.Otto, call otto.FunctionCall) otto.Value
@@ -58,9 +152,9 @@ func (oe *JavascriptExpressionParser) Resolve(rootScope interface{}, currentTask
 
 	switch expr.ValueType() {
 	case typedvalues.TypeList:
-		return oe.resolveList(rootScope, currentTask, expr)
+		return resolveList(oe, rootScope, currentTask, expr)
 	case typedvalues.TypeMap:
-		return oe.resolveMap(rootScope, currentTask, expr)
+		return resolveMap(oe, rootScope, currentTask, expr)
 	case typedvalues.TypeExpression:
 		return oe.resolveExpr(rootScope, currentTask, expr)
 	default:
@@ -133,7 +227,8 @@ func (oe *JavascriptExpressionParser) resolveExpr(rootScope interface{}, current
 	return result, nil
 }
 
-func (oe *JavascriptExpressionParser) resolveMap(rootScope interface{}, currentTask string,
+// resolveMap resolves each of the values in the map using the resolver.
+func resolveMap(r Resolver, rootScope interface{}, currentTask string,
 	expr *typedvalues.TypedValue) (*typedvalues.TypedValue, error) {
 
 	if expr.ValueType() != typedvalues.TypeMap {
@@ -154,7 +249,7 @@ func (oe *JavascriptExpressionParser) resolveMap(rootScope interface{}, currentT
 			return nil, err
 		}
 
-		resolved, err := oe.Resolve(rootScope, currentTask, field)
+		resolved, err := r.Resolve(rootScope, currentTask, field)
 		if err != nil {
 			return nil, err
 		}
@@ -168,7 +263,8 @@ func (oe *JavascriptExpressionParser) resolveMap(rootScope interface{}, currentT
 	return typedvalues.Wrap(result)
 }
 
-func (oe *JavascriptExpressionParser) resolveList(rootScope interface{}, currentTask string,
+// resolveList resolves each of the elements in the list using the resolver.
+func resolveList(r Resolver, rootScope interface{}, currentTask string,
 	expr *typedvalues.TypedValue) (*typedvalues.TypedValue, error) {
 
 	if expr.ValueType() != typedvalues.TypeList {
@@ -189,7 +285,7 @@ func (oe *JavascriptExpressionParser) resolveList(rootScope interface{}, current
 			return nil, err
 		}
 
-		resolved, err := oe.Resolve(rootScope, currentTask, field)
+		resolved, err := r.Resolve(rootScope, currentTask, field)
 		if err != nil {
 			return nil, err
 		}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	exprsyntax "github.com/fission/fission-workflows/pkg/types/expr"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/stretchr/testify/assert"
 )
@@ -85,6 +86,73 @@ func TestResolveInjectedFunction(t *testing.T) {
 	assert.NotEmpty(t, resolvedString)
 }
 
+func TestMetaResolver_Resolve(t *testing.T) {
+	resolver := NewMetaResolver(map[string]Resolver{
+		exprsyntax.LangJavascript: NewJavascriptExpressionParser(),
+		exprsyntax.LangPath:       NewPathExpressionParser(),
+	}, exprsyntax.LangJavascript)
+
+	// Without a prefix the default language is used.
+	resolved, err := resolver.Resolve(rootScope, "", mustParseExpr("{$.foo.toUpperCase()}"))
+	assert.NoError(t, err)
+	assert.Equal(t, "BAR", typedvalues.MustUnwrap(resolved))
+
+	resolved, err = resolver.Resolve(rootScope, "", mustParseExpr("{path: $.currentScope.bit}"))
+	assert.NoError(t, err)
+	assert.Equal(t, "bat", typedvalues.MustUnwrap(resolved))
+	src, _ := resolved.GetMetadataValue("src")
+	assert.Equal(t, "{path: $.currentScope.bit}", src)
+
+	_, err = resolver.Resolve(rootScope, "", mustParseExpr("{path: $.foo.toUpperCase()}"))
+	assert.Error(t, err)
+
+	// Prefixes are also recognized in nested values.
+	resolved, err = resolver.Resolve(rootScope, "", typedvalues.MustWrap(map[string]interface{}{
+		"js":   "{js: $.foo + $.foo}",
+		"path": []interface{}{"{path: $.foo}"},
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"js":   "barbar",
+		"path": []interface{}{"bar"},
+	}, typedvalues.MustUnwrap(resolved))
+}
+
+func TestMetaResolver_ResolveMultiline(t *testing.T) {
+	resolver := NewMetaResolver(map[string]Resolver{
+		exprsyntax.LangJavascript: NewJavascriptExpressionParser(),
+		exprsyntax.LangPath:       NewPathExpressionParser(),
+	}, exprsyntax.LangJavascript)
+
+	resolved, err := resolver.Resolve(rootScope, "", mustParseExpr("{path:\n  $.currentScope\n  .bit\n}"))
+	assert.NoError(t, err)
+	assert.Equal(t, "bat", typedvalues.MustUnwrap(resolved))
+}
+
+func TestDefaultResolver_RegistersLanguages(t *testing.T) {
+	for lang := range DefaultResolver.languages {
+		assert.True(t, exprsyntax.IsLanguage(lang), lang)
+	}
+	assert.False(t, exprsyntax.IsLanguage("cobol"))
+}
+
+func TestMetaResolver_WithDefaultLanguage(t *testing.T) {
+	resolver := NewMetaResolver(map[string]Resolver{
+		exprsyntax.LangJavascript: NewJavascriptExpressionParser(),
+		exprsyntax.LangPath:       NewPathExpressionParser(),
+	}, exprsyntax.LangJavascript).WithDefaultLanguage(exprsyntax.LangPath)
+
+	// Expressions without a prefix are resolved as path expressions, whereas prefixes still take precedence.
+	_, err := resolver.Resolve(rootScope, "", mustParseExpr("{$.foo.toUpperCase()}"))
+	assert.Error(t, err)
+	resolved, err := resolver.Resolve(rootScope, "", mustParseExpr("{js: $.foo.toUpperCase()}"))
+	assert.NoError(t, err)
+	assert.Equal(t, "BAR", typedvalues.MustUnwrap(resolved))
+
+	_, err = resolver.WithDefaultLanguage("cobol").Resolve(rootScope, "", mustParseExpr("{$.foo}"))
+	assert.Error(t, err)
+}
+
 func mustParseExpr(s string) *typedvalues.TypedValue {
 	tv := typedvalues.MustWrap(s)
 	if tv.ValueType() != typedvalues.TypeExpression {
@@ -0,0 +1,217 @@
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/fatih/structs"
+	"github.com/fission/fission-workflows/pkg/types"
+	exprsyntax "github.com/fission/fission-workflows/pkg/types/expr"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/fission/fission-workflows/pkg/util"
+)
+
+// PathExpressionParser resolves expressions that consist of a single reference to data in the scope, such as
+// `{output('task').field}`. Unlike the JavascriptExpressionParser it does not evaluate the expression in an
+// interpreter, which makes resolving fast and deterministic, at the cost of only supporting data references.
+//
+// A path expression (see exprsyntax.Path) starts with the scope (`$`), the id of the current task (`taskId`), or one of
+// the builtin functions input, output, outputHeaders, param and task. These functions take the same arguments and have the same
+// semantics as in JavaScript expressions. The uid function is not supported, as it is not deterministic.
+//
+// The root can be followed by any number of selectors: `.field`, `["field"]` or `[0]`. Selecting a field or index
+// that does not exist results in an undefined (nil) value, whereas selecting anything of an undefined value fails.
+type PathExpressionParser struct{}
+
+func NewPathExpressionParser() *PathExpressionParser {
+	return &PathExpressionParser{}
+}
+
+func (pe *PathExpressionParser) Resolve(rootScope interface{}, currentTask string,
+	expr *typedvalues.TypedValue) (*typedvalues.TypedValue, error) {
+
+	switch expr.ValueType() {
+	case typedvalues.TypeList:
+		return resolveList(pe, rootScope, currentTask, expr)
+	case typedvalues.TypeMap:
+		return resolveMap(pe, rootScope, currentTask, expr)
+	case typedvalues.TypeExpression:
+		return pe.resolveExpr(rootScope, currentTask, expr)
+	default:
+		return expr, nil
+	}
+}
+
+func (pe *PathExpressionParser) resolveExpr(rootScope interface{}, currentTask string,
+	expr *typedvalues.TypedValue) (*typedvalues.TypedValue, error) {
+
+	e, err := typedvalues.UnwrapExpression(expr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format expression for resolving (%v)", err)
+	}
+
+	path, err := exprsyntax.ParsePath(typedvalues.RemoveExpressionDelimiters(e))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse path expression '%s': %v", e, err)
+	}
+	i, err := evalPath(path, rootScope, currentTask)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path expression '%s': %v", e, err)
+	}
+
+	if structs.IsStruct(i) {
+		mp, err := util.ConvertStructsToMap(i)
+		if err != nil {
+			return nil, err
+		}
+		i = mp
+	}
+
+	result, err := typedvalues.Wrap(i)
+	if err != nil {
+		return nil, err
+	}
+	result.SetMetadata("src", e)
+	return result, nil
+}
+
+// evalPath evaluates the parsed path expression within the scope.
+func evalPath(path *exprsyntax.Path, rootScope interface{}, currentTask string) (interface{}, error) {
+	val, err := evalRoot(path, rootScope, currentTask)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range path.Selectors {
+		val, err = selectKey(val, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return val, nil
+}
+
+func evalRoot(path *exprsyntax.Path, rootScope interface{}, currentTask string) (interface{}, error) {
+	switch path.Root {
+	case exprsyntax.VarScope:
+		return rootScope, nil
+	case exprsyntax.VarCurrentTask:
+		return currentTask, nil
+	}
+
+	args := path.Args
+	arg := func(i int, defaultVal string) string {
+		if i < len(args) {
+			return args[i]
+		}
+		return defaultVal
+	}
+
+	// The builtin functions are translated to lookups in the scope, equivalent to the JavaScript functions.
+	var keys []interface{}
+	switch path.Root {
+	case "input":
+		keys = []interface{}{"Tasks", arg(0, currentTask), "Inputs", arg(1, types.InputMain)}
+	case "output":
+		keys = []interface{}{"Tasks", arg(0, currentTask), "Output"}
+	case "outputHeaders":
+		keys = []interface{}{"Tasks", arg(0, currentTask), "OutputHeaders"}
+		if len(args) > 1 {
+			keys = append(keys, args[1])
+		}
+	case "param":
+		keys = []interface{}{"Invocation", "Inputs", arg(0, types.InputMain)}
+	case "task":
+		keys = []interface{}{"Tasks", arg(0, currentTask)}
+	default:
+		return nil, fmt.Errorf("unsupported function '%s'", path.Root)
+	}
+
+	// Similar to the JavaScript functions, a lookup of an undefined task or input results in an undefined value.
+	val := rootScope
+	for _, key := range keys {
+		if val == nil {
+			return nil, nil
+		}
+		var err error
+		val, err = selectKey(val, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return val, nil
+}
+
+// selectKey selects the field or element identified by key of the value, which can be a map, struct, slice or array.
+func selectKey(val interface{}, key interface{}) (interface{}, error) {
+	rv := reflect.ValueOf(val)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			break
+		}
+		rv = rv.Elem()
+	}
+	if !rv.IsValid() || ((rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) && rv.IsNil()) {
+		return nil, fmt.Errorf("cannot select '%v' of undefined value", key)
+	}
+
+	var result reflect.Value
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, errors.New("only maps with string keys are supported")
+		}
+		result = rv.MapIndex(reflect.ValueOf(fmt.Sprintf("%v", key)).Convert(rv.Type().Key()))
+	case reflect.Struct:
+		name, ok := key.(string)
+		if !ok {
+			return nil, nil
+		}
+		result = fieldByName(rv, name)
+	case reflect.Slice, reflect.Array:
+		i, ok := key.(int)
+		if !ok {
+			var err error
+			if i, err = strconv.Atoi(fmt.Sprintf("%v", key)); err != nil {
+				return nil, nil
+			}
+		}
+		if i < 0 || i >= rv.Len() {
+			return nil, nil
+		}
+		result = rv.Index(i)
+	}
+
+	if !result.IsValid() {
+		return nil, nil
+	}
+	switch result.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+		if result.IsNil() {
+			return nil, nil
+		}
+	}
+	return result.Interface(), nil
+}
+
+// fieldByName looks up an exported field of the struct, including promoted fields of embedded structs. An invalid
+// value is returned if the field does not exist or if one of the embedded structs on the way is nil.
+func fieldByName(rv reflect.Value, name string) reflect.Value {
+	field, ok := rv.Type().FieldByName(name)
+	if !ok || len(field.PkgPath) > 0 {
+		return reflect.Value{}
+	}
+	for i, idx := range field.Index {
+		if i > 0 {
+			if rv.Kind() == reflect.Ptr {
+				if rv.IsNil() {
+					return reflect.Value{}
+				}
+				rv = rv.Elem()
+			}
+		}
+		rv = rv.Field(idx)
+	}
+	return rv
+}
@@ -0,0 +1,80 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathExpressionParser_Resolve(t *testing.T) {
+	parser := NewPathExpressionParser()
+	testScope := makeTestScope()
+
+	cases := map[string]interface{}{
+		"{$.Invocation.Inputs.headers}":        "http-headers",
+		"{$.Tasks.TaskA.Id}":                   "TaskA",
+		"{$['Tasks'][\"TaskA\"].Output}":       "some output",
+		"{ output('TaskA') }":                  "some output",
+		"{output()}":                           "some output",
+		"{input()}":                            "input-default",
+		"{input('TaskA', 'otherInput')}":       "input-otherInput",
+		"{param()}":                            "body",
+		"{param('headers')}":                   "http-headers",
+		"{outputHeaders('TaskA')['some-key']}": "some-value",
+		"{outputHeaders('TaskA', 'some-key')}": "some-value",
+		"{taskId}":                             "TaskA",
+		"{task().Function}":                    "",
+		"{$.Tasks.TaskA.Inputs.nonExistent}":   nil,
+		"{output('nonExistentTask')}":          nil,
+		"{$.Invocation.Inputs.default.length}": nil,
+		"{task('TaskA')}":                      util.MustConvertStructsToMap(testScope.Tasks["TaskA"]),
+		"{outputHeaders()}":                    testScope.Tasks["TaskA"].OutputHeaders,
+		"{$.Tasks.TaskA.Requires}":             nil,
+		"{$.Workflow.Name}":                    "",
+		"{$.Tasks.TaskA.ObjectMetadata.Id}":    "TaskA",
+		"{$.Invocation.Inputs['default']}":     "body",
+	}
+
+	for expr, expected := range cases {
+		result, err := parser.Resolve(testScope, "TaskA", mustParseExpr(expr))
+		if !assert.NoError(t, err, expr) {
+			continue
+		}
+		assert.Equal(t, expected, typedvalues.MustUnwrap(result), expr)
+		src, _ := result.GetMetadataValue("src")
+		assert.Equal(t, expr, src, expr)
+	}
+}
+
+func TestPathExpressionParser_ResolveList(t *testing.T) {
+	parser := NewPathExpressionParser()
+	scope := map[string]interface{}{
+		"list": []interface{}{"a", "b"},
+	}
+
+	result, err := parser.Resolve(scope, "", typedvalues.MustWrap([]interface{}{"{$.list[1]}", "{$.list[2]}", "c"}))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"b", nil, "c"}, typedvalues.MustUnwrap(result))
+}
+
+func TestPathExpressionParser_ResolveInvalid(t *testing.T) {
+	parser := NewPathExpressionParser()
+	testScope := makeTestScope()
+
+	for _, expr := range []string{
+		"{}",
+		"{uid()}",
+		"{'literal'}",
+		"{$.Tasks.TaskA.Output.toUpperCase()}",
+		"{$.Tasks.nonExistentTask.Output}",
+		"{$.Tasks[TaskA]}",
+		"{output('TaskA'}",
+		"{$.Tasks.}",
+		"{$ + 1}",
+	} {
+		_, err := parser.Resolve(testScope, "TaskA", mustParseExpr(expr))
+		assert.Error(t, err, expr)
+	}
+}
@@ -8,23 +8,27 @@ import (
 	"time"
 
 	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/events"
 	"github.com/fission/fission-workflows/pkg/api/store"
 	"github.com/fission/fission-workflows/pkg/controller/ctrl"
 	"github.com/fission/fission-workflows/pkg/controller/executor"
 	"github.com/fission/fission-workflows/pkg/controller/expr"
+	"github.com/fission/fission-workflows/pkg/controller/lease"
 	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/scheduler"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues/controlflow"
+	"github.com/fission/fission-workflows/pkg/types/validate"
 	"github.com/fission/fission-workflows/pkg/util"
+	"github.com/fission/fission-workflows/pkg/util/backoff"
+	"github.com/fission/fission-workflows/pkg/util/workqueue"
 	"github.com/golang/protobuf/ptypes"
 	"github.com/opentracing/opentracing-go"
 	"github.com/sirupsen/logrus"
 )
 
 const (
-	DefaultMaxRuntime       = 10 * time.Minute
 	awaitWorkflowMaxRuntime = 10 * time.Second
 )
 
@@ -35,17 +39,21 @@ type InvocationController struct {
 	invocationAPI *api.Invocation
 	taskAPI       *api.Task
 	scheduler     *scheduler.InvocationScheduler
+	limiter       *scheduler.ConcurrencyLimiter
+	maxRuntime    time.Duration
 	StateStore    *expr.Store // Future: just grab the initial state of the parent, instead of constantly rebuilding it.
 	span          opentracing.Span
 	logger        *logrus.Entry
 	startedTasks  map[string]struct{}
+	retriedTasks  map[string]int32 // The most recent retry attempt submitted for each task.
 
 	errorCount int
 }
 
 func NewInvocationController(invocationID string, executor *executor.LocalExecutor, invocationAPI *api.Invocation,
-	taskAPI *api.Task, scheduler *scheduler.InvocationScheduler, stateStore *expr.Store,
-	span opentracing.Span, logger *logrus.Entry) *InvocationController {
+	taskAPI *api.Task, scheduler *scheduler.InvocationScheduler, limiter *scheduler.ConcurrencyLimiter,
+	maxRuntime time.Duration, stateStore *expr.Store, span opentracing.Span,
+	logger *logrus.Entry) *InvocationController {
 
 	return &InvocationController{
 		invocationID:  invocationID,
@@ -53,10 +61,13 @@ func NewInvocationController(invocationID string, executor *executor.LocalExecut
 		invocationAPI: invocationAPI,
 		taskAPI:       taskAPI,
 		scheduler:     scheduler,
+		limiter:       limiter,
+		maxRuntime:    maxRuntime,
 		StateStore:    stateStore,
 		span:          span,
 		logger:        logger,
 		startedTasks:  map[string]struct{}{},
+		retriedTasks:  map[string]int32{},
 	}
 }
 
@@ -79,6 +90,7 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 		c.executor.Submit(&executor.Task{
 			TaskID:  invocation.ID() + ".fail",
 			GroupID: invocation.ID(),
+			Class:   scheduler.PriorityClassOf(invocation),
 			Apply: func() error {
 				return c.invocationAPI.Fail(invocation.ID(), err)
 			},
@@ -91,10 +103,18 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 		return ctrl.Err{Err: fmt.Errorf("invocation still has %d open task(s) to be executed", activeTaskCount)}
 	}
 
+	// A retried invocation discards its unsuccessful task runs, so forget about the tasks that have been submitted.
+	if processValue.Event.GetType() == events.EventInvocationRetried {
+		c.startedTasks = map[string]struct{}{}
+		c.retriedTasks = map[string]int32{}
+	}
+
 	// To avoid scheduling tasks that are being processed, ensure that all tasks that were successfully submitted have
-	// finished before reevaluating.
+	// finished before reevaluating. Tasks that are awaiting a signal have been recorded as in progress, so they will
+	// not be scheduled again.
 	for taskID := range c.startedTasks {
-		if taskRun, ok := invocation.TaskInvocation(taskID); !ok || !taskRun.GetStatus().Finished() {
+		taskRun, ok := invocation.TaskInvocation(taskID)
+		if !ok || !(taskRun.GetStatus().Finished() || awaitingSignal(taskRun) || signaled(taskRun)) {
 			return ctrl.Success{}
 		}
 	}
@@ -105,28 +125,65 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 			invocation.GetStatus().GetStatus().String())}
 	}
 
-	// Check if the deadline has not been exceeded
-	deadline, err := ptypes.Timestamp(invocation.GetSpec().GetDeadline())
-	if err != nil {
-		createdAt, err := ptypes.Timestamp(invocation.GetMetadata().GetCreatedAt())
-		if err != nil {
-			err := errors.New("failed to read deadline and createdAt")
+	// Complete the tasks that have received their signal, transforming the payload like the output of other tasks.
+	if signaledTasks := getSignaledTasks(invocation); len(signaledTasks) > 0 {
+		for _, taskID := range signaledTasks {
+			taskRun, _ := invocation.TaskInvocation(taskID)
 			c.executor.Submit(&executor.Task{
-				TaskID:  invocation.ID() + ".fail",
+				TaskID:  fmt.Sprintf("%s.signaled.%s", invocation.ID(), taskID),
 				GroupID: invocation.ID(),
+				Class:   scheduler.PriorityClassOf(invocation),
 				Apply: func() error {
-					return c.invocationAPI.Fail(invocation.ID(), err)
+					return c.completeSignaledTask(invocation, taskRun)
 				},
 			})
-			return ctrl.Err{Err: err}
 		}
-		deadline = createdAt.Add(DefaultMaxRuntime)
+		return ctrl.Success{Msg: fmt.Sprintf("completing %d signaled task(s)", len(signaledTasks))}
+	}
+
+	// Fail the tasks that are still in progress after their deadline has passed, such as tasks awaiting a signal or
+	// tasks of which the controller stopped while running them.
+	if expiredTasks := getExpiredTasks(invocation, time.Now()); len(expiredTasks) > 0 {
+		for _, taskID := range expiredTasks {
+			taskID := taskID
+			taskRun, _ := invocation.TaskInvocation(taskID)
+			msg := "deadline exceeded"
+			if awaitingSignal(taskRun) {
+				msg = "deadline exceeded while awaiting a signal"
+			}
+			generation := taskRun.GetMetadata().GetGeneration()
+			c.executor.Submit(&executor.Task{
+				TaskID:  fmt.Sprintf("%s.timeout.%s", invocation.ID(), taskID),
+				GroupID: invocation.ID(),
+				Class:   scheduler.PriorityClassOf(invocation),
+				Apply: func() error {
+					return c.taskAPI.Fail(invocation.ID(), taskID, msg, api.ExpectGeneration(generation))
+				},
+			})
+		}
+		return ctrl.Success{Msg: fmt.Sprintf("timed out %d task(s) in progress", len(expiredTasks))}
+	}
+
+	// Check if the deadline has not been exceeded
+	deadline, err := invocation.Deadline(c.maxRuntime)
+	if err != nil {
+		err := errors.New("failed to read deadline and createdAt")
+		c.executor.Submit(&executor.Task{
+			TaskID:  invocation.ID() + ".fail",
+			GroupID: invocation.ID(),
+			Class:   scheduler.PriorityClassOf(invocation),
+			Apply: func() error {
+				return c.invocationAPI.Fail(invocation.ID(), err)
+			},
+		})
+		return ctrl.Err{Err: err}
 	}
 	if time.Now().After(deadline) {
 		err := errors.New("deadline exceeded")
 		c.executor.Submit(&executor.Task{
 			TaskID:  invocation.ID() + ".fail",
 			GroupID: invocation.ID(),
+			Class:   scheduler.PriorityClassOf(invocation),
 			Apply: func() error {
 				return c.invocationAPI.Fail(invocation.ID(), err)
 			},
@@ -140,6 +197,7 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 		c.executor.Submit(&executor.Task{
 			TaskID:  invocation.ID() + ".fail",
 			GroupID: invocation.ID(),
+			Class:   scheduler.PriorityClassOf(invocation),
 			Apply: func() error {
 				return c.invocationAPI.Fail(invocation.ID(), err)
 			},
@@ -147,13 +205,24 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 		return ctrl.Err{Err: err}
 	}
 
+	// Retry failed tasks that have attempts left, before their failure is considered to be final. The tasks that do
+	// not depend on the retried tasks are scheduled as usual in the meantime.
+	retryableTasks := getRetryableTasks(invocation)
+	var retries int
+	for taskID, taskRun := range retryableTasks {
+		if c.retryTask(invocation, taskID, taskRun) {
+			retries++
+		}
+	}
+
 	// Check if all tasks have finished
-	if allTasksFinished(invocation) {
+	if len(retryableTasks) == 0 && allTasksFinished(invocation) {
 		output, outputHeaders, err := determineTaskOutput(invocation)
 		if err != nil {
 			c.executor.Submit(&executor.Task{
 				TaskID:  invocation.ID() + ".fail",
 				GroupID: invocation.ID(),
+				Class:   scheduler.PriorityClassOf(invocation),
 				Apply: func() error {
 					return c.invocationAPI.Fail(invocation.ID(), err)
 				},
@@ -163,6 +232,7 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 			c.executor.Submit(&executor.Task{
 				TaskID:  invocation.ID() + ".success",
 				GroupID: invocation.ID(),
+				Class:   scheduler.PriorityClassOf(invocation),
 				Apply: func() error {
 					return c.invocationAPI.Complete(invocation.ID(), output, outputHeaders)
 				},
@@ -183,6 +253,7 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 		c.executor.Submit(&executor.Task{
 			TaskID:  invocation.ID() + ".fail",
 			GroupID: invocation.ID(),
+			Class:   scheduler.PriorityClassOf(invocation),
 			Apply: func() error {
 				return c.invocationAPI.Fail(invocation.ID(), err)
 			},
@@ -195,6 +266,7 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 		c.executor.Submit(&executor.Task{
 			TaskID:  fmt.Sprintf("%s.prewarm.%s", invocation.ID(), action.TaskID),
 			GroupID: invocation.ID(),
+			Class:   scheduler.PriorityClassOf(invocation),
 			Apply: func() error {
 				task, ok := invocation.Task(action.TaskID)
 				if !ok || task == nil {
@@ -206,23 +278,55 @@ func (c *InvocationController) Eval(ctx context.Context, processValue *ctrl.Even
 		})
 	}
 
-	// Execute the tasks listed in the schedule.
+	// Skip the tasks listed in the schedule.
+	for _, action := range schedule.GetSkipTasks() {
+		taskID := action.TaskID
+		generation := taskRunGeneration(invocation, taskID)
+		c.logger.Infof("Skipping task %s: %s", taskID, action.Reason)
+		c.executor.Submit(&executor.Task{
+			TaskID:  fmt.Sprintf("%s.skip.%s", invocation.ID(), taskID),
+			GroupID: invocation.ID(),
+			Class:   scheduler.PriorityClassOf(invocation),
+			Apply: func() error {
+				return c.taskAPI.Skip(invocation.ID(), taskID, api.ExpectGeneration(generation))
+			},
+		})
+	}
+
+	// Execute the tasks listed in the schedule. Tasks that would exceed a concurrency limit remain queued; the
+	// invocation is re-evaluated once capacity has been released.
+	var queued int
 	for _, action := range schedule.GetRunTasks() {
 		taskID := action.TaskID
+		release := func() {}
+		if c.limiter != nil {
+			var ok bool
+			release, ok = c.limiter.TryAcquire(invocation, taskID)
+			if !ok {
+				c.logger.Debugf("Queued task %s: concurrency limit reached", taskID)
+				queued++
+				continue
+			}
+		}
 		if c.executor.Submit(&executor.Task{
 			TaskID:  fmt.Sprintf("%s.run.%s", invocation.ID(), taskID),
 			GroupID: invocation.ID(),
+			Class:   scheduler.PriorityClassOf(invocation),
 			Apply: func() error {
+				defer release()
 				return c.execTask(invocation, taskID)
 			},
 		}) {
 			c.startedTasks[action.TaskID] = struct{}{}
+		} else {
+			release()
 		}
 	}
 
 	return ctrl.Success{
-		Msg: fmt.Sprintf("scheduled execution of %d tasks and preparation of %d tasks",
-			len(schedule.GetRunTasks()), len(schedule.GetPrepareTasks())),
+		Msg: fmt.Sprintf("scheduled execution of %d tasks (%d queued), preparation of %d tasks, skipping of %d tasks "+
+			"and retry of %d tasks", len(schedule.GetRunTasks())-queued, queued, len(schedule.GetPrepareTasks()),
+			len(schedule.GetSkipTasks()), retries),
 	}
 }
 
@@ -280,6 +384,17 @@ func (c *InvocationController) execTask(invocation *types.WorkflowInvocation, ta
 		}
 	}
 
+	// Provide error handlers with the error of the task that they handle.
+	if handledTaskID, ok := invocation.ErrorHandlers()[taskID]; ok {
+		if _, ok := inputs[types.InputError]; !ok {
+			handled, _ := invocation.TaskInvocation(handledTaskID)
+			if inputs == nil {
+				inputs = map[string]*typedvalues.TypedValue{}
+			}
+			inputs[types.InputError] = typedvalues.MustWrap(handled.GetStatus().GetError().GetMessage())
+		}
+	}
+
 	// Create the task run
 	taskRunSpec := types.NewTaskInvocationSpec(invocation, task, time.Now())
 	taskRunSpec.Inputs = inputs
@@ -303,10 +418,16 @@ func (c *InvocationController) execTask(invocation *types.WorkflowInvocation, ta
 	ctx = opentracing.ContextWithSpan(ctx, span)
 
 	// Invoke the task
+	// Another controller could have claimed the task in the meantime, based on the same state of the invocation. In
+	// that case, the event store rejects the claim of this controller, and the task is not run again.
 	updated, err := c.taskAPI.Invoke(taskRunSpec, api.WithContext(ctx), api.AwaitWorklow(awaitWorkflowMaxRuntime),
 		api.PostTransformer(func(ti *types.TaskInvocation) error {
 			return c.transformTaskRunOutputs(invocation, ti)
-		}))
+		}), api.ExpectGeneration(taskRunGeneration(invocation, taskID)))
+	if fes.ErrGenerationConflict.Is(err) {
+		log.Debugf("Task %s has already been claimed: %v", taskID, err)
+		return nil
+	}
 	if err != nil {
 		span.LogKV("error", err)
 		return err
@@ -329,6 +450,54 @@ func (c *InvocationController) execTask(invocation *types.WorkflowInvocation, ta
 	return nil
 }
 
+// completeSignaledTask completes the task run that has received its signal, with the payload of the signal
+// transformed according to the output and output headers of the task.
+func (c *InvocationController) completeSignaledTask(invocation *types.WorkflowInvocation,
+	taskRun *types.TaskInvocation) error {
+	taskID := taskRun.ID()
+	generation := taskRun.GetMetadata().GetGeneration()
+	completed := taskRun.Copy()
+	completed.Status.Status = types.TaskInvocationStatus_SUCCEEDED
+	if err := c.transformTaskRunOutputs(invocation, completed); err != nil {
+		return c.taskAPI.Fail(invocation.ID(), taskID, fmt.Sprintf("failed to transform the signal: %v", err),
+			api.ExpectGeneration(generation))
+	}
+	return c.taskAPI.Succeed(invocation.ID(), taskID, completed.GetStatus(), api.ExpectGeneration(generation))
+}
+
+// retryTask submits a retry of the failed task, delayed according to the backoff of its retry policy. The retry
+// resets the task, after which it is scheduled again like any other task.
+func (c *InvocationController) retryTask(invocation *types.WorkflowInvocation, taskID string,
+	taskRun *types.TaskInvocation) bool {
+	attempt := taskRun.GetStatus().GetRetries() + 1
+	if c.retriedTasks[taskID] >= attempt {
+		// The retry has already been submitted.
+		return false
+	}
+
+	task, _ := invocation.Task(taskID)
+	policy := task.GetSpec().GetRetry()
+	delay := retryDelay(policy, attempt)
+	cause := taskRun.GetStatus().GetError()
+	// The delayed retry is not part of the group of the invocation, as it would otherwise block the evaluation of the
+	// invocation, and so the execution of other tasks, until its backoff has passed.
+	if !c.executor.SubmitAfter(&executor.Task{
+		TaskID: fmt.Sprintf("%s.retry.%s.%d", invocation.ID(), taskID, attempt),
+		Class:  scheduler.PriorityClassOf(invocation),
+		Apply: func() error {
+			return c.taskAPI.Retry(invocation.ID(), taskID, attempt, cause,
+				api.ExpectGeneration(taskRun.GetMetadata().GetGeneration()))
+		},
+	}, delay) {
+		return false
+	}
+	c.retriedTasks[taskID] = attempt
+	delete(c.startedTasks, taskID)
+	c.logger.Infof("Retrying task %s in %v (attempt %d of %d): %v", taskID, delay, attempt+1,
+		policy.GetMaxAttempts(), cause.GetMessage())
+	return true
+}
+
 func (c *InvocationController) resolveInputs(invocation *types.WorkflowInvocation, taskID string,
 	inputs map[string]*typedvalues.TypedValue) (map[string]*typedvalues.TypedValue, error) {
 	// Inherit scope if invocation has a parent
@@ -352,7 +521,7 @@ func (c *InvocationController) resolveInputs(invocation *types.WorkflowInvocatio
 	// Resolve each of the inputs (based on priority)
 	resolvedInputs := map[string]*typedvalues.TypedValue{}
 	for _, input := range typedvalues.Prioritize(inputs) {
-		resolvedInput, err := expr.Resolve(scope, taskID, input.Val)
+		resolvedInput, err := expr.ResolveWithLanguage(expressionLanguage(invocation), scope, taskID, input.Val)
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve input field %v: %v", input.Key, err)
 		}
@@ -398,7 +567,7 @@ func (c *InvocationController) resolveOutput(invocation *types.WorkflowInvocatio
 	scope.Tasks[taskID].Output = typedvalues.MustUnwrap(ti.GetStatus().GetOutput())
 
 	// Resolve the output expression
-	resolvedOutput, err := expr.Resolve(scope, taskID, outputExpr)
+	resolvedOutput, err := expr.ResolveWithLanguage(expressionLanguage(invocation), scope, taskID, outputExpr)
 	if err != nil {
 		return nil, err
 	}
@@ -472,7 +641,8 @@ func (c *InvocationController) resolveOutputHeaders(invocation *types.WorkflowIn
 	scope.Tasks[taskID].OutputHeaders = typedvalues.MustUnwrap(ti.GetStatus().GetOutputHeaders())
 
 	// Resolve the outputHeaders expression
-	resolvedOutputHeaders, err := expr.Resolve(scope, taskID, outputHeadersExpr)
+	resolvedOutputHeaders, err := expr.ResolveWithLanguage(expressionLanguage(invocation), scope, taskID,
+		outputHeadersExpr)
 	if err != nil {
 		return nil, err
 	}
@@ -484,11 +654,16 @@ func determineTaskOutput(invocation *types.WorkflowInvocation) (output *typedval
 
 	success := true
 	wf := invocation.GetSpec().GetWorkflow()
-	for id := range invocation.Tasks() {
-		task := invocation.Status.Tasks[id]
-		if !task.GetStatus().Successful() {
+	for id, task := range invocation.Tasks() {
+		taskRun := invocation.Status.Tasks[id]
+		switch taskRun.GetStatus().GetStatus() {
+		case types.TaskInvocationStatus_SUCCEEDED, types.TaskInvocationStatus_SKIPPED:
+			// ok
+		case types.TaskInvocationStatus_FAILED:
+			// Failures of tasks with an error handler do not fail the invocation.
+			success = success && len(task.GetSpec().GetOnError()) > 0
+		default:
 			success = false
-			break
 		}
 	}
 
@@ -500,12 +675,107 @@ func determineTaskOutput(invocation *types.WorkflowInvocation) (output *typedval
 	}
 
 	if success {
+		if err := validate.WorkflowOutput(wf.GetSpec(), finalOutput); err != nil {
+			return nil, nil, err
+		}
 		return finalOutput, finalOutputHeaders, nil
 	} else {
 		return nil, nil, errors.New("one or more tasks in the workflow have failed")
 	}
 }
 
+// getRetryableTasks returns the failed tasks that should be retried according to their retry policies. If any of the
+// failed tasks cannot be retried nor has an error handler, the invocation is bound to fail, so none of the tasks are
+// returned.
+func getRetryableTasks(invocation *types.WorkflowInvocation) map[string]*types.TaskInvocation {
+	retryable := map[string]*types.TaskInvocation{}
+	for taskID, taskRun := range invocation.TaskInvocations() {
+		if taskRun.GetStatus().GetStatus() != types.TaskInvocationStatus_FAILED {
+			continue
+		}
+		task, ok := invocation.Task(taskID)
+		if !ok {
+			return nil
+		}
+		if task.GetSpec().GetRetry().ShouldRetry(taskRun.GetStatus()) {
+			retryable[taskID] = taskRun
+		} else if len(task.GetSpec().GetOnError()) == 0 {
+			return nil
+		}
+	}
+	return retryable
+}
+
+// retryDelay computes the delay before the given retry attempt (starting at 1) of a task.
+func retryDelay(policy *types.RetryPolicy, attempt int32) time.Duration {
+	b := &backoff.Instance{
+		BackoffPolicy: backoff.ExponentialBackoff,
+		Attempt:       int(attempt - 1),
+	}
+	if policy.GetBackoff() == types.RetryPolicy_CONSTANT {
+		b.BackoffPolicy = backoff.ConstantBackoff
+	}
+	if delay, err := ptypes.Duration(policy.GetDelay()); err == nil {
+		b.BaseRetryDuration = delay
+	}
+	if maxDelay, err := ptypes.Duration(policy.GetMaxDelay()); err == nil {
+		b.MaxBackoffDuration = maxDelay
+	}
+	return b.Duration()
+}
+
+// expressionLanguage returns the language of the expressions in the invocation that do not specify a language.
+func expressionLanguage(invocation *types.WorkflowInvocation) string {
+	return invocation.Workflow().GetSpec().GetExpressionLanguage()
+}
+
+// taskRunGeneration returns the generation of the run of the task in the invocation, which is 0 if the task has not
+// been run yet.
+func taskRunGeneration(invocation *types.WorkflowInvocation, taskID string) int64 {
+	taskRun, _ := invocation.TaskInvocation(taskID)
+	return taskRun.GetMetadata().GetGeneration()
+}
+
+// awaitingSignal checks if the function of the task run has returned, but the task run is waiting to be completed by a
+// signal.
+func awaitingSignal(taskRun *types.TaskInvocation) bool {
+	return taskRun.GetStatus().GetStatus() == types.TaskInvocationStatus_IN_PROGRESS &&
+		taskRun.GetStatus().GetAwaitingSignal() && !taskRun.GetStatus().GetSignaled()
+}
+
+// signaled checks if the task run has received its signal, but has not been completed yet.
+func signaled(taskRun *types.TaskInvocation) bool {
+	return taskRun.GetStatus().GetStatus() == types.TaskInvocationStatus_IN_PROGRESS &&
+		taskRun.GetStatus().GetSignaled()
+}
+
+// getSignaledTasks returns the IDs of the tasks that have received their signal, but have not been completed yet.
+func getSignaledTasks(invocation *types.WorkflowInvocation) []string {
+	var tasks []string
+	for taskID, taskRun := range invocation.TaskInvocations() {
+		if signaled(taskRun) {
+			tasks = append(tasks, taskID)
+		}
+	}
+	return tasks
+}
+
+// getExpiredTasks returns the IDs of the tasks that are in progress beyond the deadline of their task run. Tasks that
+// have received their signal are excluded, as they are about to be completed.
+func getExpiredTasks(invocation *types.WorkflowInvocation, now time.Time) []string {
+	var expired []string
+	for taskID, taskRun := range invocation.TaskInvocations() {
+		if taskRun.GetStatus().GetStatus() != types.TaskInvocationStatus_IN_PROGRESS || signaled(taskRun) {
+			continue
+		}
+		deadline, err := ptypes.Timestamp(taskRun.GetSpec().GetDeadline())
+		if err == nil && now.After(deadline) {
+			expired = append(expired, taskID)
+		}
+	}
+	return expired
+}
+
 func allTasksFinished(invocation *types.WorkflowInvocation) bool {
 	finished := true
 	for id := range invocation.Tasks() {
@@ -530,16 +800,18 @@ type InvocationMetaController struct {
 	runOnce     *sync.Once
 	invocations *store.Invocations
 	system      *ctrl.System
+	shards      *lease.Shards
 }
 
 func NewInvocationMetaController(executor *executor.LocalExecutor, invocations *store.Invocations,
-	invocationAPI *api.Invocation, taskAPI *api.Task, scheduler *scheduler.InvocationScheduler, stateStore *expr.Store,
+	invocationAPI *api.Invocation, taskAPI *api.Task, scheduler *scheduler.InvocationScheduler,
+	limiter *scheduler.ConcurrencyLimiter, maxRuntime time.Duration, stateStore *expr.Store,
 	cachePollInterval time.Duration) *InvocationMetaController {
 	c := &InvocationMetaController{
 		executor:    executor,
 		runOnce:     &sync.Once{},
 		invocations: invocations,
-		system: ctrl.NewSystem(func(event *ctrl.Event) (ctrl ctrl.Controller, err error) {
+		system: ctrl.NewSystemWithQueue(func(event *ctrl.Event) (ctrl ctrl.Controller, err error) {
 			spanCtx, err := fes.ExtractTracingFromEventMetadata(event.Event.GetMetadata())
 			if err != nil {
 				logrus.Debugf("Could not extract span from event metadata: %v", err)
@@ -554,9 +826,9 @@ func NewInvocationMetaController(executor *executor.LocalExecutor, invocations *
 			if len(invocationID) == 0 {
 				return nil, fmt.Errorf("invocation ID missing in event: %v %v", event.Aggregate, event.Event.GetType())
 			}
-			return NewInvocationController(invocationID, executor, invocationAPI, taskAPI, scheduler,
-				stateStore, span, logrus.WithField("key", invocationID)), nil
-		}),
+			return NewInvocationController(invocationID, executor, invocationAPI, taskAPI, scheduler, limiter,
+				maxRuntime, stateStore, span, logrus.WithField("key", invocationID)), nil
+		}, newInvocationEvalQueue()),
 	}
 	c.sensors = []ctrl.Sensor{
 		NewInvocationNotificationSensor(invocations),
@@ -573,9 +845,76 @@ func NewInvocationMetaController(executor *executor.LocalExecutor, invocations *
 			return aggregate, invocation, nil
 		}, 100*time.Millisecond, time.Second),
 	}
+	if limiter != nil {
+		limiter.OnRelease(c.refresh)
+	}
 	return c
 }
 
+// newInvocationEvalQueue creates the evaluation queue of the invocation controllers, which orders the evaluations by
+// the priority of the invocations.
+func newInvocationEvalQueue() workqueue.Interface {
+	return workqueue.NewWeightedFairQueue("invocations", workqueue.DefaultMaxSize, true, scheduler.PriorityWeights,
+		func(item interface{}) string {
+			if invocation, ok := item.(*ctrl.Event).Updated.(*types.WorkflowInvocation); ok {
+				return scheduler.PriorityClassOf(invocation)
+			}
+			return scheduler.PriorityClass(types.WorkflowInvocationSpec_NORMAL)
+		})
+}
+
+// refresh submits an evaluation of each of the unfinished invocations.
+func (c *InvocationMetaController) refresh(invocationIDs []string) {
+	for _, invocationID := range invocationIDs {
+		invocation, err := c.invocations.GetInvocation(invocationID)
+		if err != nil {
+			logrus.Debugf("Failed to fetch invocation %s for refresh: %v", invocationID, err)
+			continue
+		}
+		if invocation.GetStatus().Finished() {
+			continue
+		}
+		aggregate := fes.Aggregate{
+			Type: types.TypeInvocation,
+			Id:   invocationID,
+		}
+		c.system.Submit(&ctrl.Event{
+			Old:     invocation,
+			Updated: invocation,
+			Event: &fes.Event{
+				Type:      EventRefresh,
+				Aggregate: &aggregate,
+				Timestamp: ptypes.TimestampNow(),
+			},
+			Aggregate: aggregate,
+		})
+	}
+}
+
+// Shard limits the controller to the invocations in the shards owned by this replica, which allows multiple replicas
+// to divide the invocations between them. It should be called before the controller is run.
+func (c *InvocationMetaController) Shard(shards *lease.Shards) {
+	c.shards = shards
+	c.system.SetFilter(shards.Owns)
+	shards.OnAcquire(c.takeOver)
+}
+
+// takeOver submits evaluations of the unfinished invocations in the acquired shards, such as the invocations of a
+// replica that stopped.
+func (c *InvocationMetaController) takeOver(shards []int) {
+	acquired := map[int]bool{}
+	for _, shard := range shards {
+		acquired[shard] = true
+	}
+	var invocationIDs []string
+	for _, aggregate := range c.invocations.List() {
+		if aggregate.Type == types.TypeInvocation && acquired[lease.ShardOf(aggregate.Id, c.shards.Count)] {
+			invocationIDs = append(invocationIDs, aggregate.Id)
+		}
+	}
+	c.refresh(invocationIDs)
+}
+
 func (c *InvocationMetaController) Run() {
 	c.runOnce.Do(func() {
 		go c.run()
@@ -586,6 +925,11 @@ func (c *InvocationMetaController) run() {
 	// Start the task executor
 	c.executor.Start()
 
+	// Acquire the shards of this replica
+	if c.shards != nil {
+		c.shards.Run()
+	}
+
 	// Start the sensors
 	for _, sensor := range c.sensors {
 		err := sensor.Start(c.system)
@@ -605,6 +949,10 @@ func (c *InvocationMetaController) Close() error {
 	for _, sensor := range c.sensors {
 		err = sensor.Close()
 	}
+	// Release the shards, so that the other replicas take over the invocations right away.
+	if c.shards != nil {
+		err = c.shards.Close()
+	}
 	return err
 }
 
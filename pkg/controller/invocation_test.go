@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/api/store"
+	"github.com/fission/fission-workflows/pkg/controller/executor"
+	"github.com/fission/fission-workflows/pkg/controller/expr"
+	"github.com/fission/fission-workflows/pkg/controller/lease"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/fes/cache"
+	"github.com/fission/fission-workflows/pkg/fnenv"
+	"github.com/fission/fission-workflows/pkg/fnenv/native"
+	"github.com/fission/fission-workflows/pkg/scheduler"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/fission/fission-workflows/pkg/util/labels"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/stretchr/testify/assert"
+)
+
+// blockingFunction is an internal function that signals that it started, and then blocks until it is released.
+type blockingFunction struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (fn *blockingFunction) Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+	fn.started <- struct{}{}
+	<-fn.release
+	return nil, nil
+}
+
+// failingLocker fails to acquire leases once it is failing, such as when the replica lost its connection to the
+// database that holds the leases.
+type failingLocker struct {
+	lease.Locker
+	failing int32
+}
+
+func (l *failingLocker) Acquire(key string, holder string, ttl time.Duration) (bool, error) {
+	if atomic.LoadInt32(&l.failing) > 0 {
+		return false, errors.New("locker unavailable")
+	}
+	return l.Locker.Acquire(key, holder, ttl)
+}
+
+// newShardedInvocationController creates the invocation controller of a replica, which shares the backend with the
+// other replicas and runs the internal functions.
+func newShardedInvocationController(backend *mem.Backend, shards *lease.Shards,
+	fns map[string]native.InternalFunction) (*InvocationMetaController, *store.Invocations) {
+	projector := projectors.NewWorkflowInvocation()
+	sub := backend.Subscribe(pubsub.SubscriptionOptions{
+		Buffer: 100,
+		LabelMatcher: labels.Or(
+			labels.In(fes.PubSubLabelAggregateType, types.TypeInvocation),
+			labels.In("parent.type", types.TypeInvocation)),
+	})
+	invocations := store.NewInvocationStore(cache.NewSubscribedCache(
+		cache.NewLoadingCache(cache.NewLRUCache(10), backend, projector), projector, sub))
+	runtimes := map[string]fnenv.Runtime{"internal": native.NewFunctionEnv(fns)}
+
+	// Poll the store rarely, so that the invocations are only picked up by notifications and acquired shards.
+	c := NewInvocationMetaController(executor.NewLocalExecutor(10, 100), invocations, api.NewInvocationAPI(backend),
+		api.NewTaskAPI(runtimes, backend, nil), scheduler.NewInvocationScheduler(scheduler.NewHorizonPolicy()), nil,
+		time.Minute, expr.NewStore(), time.Hour)
+	c.Shard(shards)
+	return c, invocations
+}
+
+// eventually checks if the condition holds within a few seconds.
+func eventually(condition func() bool) bool {
+	for timeout := time.After(5 * time.Second); ; {
+		if condition() {
+			return true
+		}
+		select {
+		case <-timeout:
+			return false
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestInvocationMetaController_Shard(t *testing.T) {
+	backend := mem.NewBackend()
+	memLocker := lease.NewMemLocker()
+	fn := &blockingFunction{started: make(chan struct{}, 1), release: make(chan struct{})}
+	fns := map[string]native.InternalFunction{"fn": fn}
+
+	// Replica a holds the only shard, but stops before evaluating the invocation.
+	shardsA := lease.NewShards(memLocker, lease.ShardsConfig{Name: "invocations", Holder: "a", Count: 1, TTL: time.Minute})
+	assert.NoError(t, shardsA.Sync())
+	a, _ := newShardedInvocationController(backend, shardsA, fns)
+
+	locker := &failingLocker{Locker: memLocker}
+	shardsB := lease.NewShards(locker, lease.ShardsConfig{Name: "invocations", Holder: "b", Count: 1,
+		TTL: 300 * time.Millisecond})
+	b, invocations := newShardedInvocationController(backend, shardsB, fns)
+	b.Run()
+	defer b.Close()
+
+	wf := types.NewWorkflow("wf")
+	wf.Spec.OutputTask = "task"
+	wf.Spec.Tasks = types.Tasks{"task": types.NewTaskSpec("fn")}
+	fnRef := types.NewFnRef("internal", "", "fn")
+	wf.Status.Status = types.WorkflowStatus_READY
+	wf.Status.AddTask("task", &types.Task{
+		Spec:   wf.Spec.Tasks["task"],
+		Status: &types.TaskStatus{Status: types.TaskStatus_READY, FnRef: &fnRef},
+	})
+	spec := types.NewWorkflowInvocationSpec(wf.ID(), time.Now().Add(time.Minute))
+	spec.Workflow = wf
+	invocationID, err := api.NewInvocationAPI(backend).Invoke(spec)
+	assert.NoError(t, err)
+
+	// b ignores the invocation, which is in the shard of a.
+	assert.True(t, eventually(func() bool {
+		_, err := invocations.GetInvocation(invocationID)
+		return err == nil
+	}))
+	select {
+	case <-fn.started:
+		t.Fatal("the invocation was evaluated by the replica that does not own its shard")
+	case <-time.After(time.Second):
+	}
+	_, ok := b.system.GetController(invocationID)
+	assert.False(t, ok)
+	assert.Empty(t, shardsB.Owned())
+
+	// Once a closes, b acquires its shard and picks up the invocation.
+	assert.NoError(t, a.Close())
+	select {
+	case <-fn.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the invocation was not taken over by the remaining replica")
+	}
+	assert.Equal(t, []int{0}, shardsB.Owned())
+	_, ok = b.system.GetController(invocationID)
+	assert.True(t, ok)
+
+	// Once the lease on the shard of b expires, b drops the controller of the invocation on its next event, leaving
+	// the invocation to the replica that acquires the shard.
+	atomic.StoreInt32(&locker.failing, 1)
+	assert.True(t, eventually(func() bool {
+		return len(shardsB.Owned()) == 0
+	}))
+	close(fn.release)
+	assert.True(t, eventually(func() bool {
+		_, ok := b.system.GetController(invocationID)
+		return !ok
+	}))
+	time.Sleep(500 * time.Millisecond)
+	invocation, err := invocations.GetInvocation(invocationID)
+	assert.NoError(t, err)
+	assert.False(t, invocation.GetStatus().Finished())
+}
@@ -0,0 +1,84 @@
+// Package lease provides time-bound locks on named resources, which allow multiple replicas of the workflow engine to
+// divide the work between them and to take over the work of replicas that have stopped.
+package lease
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// Locker is a lock service that grants leases on keys to holders. A lease expires after its TTL, unless the holder
+// renews it, so that the keys of a holder that has stopped become available to other holders.
+//
+// Implementations should be shared by all replicas, such as a database. The MemLocker only coordinates the holders
+// within a single process.
+type Locker interface {
+	// Acquire acquires the lease on the key for the holder, or renews the lease if the holder already has it. It
+	// returns false if the key is leased by another holder.
+	Acquire(key string, holder string, ttl time.Duration) (bool, error)
+
+	// Release releases the lease of the holder on the key, allowing other holders to acquire it right away. Releasing
+	// a key that is not leased by the holder has no effect.
+	Release(key string, holder string) error
+
+	// Holders returns the holders of the unexpired leases of which the key has the prefix, by key.
+	Holders(prefix string) (map[string]string, error)
+}
+
+type memLease struct {
+	holder    string
+	expiresAt time.Time
+}
+
+// MemLocker is an in-memory implementation of the Locker.
+type MemLocker struct {
+	leases map[string]memLease
+	mu     sync.Mutex
+
+	// Now returns the current time. It can be replaced to control the expiry of leases, such as in tests.
+	Now func() time.Time
+}
+
+func NewMemLocker() *MemLocker {
+	return &MemLocker{
+		leases: map[string]memLease{},
+		Now:    time.Now,
+	}
+}
+
+func (l *MemLocker) Acquire(key string, holder string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.Now()
+	if lease, ok := l.leases[key]; ok && lease.holder != holder && now.Before(lease.expiresAt) {
+		return false, nil
+	}
+	l.leases[key] = memLease{
+		holder:    holder,
+		expiresAt: now.Add(ttl),
+	}
+	return true, nil
+}
+
+func (l *MemLocker) Release(key string, holder string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lease, ok := l.leases[key]; ok && lease.holder == holder {
+		delete(l.leases, key)
+	}
+	return nil
+}
+
+func (l *MemLocker) Holders(prefix string) (map[string]string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.Now()
+	holders := map[string]string{}
+	for key, lease := range l.leases {
+		if strings.HasPrefix(key, prefix) && now.Before(lease.expiresAt) {
+			holders[key] = lease.holder
+		}
+	}
+	return holders, nil
+}
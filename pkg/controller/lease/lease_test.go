@@ -0,0 +1,54 @@
+package lease
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemLocker_Acquire(t *testing.T) {
+	now := time.Now()
+	locker := NewMemLocker()
+	locker.Now = func() time.Time {
+		return now
+	}
+
+	ok, err := locker.Acquire("key", "a", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// The lease can be renewed by its holder, but not acquired by others.
+	ok, err = locker.Acquire("key", "a", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = locker.Acquire("key", "b", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	// Once expired, the lease can be acquired by others.
+	now = now.Add(time.Minute)
+	holders, err := locker.Holders("")
+	assert.NoError(t, err)
+	assert.Empty(t, holders)
+	ok, err = locker.Acquire("key", "b", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestMemLocker_Release(t *testing.T) {
+	locker := NewMemLocker()
+	_, err := locker.Acquire("key", "a", time.Minute)
+	assert.NoError(t, err)
+
+	// Only the holder can release the lease.
+	assert.NoError(t, locker.Release("key", "b"))
+	holders, err := locker.Holders("k")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"key": "a"}, holders)
+
+	assert.NoError(t, locker.Release("key", "a"))
+	ok, err := locker.Acquire("key", "b", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
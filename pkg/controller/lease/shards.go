@@ -0,0 +1,284 @@
+package lease
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultShardCount = 16
+	DefaultTTL        = 15 * time.Second
+)
+
+// ShardsConfig configures how the keys of a resource are divided into shards.
+type ShardsConfig struct {
+	// Name is the name of the sharded resource, such as 'invocations'. It prefixes the keys of the leases.
+	Name string
+
+	// Holder identifies this replica. It should be unique among the replicas, such as the name of the pod.
+	Holder string
+
+	// Count is the number of shards. All replicas should use the same number of shards. (default: 16)
+	Count int
+
+	// TTL is the time after which the shards of a replica are taken over by others, if it did not renew the leases
+	// of its shards. The leases are renewed at a third of the TTL. (default: 15s)
+	TTL time.Duration
+}
+
+// Shards divides the keys of a resource over shards, and the shards over the replicas that share a Locker. Each
+// replica acquires the leases on its fair share of the shards and only processes the keys in the shards that it owns.
+//
+// Every replica holds a membership lease, which determines the number of replicas to divide the shards between.
+// When a replica joins, the others release their excess shards. When a replica stops, its leases expire, after which
+// the remaining replicas acquire its orphaned shards.
+type Shards struct {
+	ShardsConfig
+
+	// Now returns the current time. It can be replaced to control the expiry of the shards, such as in tests.
+	Now func() time.Time
+
+	locker    Locker
+	mu        sync.RWMutex
+	owned     map[int]struct{}
+	renewedAt time.Time
+	onAcquire func(shards []int)
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+func NewShards(locker Locker, cfg ShardsConfig) *Shards {
+	if cfg.Count <= 0 {
+		cfg.Count = DefaultShardCount
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultTTL
+	}
+	return &Shards{
+		ShardsConfig: cfg,
+		Now:          time.Now,
+		locker:       locker,
+		owned:        map[int]struct{}{},
+		done:         make(chan struct{}),
+	}
+}
+
+// ShardOf returns the shard of the key.
+func ShardOf(key string, count int) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(count))
+}
+
+// OnAcquire sets the listener that is called with the shards that this replica acquired, such as the orphaned shards
+// of a replica that stopped. This allows the keys in these shards to be picked up right away.
+func (s *Shards) OnAcquire(fn func(shards []int)) {
+	s.mu.Lock()
+	s.onAcquire = fn
+	s.mu.Unlock()
+}
+
+// Owns checks if the key belongs to one of the shards owned by this replica. Once the leases on the shards could have
+// expired without being renewed, this replica no longer owns any of them.
+func (s *Shards) Owns(key string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.expired() {
+		return false
+	}
+	_, ok := s.owned[ShardOf(key, s.Count)]
+	return ok
+}
+
+// Owned returns the shards owned by this replica in ascending order.
+func (s *Shards) Owned() []int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.expired() {
+		return []int{}
+	}
+	return s.sortedOwned()
+}
+
+// Run synchronizes the shards of this replica until the Shards are closed.
+func (s *Shards) Run() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.TTL / 3)
+		defer ticker.Stop()
+		for {
+			if err := s.Sync(); err != nil {
+				logrus.Warnf("Failed to synchronize the %s shards: %v", s.Name, err)
+			}
+			select {
+			case <-s.done:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Sync renews the membership of this replica and the leases on its shards, after which it releases or acquires shards
+// to end up with its fair share of the shards.
+func (s *Shards) Sync() error {
+	// The leases expire relative to the time that they were acquired at, which is at the latest when the sync starts.
+	start := s.Now()
+	owned, err := s.sync()
+	s.mu.Lock()
+	if err != nil {
+		// Without being able to renew the leases, the shards are bound to be taken over by other replicas.
+		if s.expired() && len(s.owned) > 0 {
+			logrus.Warnf("Lost the leases on the %s shards %v", s.Name, s.sortedOwned())
+			s.owned = map[int]struct{}{}
+		}
+		s.mu.Unlock()
+		return err
+	}
+	var acquired []int
+	for shard := range owned {
+		if _, ok := s.owned[shard]; !ok {
+			acquired = append(acquired, shard)
+		}
+	}
+	sort.Ints(acquired)
+	s.owned = owned
+	s.renewedAt = start
+	onAcquire := s.onAcquire
+	s.mu.Unlock()
+
+	if len(acquired) > 0 {
+		logrus.Infof("Acquired the %s shards %v (owning %d of %d)", s.Name, acquired, len(owned), s.Count)
+		if onAcquire != nil {
+			onAcquire(acquired)
+		}
+	}
+	return nil
+}
+
+func (s *Shards) sync() (map[int]struct{}, error) {
+	if _, err := s.locker.Acquire(s.memberKey(), s.Holder, s.TTL); err != nil {
+		return nil, err
+	}
+	members, err := s.locker.Holders(s.Name + "/members/")
+	if err != nil {
+		return nil, err
+	}
+	share := s.Count
+	if len(members) > 1 {
+		share = (s.Count + len(members) - 1) / len(members)
+	}
+	holders, err := s.locker.Holders(s.Name + "/shards/")
+	if err != nil {
+		return nil, err
+	}
+
+	// Renew the leases on the shards that this replica holds.
+	owned := map[int]struct{}{}
+	for key, holder := range holders {
+		shard, ok := s.parseShardKey(key)
+		if !ok || holder != s.Holder {
+			continue
+		}
+		if ok, err := s.locker.Acquire(key, s.Holder, s.TTL); err != nil {
+			return nil, err
+		} else if ok {
+			owned[shard] = struct{}{}
+		}
+	}
+
+	// Release the excess shards, which allows new replicas to acquire them.
+	for shard := s.Count - 1; shard >= 0 && len(owned) > share; shard-- {
+		if _, ok := owned[shard]; !ok {
+			continue
+		}
+		// Stop processing the keys of the shard before releasing it, since another replica can acquire it right away.
+		s.mu.Lock()
+		delete(s.owned, shard)
+		s.mu.Unlock()
+		if err := s.locker.Release(s.shardKey(shard), s.Holder); err != nil {
+			return nil, err
+		}
+		delete(owned, shard)
+	}
+
+	// Acquire the shards that are not held by any replica. Each replica starts at a different shard to reduce the
+	// contention between replicas that start at the same time.
+	offset := ShardOf(s.Holder, s.Count)
+	for i := 0; i < s.Count && len(owned) < share; i++ {
+		shard := (offset + i) % s.Count
+		key := s.shardKey(shard)
+		if _, ok := holders[key]; ok {
+			continue
+		}
+		if ok, err := s.locker.Acquire(key, s.Holder, s.TTL); err != nil {
+			return nil, err
+		} else if ok {
+			owned[shard] = struct{}{}
+		}
+	}
+	return owned, nil
+}
+
+// Close stops the synchronization and releases the leases of this replica, so that the other replicas can take over
+// its shards right away.
+func (s *Shards) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.wg.Wait()
+
+		s.mu.Lock()
+		owned := s.sortedOwned()
+		s.owned = map[int]struct{}{}
+		s.mu.Unlock()
+		for _, shard := range owned {
+			if rerr := s.locker.Release(s.shardKey(shard), s.Holder); rerr != nil {
+				err = rerr
+			}
+		}
+		if rerr := s.locker.Release(s.memberKey(), s.Holder); rerr != nil {
+			err = rerr
+		}
+	})
+	return err
+}
+
+// expired checks if the leases on the owned shards could have expired, because they were not renewed within the TTL.
+func (s *Shards) expired() bool {
+	return !s.Now().Before(s.renewedAt.Add(s.TTL))
+}
+
+func (s *Shards) sortedOwned() []int {
+	owned := make([]int, 0, len(s.owned))
+	for shard := range s.owned {
+		owned = append(owned, shard)
+	}
+	sort.Ints(owned)
+	return owned
+}
+
+func (s *Shards) memberKey() string {
+	return fmt.Sprintf("%s/members/%s", s.Name, s.Holder)
+}
+
+func (s *Shards) shardKey(shard int) string {
+	return fmt.Sprintf("%s/shards/%d", s.Name, shard)
+}
+
+func (s *Shards) parseShardKey(key string) (int, bool) {
+	shard, err := strconv.Atoi(strings.TrimPrefix(key, s.Name+"/shards/"))
+	if err != nil || shard < 0 || shard >= s.Count {
+		return 0, false
+	}
+	return shard, true
+}
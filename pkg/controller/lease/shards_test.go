@@ -0,0 +1,138 @@
+package lease
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newShards(locker Locker, holder string) *Shards {
+	return NewShards(locker, ShardsConfig{
+		Name:   "test",
+		Holder: holder,
+		TTL:    time.Minute,
+	})
+}
+
+func TestShards_Divide(t *testing.T) {
+	locker := NewMemLocker()
+	a := newShards(locker, "a")
+	b := newShards(locker, "b")
+
+	assert.NoError(t, a.Sync())
+	assert.Len(t, a.Owned(), DefaultShardCount)
+
+	// Once b joins, a releases its excess shards to b.
+	assert.NoError(t, b.Sync())
+	assert.NoError(t, a.Sync())
+	assert.NoError(t, b.Sync())
+	assert.Len(t, a.Owned(), DefaultShardCount/2)
+	assert.Len(t, b.Owned(), DefaultShardCount/2)
+
+	// Every key is owned by exactly one of the replicas.
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		assert.True(t, a.Owns(key) != b.Owns(key), key)
+	}
+}
+
+// releaseLocker calls onRelease before releasing a lease.
+type releaseLocker struct {
+	Locker
+	onRelease func(key string)
+}
+
+func (l *releaseLocker) Release(key string, holder string) error {
+	l.onRelease(key)
+	return l.Locker.Release(key, holder)
+}
+
+func TestShards_ReleaseExcess(t *testing.T) {
+	locker := &releaseLocker{Locker: NewMemLocker()}
+	a := newShards(locker, "a")
+	b := newShards(locker, "b")
+	assert.NoError(t, a.Sync())
+	assert.NoError(t, b.Sync())
+
+	// a no longer owns the shards that it releases to b by the time that b could acquire them.
+	var released int
+	locker.onRelease = func(key string) {
+		shard, ok := a.parseShardKey(key)
+		assert.True(t, ok, key)
+		assert.NotContains(t, a.Owned(), shard)
+		released++
+	}
+	assert.NoError(t, a.Sync())
+	assert.Equal(t, DefaultShardCount/2, released)
+}
+
+func TestShards_TakeOverOnClose(t *testing.T) {
+	locker := NewMemLocker()
+	a := newShards(locker, "a")
+	b := newShards(locker, "b")
+	for _, s := range []*Shards{a, b, a, b} {
+		assert.NoError(t, s.Sync())
+	}
+	var acquired []int
+	a.OnAcquire(func(shards []int) {
+		acquired = append(acquired, shards...)
+	})
+
+	orphaned := b.Owned()
+	assert.NoError(t, b.Close())
+	assert.Empty(t, b.Owned())
+	assert.NoError(t, a.Sync())
+	assert.Len(t, a.Owned(), DefaultShardCount)
+	assert.Equal(t, orphaned, acquired)
+}
+
+func TestShards_TakeOverOnExpiry(t *testing.T) {
+	now := time.Now()
+	locker := NewMemLocker()
+	locker.Now = func() time.Time {
+		return now
+	}
+	a := newShards(locker, "a")
+	b := newShards(locker, "b")
+	for _, s := range []*Shards{a, b, a, b} {
+		assert.NoError(t, s.Sync())
+	}
+
+	// b stops without releasing its leases; a takes over once the leases of b expire.
+	now = now.Add(a.TTL / 2)
+	assert.NoError(t, a.Sync())
+	assert.Len(t, a.Owned(), DefaultShardCount/2)
+	now = now.Add(a.TTL / 2)
+	assert.NoError(t, a.Sync())
+	assert.Len(t, a.Owned(), DefaultShardCount)
+}
+
+func TestShards_ExpireWithoutRenewal(t *testing.T) {
+	now := time.Now()
+	locker := NewMemLocker()
+	locker.Now = func() time.Time {
+		return now
+	}
+	a := newShards(locker, "a")
+	a.Now = locker.Now
+	assert.NoError(t, a.Sync())
+	assert.True(t, a.Owns("key"))
+
+	// Once the leases could have been taken over by another replica, a no longer owns the keys, even before it syncs.
+	now = now.Add(a.TTL)
+	assert.False(t, a.Owns("key"))
+	assert.Empty(t, a.Owned())
+
+	assert.NoError(t, a.Sync())
+	assert.True(t, a.Owns("key"))
+}
+
+func TestShardOf(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		shard := ShardOf(fmt.Sprintf("key-%d", i), 4)
+		assert.True(t, shard >= 0 && shard < 4)
+	}
+	assert.Equal(t, ShardOf("key", 16), ShardOf("key", 16))
+}
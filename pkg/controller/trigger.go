@@ -0,0 +1,325 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/store"
+	"github.com/fission/fission-workflows/pkg/controller/ctrl"
+	"github.com/fission/fission-workflows/pkg/controller/executor"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/fission/fission-workflows/pkg/util/labels"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/robfig/cron"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	fireTask = "fire"
+)
+
+// TriggerController is the controller for firing a single trigger according to its schedule.
+//
+// If the trigger missed one or more scheduled times, for example because the controller was not running, it only
+// fires once for the most recent of the missed times.
+//
+// The firing is claimed with the generation of the trigger that the schedule was based on. This ensures that a
+// trigger fires once per scheduled time, even if it is evaluated on an outdated state or by multiple replicas.
+type TriggerController struct {
+	triggerAPI    *api.Trigger
+	invocationAPI *api.Invocation
+	workflows     *store.Workflows
+	executor      *executor.LocalExecutor
+	triggerID     string
+	schedule      cron.Schedule
+}
+
+func NewTriggerController(triggerAPI *api.Trigger, invocationAPI *api.Invocation, workflows *store.Workflows,
+	executor *executor.LocalExecutor, triggerID string) *TriggerController {
+	return &TriggerController{
+		triggerAPI:    triggerAPI,
+		invocationAPI: invocationAPI,
+		workflows:     workflows,
+		executor:      executor,
+		triggerID:     triggerID,
+	}
+}
+
+func (c *TriggerController) Eval(ctx context.Context, processValue *ctrl.Event) ctrl.Result {
+	trigger, ok := processValue.Updated.(*types.Trigger)
+	if !ok {
+		return ctrl.Err{Err: fmt.Errorf("entity expected %T, but was %T", &types.Trigger{}, processValue.Updated)}
+	}
+
+	// ensure that it is the correct trigger
+	if trigger.ID() != c.triggerID {
+		return ctrl.Err{Err: fmt.Errorf("trigger ID expected %v, but was %v", c.triggerID, trigger.ID())}
+	}
+
+	switch trigger.GetStatus().GetStatus() {
+	case types.TriggerStatus_DELETED:
+		return ctrl.Done{Msg: "trigger has been deleted"}
+	case types.TriggerStatus_ACTIVE:
+		// nop
+	default:
+		return ctrl.Err{Err: errors.New("unknown trigger state")}
+	}
+
+	// Do not evaluate as long as the trigger is still firing
+	if c.executor.GetGroupTasks(trigger.ID()) > 0 {
+		return ctrl.Err{Err: errors.New("still firing trigger")}
+	}
+
+	if c.schedule == nil {
+		schedule, err := cron.ParseStandard(trigger.GetSpec().GetCron())
+		if err != nil {
+			return ctrl.Done{Msg: fmt.Sprintf("trigger has an invalid schedule: %v", err)}
+		}
+		c.schedule = schedule
+	}
+
+	// Determine the scheduled time that is due, starting from the last time that the trigger fired.
+	last, err := ptypes.Timestamp(trigger.GetStatus().GetLastFiredAt())
+	if err != nil {
+		last, err = ptypes.Timestamp(trigger.GetMetadata().GetCreatedAt())
+		if err != nil {
+			return ctrl.Err{Err: errors.New("failed to read lastFiredAt and createdAt")}
+		}
+	}
+	now := time.Now()
+	scheduledAt := c.schedule.Next(last)
+	if scheduledAt.IsZero() || scheduledAt.After(now) {
+		return ctrl.Success{Msg: fmt.Sprintf("trigger is scheduled to fire at %v", scheduledAt)}
+	}
+	for next := c.schedule.Next(scheduledAt); !next.IsZero() && !next.After(now); next = c.schedule.Next(next) {
+		scheduledAt = next
+	}
+
+	c.executor.Submit(&executor.Task{
+		TaskID:  trigger.ID() + "." + fireTask,
+		GroupID: trigger.ID(),
+		Apply: func() error {
+			return c.fire(trigger, scheduledAt)
+		},
+	})
+	return ctrl.Success{Msg: fmt.Sprintf("firing trigger for %v", scheduledAt)}
+}
+
+// fire claims the firing of the trigger for the scheduled time, and only if that succeeds invokes the workflow of the
+// trigger. The result is recorded regardless of whether the invocation could be started, since the trigger does not
+// fire again for the same scheduled time.
+func (c *TriggerController) fire(trigger *types.Trigger, scheduledAt time.Time) error {
+	spec := trigger.GetSpec()
+	err := c.triggerAPI.Fire(trigger.ID(), scheduledAt, api.ExpectGeneration(trigger.GetMetadata().GetGeneration()))
+	if fes.ErrGenerationConflict.Is(err) {
+		log.Debugf("Trigger %s has already fired for %v or was modified; skipping.", trigger.ID(), scheduledAt)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	invocationID, err := c.invoke(trigger)
+	if err != nil {
+		log.Warnf("Trigger %s failed to invoke workflow %s: %v", trigger.ID(), spec.GetWorkflowId(), err)
+	} else {
+		log.Infof("Trigger %s invoked workflow %s: %s", trigger.ID(), spec.GetWorkflowId(), invocationID)
+	}
+	return c.triggerAPI.Invoked(trigger.ID(), invocationID, err)
+}
+
+func (c *TriggerController) invoke(trigger *types.Trigger) (string, error) {
+	spec := trigger.GetSpec()
+	wf, err := c.workflows.GetWorkflowByRef(spec.GetWorkflowId())
+	if err != nil && !fes.ErrEntityNotFound.Is(err) {
+		return "", err
+	}
+	if wf == nil {
+		return "", fmt.Errorf("workflow %s not found", spec.GetWorkflowId())
+	}
+
+	// The inputs are copied to avoid the invocation from modifying the spec of the trigger.
+	inputs := make(map[string]*typedvalues.TypedValue, len(spec.GetInputs()))
+	for k, v := range spec.GetInputs() {
+		inputs[k] = v
+	}
+	invocationSpec := &types.WorkflowInvocationSpec{
+		WorkflowId: wf.ID(),
+		Workflow:   wf,
+		Inputs:     inputs,
+		TriggerId:  trigger.ID(),
+		Labels:     labels.Merge(trigger.GetMetadata().GetLabels()),
+	}
+	if spec.GetTimeout() != nil {
+		timeout, err := ptypes.Duration(spec.GetTimeout())
+		if err != nil {
+			return "", err
+		}
+		deadline, err := ptypes.TimestampProto(time.Now().Add(timeout))
+		if err != nil {
+			return "", err
+		}
+		invocationSpec.Deadline = deadline
+	}
+	return c.invocationAPI.Invoke(invocationSpec)
+}
+
+// TriggerMetaController is the component responsible for the full integration of the triggers reconciliation loop.
+//
+// It starts the sensors that route the trigger events to the control system, manages the trigger controllers, and
+// provides the executor pool to which the controllers submit the firing of triggers.
+type TriggerMetaController struct {
+	system   *ctrl.System
+	executor *executor.LocalExecutor
+	run      *sync.Once
+	sensors  []ctrl.Sensor
+}
+
+func NewTriggerMetaController(triggerAPI *api.Trigger, invocationAPI *api.Invocation, triggers *store.Triggers,
+	workflows *store.Workflows, executor *executor.LocalExecutor,
+	storePollInterval time.Duration) *TriggerMetaController {
+
+	return &TriggerMetaController{
+		executor: executor,
+		run:      &sync.Once{},
+		sensors: []ctrl.Sensor{
+			NewTriggerNotificationSensor(triggers),
+			NewTriggerStorePollSensor(triggers, storePollInterval),
+		},
+		system: ctrl.NewSystem(func(event *ctrl.Event) (ctrl ctrl.Controller, err error) {
+			return NewTriggerController(triggerAPI, invocationAPI, workflows, executor, event.Aggregate.Id), nil
+		}),
+	}
+}
+
+func (c *TriggerMetaController) Run() {
+	c.run.Do(func() {
+		// Start the task executor
+		c.executor.Start()
+
+		// Start the sensors
+		for _, sensor := range c.sensors {
+			err := sensor.Start(c.system)
+			if err != nil {
+				panic(err)
+			}
+		}
+
+		// Run control system
+		c.system.Run()
+	})
+}
+
+func (c *TriggerMetaController) Close() error {
+	err := c.executor.Close()
+	err = c.system.Close()
+	for _, sensor := range c.sensors {
+		err = sensor.Close()
+	}
+	return err
+}
+
+// TriggerNotificationSensor watches the trigger store notifications for trigger events.
+type TriggerNotificationSensor struct {
+	triggers *store.Triggers
+	done     func()
+	closeC   <-chan struct{}
+}
+
+func NewTriggerNotificationSensor(triggers *store.Triggers) *TriggerNotificationSensor {
+	ctx, done := context.WithCancel(context.Background())
+	return &TriggerNotificationSensor{
+		triggers: triggers,
+		done:     done,
+		closeC:   ctx.Done(),
+	}
+}
+
+func (s *TriggerNotificationSensor) Start(evalQueue ctrl.EvalQueue) error {
+	go s.Run(evalQueue)
+	return nil
+}
+
+func (s *TriggerNotificationSensor) Run(evalQueue ctrl.EvalQueue) {
+	sub := s.triggers.GetTriggerUpdates()
+	if sub == nil {
+		log.Warn("Trigger store does not support pubsub.")
+		return
+	}
+	log.Debug("Listening for trigger events")
+	for {
+		select {
+		case msg := <-sub.Ch:
+			notification, err := sub.ToNotification(msg)
+			if err != nil {
+				log.Warnf("Failed to convert pubsub message to notification: %v", err)
+			}
+			evalQueue.Submit(notification)
+		case <-s.closeC:
+			err := sub.Close()
+			if err != nil {
+				log.Error(err)
+			}
+			log.Info("Notification listener stopped.")
+			return
+		}
+	}
+}
+
+func (s *TriggerNotificationSensor) Close() error {
+	s.done()
+	return nil
+}
+
+// TriggerStorePollSensor polls the triggers store on a set interval. Besides recovering from missed notifications,
+// the polling is what causes the triggers to be evaluated when their next scheduled time has passed. The interval
+// therefore determines how punctual the triggers fire.
+type TriggerStorePollSensor struct {
+	*ctrl.PollSensor
+	triggers *store.Triggers
+}
+
+func NewTriggerStorePollSensor(triggers *store.Triggers, interval time.Duration) *TriggerStorePollSensor {
+	s := &TriggerStorePollSensor{
+		triggers: triggers,
+	}
+	s.PollSensor = ctrl.NewPollSensor(interval, s.Poll)
+	return s
+}
+
+func (s *TriggerStorePollSensor) Poll(evalQueue ctrl.EvalQueue) {
+	for _, aggregate := range s.triggers.List() {
+		// Ignore non-trigger entities in trigger store
+		if aggregate.Type != types.TypeTrigger {
+			log.Warnf("Non-trigger entity in triggers store: %v", aggregate)
+			continue
+		}
+
+		trigger, err := s.triggers.GetTrigger(aggregate.GetId())
+		if err != nil {
+			log.Warnf("Could not retrieve entity from triggers store: %v", aggregate)
+			continue
+		}
+
+		if !trigger.GetStatus().Active() {
+			continue
+		}
+
+		evalQueue.Submit(&ctrl.Event{
+			Old:     trigger,
+			Updated: trigger,
+			Event: &fes.Event{
+				Type:      EventRefresh,
+				Aggregate: &aggregate,
+				Timestamp: ptypes.TimestampNow(),
+			},
+			Aggregate: aggregate,
+		})
+	}
+}
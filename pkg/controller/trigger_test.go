@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/api/store"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend/mem"
+	"github.com/fission/fission-workflows/pkg/fes/cache"
+	"github.com/fission/fission-workflows/pkg/fnenv/native/builtin"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriggerController_FireOnce(t *testing.T) {
+	backend := mem.NewBackend()
+	workflows := store.NewWorkflowsStore(cache.NewLoadingCache(cache.NewLRUCache(10), backend,
+		projectors.NewWorkflow()))
+	triggerCache := cache.NewLoadingCache(cache.NewLRUCache(10), backend, projectors.NewTrigger())
+	triggers := store.NewTriggersStore(triggerCache)
+	triggerAPI := api.NewTriggerAPI(backend)
+	invocationAPI := api.NewInvocationAPI(backend)
+
+	workflowID, err := api.NewWorkflowAPI(backend, nil).Create(&types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task",
+		Tasks: map[string]*types.TaskSpec{
+			"task": {FunctionRef: builtin.Noop},
+		},
+	})
+	assert.NoError(t, err)
+	triggerID, err := triggerAPI.Create(&types.TriggerSpec{WorkflowId: workflowID, Cron: "@every 1s"})
+	assert.NoError(t, err)
+	trigger, err := triggers.GetTrigger(triggerID)
+	assert.NoError(t, err)
+
+	// Controllers that fire the same state of the trigger, such as those of multiple replicas, start one invocation.
+	scheduledAt := time.Now()
+	for i := 0; i < 2; i++ {
+		c := NewTriggerController(triggerAPI, invocationAPI, workflows, nil, triggerID)
+		assert.NoError(t, c.fire(trigger, scheduledAt))
+	}
+	invocations, err := backend.List(func(aggregate fes.Aggregate) bool {
+		return aggregate.Type == types.TypeInvocation
+	})
+	assert.NoError(t, err)
+
+	triggerCache.Invalidate(projectors.NewTriggerAggregate(triggerID))
+	fired, err := triggers.GetTrigger(triggerID)
+	assert.NoError(t, err)
+	if assert.Len(t, invocations, 1) {
+		assert.Equal(t, invocations[0].Id, fired.GetStatus().GetLastInvocationId())
+	}
+	assert.Nil(t, fired.GetStatus().GetError())
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/fission/fission-workflows/pkg/controller/executor"
 	"github.com/fission/fission-workflows/pkg/fes"
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/validate"
 	"github.com/golang/protobuf/ptypes"
 	log "github.com/sirupsen/logrus"
 )
@@ -55,7 +56,13 @@ func (c *WorkflowController) Eval(ctx context.Context, processValue *ctrl.Event)
 		// Stop tracking this workflow because it has reached a terminal state
 		return ctrl.Done{}
 	case types.WorkflowStatus_FAILED:
+		// An invalid workflow will fail to parse regardless of the number of attempts.
+		if err := validate.WorkflowSpec(workflow.GetSpec()); err != nil {
+			return ctrl.Done{Msg: "workflow is invalid"}
+		}
+
 		// The previous parsing has failed. We retry the parsing but with a increasing backoff.
+		c.errorCount++
 		backoff := time.Duration(c.errorCount) * time.Second
 		log.Infof("Backing off for %v before trying to parse workflow again", backoff)
 		c.executor.SubmitAfter(&executor.Task{
@@ -244,6 +251,7 @@ func (s *WorkflowStorePollSensor) Poll(evalQueue ctrl.EvalQueue) {
 				Aggregate: &aggregate,
 				Timestamp: ptypes.TimestampNow(),
 			},
+			Aggregate: aggregate,
 		})
 	}
 }
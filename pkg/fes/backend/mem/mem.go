@@ -102,10 +102,11 @@ func NewBackend(cfgs ...Config) *Backend {
 	return b
 }
 
-func (b *Backend) Append(event *fes.Event) error {
+func (b *Backend) Append(event *fes.Event, opts ...fes.AppendOption) error {
 	if err := fes.ValidateEvent(event); err != nil {
 		return err
 	}
+	cfg := fes.ParseAppendOptions(opts)
 	key := *event.Aggregate
 	if event.Parent != nil {
 		key = *event.Parent
@@ -131,6 +132,10 @@ func (b *Backend) Append(event *fes.Event) error {
 		return ErrEventLimitExceeded.WithAggregate(&key)
 	}
 
+	if err := cfg.CheckGeneration(event, fes.Generation(*event.Aggregate, events)); err != nil {
+		return err
+	}
+
 	if !fromStore {
 		b.promote(key)
 	}
@@ -167,6 +172,19 @@ func (b *Backend) Get(key fes.Aggregate) ([]*fes.Event, error) {
 	return events, nil
 }
 
+func (b *Backend) GetTail(key fes.Aggregate, offset int) ([]*fes.Event, error) {
+	if err := fes.ValidateAggregate(&key); err != nil {
+		return nil, err
+	}
+	b.storeLock.RLock()
+	events, _, _ := b.get(key)
+	b.storeLock.RUnlock()
+	if offset > len(events) {
+		return nil, fes.ErrOffsetOutOfRange.WithAggregate(&key)
+	}
+	return events[offset:], nil
+}
+
 func (b *Backend) Len() int {
 	return int(atomic.LoadInt32(b.entries))
 }
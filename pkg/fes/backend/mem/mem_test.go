@@ -175,6 +175,33 @@ func TestBackend_Append(t *testing.T) {
 	assert.Equal(t, len(mem.mustGet(fes.Aggregate{Type: "type", Id: "id"})), 2)
 }
 
+func TestBackend_AppendExpectedGeneration(t *testing.T) {
+	mem := setupBackend()
+	parent := fes.Aggregate{Type: "type", Id: "id"}
+	child := fes.Aggregate{Type: "child", Id: "child"}
+	newChildEvent := func(data string) *fes.Event {
+		event := newEvent(child, []byte(data))
+		event.Parent = &parent
+		return event
+	}
+
+	assert.NoError(t, mem.Append(newEvent(parent, []byte("event 1")), fes.ExpectGeneration(0)))
+	assert.NoError(t, mem.Append(newChildEvent("child 1"), fes.ExpectGeneration(0)))
+	assert.NoError(t, mem.Append(newChildEvent("child 2"), fes.ExpectGeneration(1)))
+
+	// The child events do not count towards the generation of the parent
+	assert.NoError(t, mem.Append(newEvent(parent, []byte("event 2")), fes.ExpectGeneration(1)))
+
+	// A writer with an outdated view of the aggregate is rejected
+	err := mem.Append(newChildEvent("child 2 (conflicting)"), fes.ExpectGeneration(1))
+	assert.True(t, fes.ErrGenerationConflict.Is(err))
+	assert.Len(t, mem.mustGet(parent), 4)
+
+	// Without an expected generation, events are appended regardless
+	assert.NoError(t, mem.Append(newChildEvent("child 3")))
+	assert.Len(t, mem.mustGet(parent), 5)
+}
+
 func TestBackend_GetMultiple(t *testing.T) {
 	mem := setupBackend()
 	key := fes.Aggregate{Type: "type", Id: "id"}
@@ -194,6 +221,31 @@ func TestBackend_GetMultiple(t *testing.T) {
 	assert.EqualValues(t, events, getEvents)
 }
 
+func TestBackend_GetTail(t *testing.T) {
+	mem := setupBackend()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	events := []*fes.Event{
+		newEvent(key, []byte("event 1")),
+		newEvent(key, []byte("event 2")),
+		newEvent(key, []byte("event 3")),
+	}
+	for k := range events {
+		err := mem.Append(events[k])
+		assert.NoError(t, err)
+	}
+
+	tail, err := mem.GetTail(key, 1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, events[1:], tail)
+
+	tail, err = mem.GetTail(key, 3)
+	assert.NoError(t, err)
+	assert.Empty(t, tail)
+
+	_, err = mem.GetTail(key, 4)
+	assert.True(t, fes.ErrOffsetOutOfRange.Is(err))
+}
+
 func TestBackend_GetNonexistent(t *testing.T) {
 	mem := setupBackend()
 	key := fes.Aggregate{Type: "type", Id: "id"}
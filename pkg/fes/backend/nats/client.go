@@ -11,6 +11,7 @@ import (
 	"github.com/fission/fission-workflows/pkg/util/pubsub"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/hashicorp/golang-lru"
 	"github.com/nats-io/go-nats"
 	"github.com/nats-io/go-nats-streaming"
 	"github.com/prometheus/client_golang/prometheus"
@@ -21,6 +22,9 @@ const (
 	defaultClient     = "fes"
 	defaultCluster    = "fes-cluster"
 	reconnectInterval = 10 * time.Second
+
+	// generationCacheSize is the number of aggregates of which the generation is cached for guarded appends.
+	generationCacheSize = 10000
 )
 
 var (
@@ -37,6 +41,11 @@ func init() {
 }
 
 // EventStore is a NATS-based implementation of the EventStore interface.
+//
+// The EventStore assumes that it is the only writer to the NATS cluster. NATS Streaming does not support conditional
+// publishes, so expected generations are checked against the generations of the aggregates as known by this client.
+// Multiple engines sharing a NATS cluster do not detect each other's conflicting appends; use the SQL backend for
+// those.
 type EventStore struct {
 	pubsub.Publisher
 	conn            *WildcardConn
@@ -44,6 +53,8 @@ type EventStore struct {
 	Config          Config
 	closeFn         func()
 	initConnChecker sync.Once
+	appendLock      sync.Mutex
+	generations     *lru.Cache // map[fes.Aggregate]int64
 }
 
 type Config struct {
@@ -54,11 +65,16 @@ type Config struct {
 }
 
 func NewEventStore(conn *WildcardConn, cfg Config) *EventStore {
+	generations, err := lru.New(generationCacheSize)
+	if err != nil {
+		panic(err)
+	}
 	return &EventStore{
-		Publisher: pubsub.NewPublisher(),
-		conn:      conn,
-		subs:      map[fes.Aggregate]stan.Subscription{},
-		Config:    cfg,
+		Publisher:   pubsub.NewPublisher(),
+		conn:        conn,
+		subs:        map[fes.Aggregate]stan.Subscription{},
+		Config:      cfg,
+		generations: generations,
 	}
 }
 
@@ -222,25 +238,54 @@ func (es *EventStore) Close() error {
 }
 
 // Append publishes (and persists) an event on the NATS message queue
-func (es *EventStore) Append(event *fes.Event) error {
+//
+// Appends are serialized, so that an expected generation can be checked against the cached generation of the
+// aggregate right before publishing. The generation is only read from the subject if it is not cached yet.
+func (es *EventStore) Append(event *fes.Event, opts ...fes.AppendOption) error {
 	if err := fes.ValidateEvent(event); err != nil {
 		return err
 	}
+	cfg := fes.ParseAppendOptions(opts)
 
 	// TODO make generic / configurable whether to fold event into parent's Subject
-	subject := toSubject(*event.Aggregate)
+	key := *event.Aggregate
 	if event.Parent != nil {
-		subject = toSubject(*event.Parent)
+		key = *event.Parent
 	}
+	subject := toSubject(key)
 	data, err := proto.Marshal(event)
 	if err != nil {
 		return err
 	}
 
+	es.appendLock.Lock()
+	defer es.appendLock.Unlock()
+	var generation int64
+	cached, ok := es.generations.Get(*event.Aggregate)
+	if ok {
+		generation = cached.(int64)
+	}
+	if cfg.ExpectedGeneration != fes.AnyGeneration {
+		if !ok {
+			generation, err = es.generation(key, *event.Aggregate)
+			if err != nil {
+				return err
+			}
+			ok = true
+		}
+		if err := cfg.CheckGeneration(event, generation); err != nil {
+			return err
+		}
+	}
+
 	err = es.conn.Publish(subject, data)
 	if err != nil {
 		return err
 	}
+	// The generation is only tracked once it is known, as the aggregate could have events from before this client.
+	if ok {
+		es.generations.Add(*event.Aggregate, generation+1)
+	}
 
 	logrus.WithFields(logrus.Fields{
 		"aggregate":    event.Aggregate.Format(),
@@ -252,6 +297,22 @@ func (es *EventStore) Append(event *fes.Event) error {
 	return nil
 }
 
+// generation returns the generation of the aggregate within the subject of the key. Fetching the events of a
+// subject that does not exist only fails after a timeout, so the subject is looked up first.
+func (es *EventStore) generation(key fes.Aggregate, aggregate fes.Aggregate) (int64, error) {
+	subjects, err := es.conn.List(func(a fes.Aggregate) bool {
+		return a == key
+	})
+	if err != nil || len(subjects) == 0 {
+		return 0, err
+	}
+	events, err := es.Get(key)
+	if err != nil {
+		return 0, err
+	}
+	return fes.Generation(aggregate, events), nil
+}
+
 // Get returns all events related to a specific aggregate
 func (es *EventStore) Get(aggregate fes.Aggregate) ([]*fes.Event, error) {
 	if err := fes.ValidateAggregate(&aggregate); err != nil {
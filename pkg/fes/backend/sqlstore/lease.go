@@ -0,0 +1,55 @@
+package sqlstore
+
+import (
+	"strings"
+	"time"
+)
+
+// Acquire acquires or renews the lease on the key for the holder, unless the key is leased by another holder.
+//
+// The expiry of the leases is based on the clocks of the instances, so these should be reasonably synchronized.
+func (b *Backend) Acquire(key string, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	expiresAt := now.Add(ttl).UnixNano()
+	res, err := b.db.Exec(b.dialect.rebind(`UPDATE fes_leases SET holder = ?, expires_at = ?
+		WHERE lease_key = ? AND (holder = ? OR expires_at <= ?)`), holder, expiresAt, key, holder, now.UnixNano())
+	if err != nil {
+		return false, err
+	}
+	if n, err := res.RowsAffected(); err != nil || n > 0 {
+		return n > 0, err
+	}
+	res, err = b.db.Exec(b.dialect.rebind(`INSERT INTO fes_leases (lease_key, holder, expires_at) VALUES (?, ?, ?)
+		ON CONFLICT DO NOTHING`), key, holder, expiresAt)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (b *Backend) Release(key string, holder string) error {
+	_, err := b.db.Exec(b.dialect.rebind(`DELETE FROM fes_leases WHERE lease_key = ? AND holder = ?`), key, holder)
+	return err
+}
+
+func (b *Backend) Holders(prefix string) (map[string]string, error) {
+	rows, err := b.db.Query(b.dialect.rebind(`SELECT lease_key, holder FROM fes_leases
+		WHERE lease_key LIKE ? AND expires_at > ?`), prefix+"%", time.Now().UnixNano())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	holders := map[string]string{}
+	for rows.Next() {
+		var key, holder string
+		if err := rows.Scan(&key, &holder); err != nil {
+			return nil, err
+		}
+		// Underscores in the prefix match any character in a LIKE pattern.
+		if strings.HasPrefix(key, prefix) {
+			holders[key] = holder
+		}
+	}
+	return holders, rows.Err()
+}
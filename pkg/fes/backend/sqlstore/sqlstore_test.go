@@ -0,0 +1,374 @@
+package sqlstore
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/util/labels"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEvent(a fes.Aggregate, data []byte) *fes.Event {
+	event, err := fes.NewEvent(a, &wrappers.BytesValue{
+		Value: data,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return event
+}
+
+// setupBackend creates a backend on top of a SQLite database in a temporary directory. The returned config can be
+// used to open other backends on the same database.
+func setupBackend(t *testing.T, cfg Config) (*Backend, func()) {
+	dir, err := ioutil.TempDir("", "fes-sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Driver = DriverSQLite
+	cfg.DataSource = fmt.Sprintf("file:%s?_busy_timeout=5000&_txlock=immediate", filepath.Join(dir, "events.db"))
+	b, err := NewBackend(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, func() {
+		b.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func open(t *testing.T, cfg Config) *Backend {
+	b, err := NewBackend(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func assertEvents(t *testing.T, expected []*fes.Event, actual []*fes.Event) {
+	if !assert.Len(t, actual, len(expected)) {
+		return
+	}
+	for i := range expected {
+		assert.True(t, proto.Equal(expected[i], actual[i]), "expected %v, but was %v", expected[i], actual[i])
+	}
+}
+
+func TestBackend_Append(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+
+	event := newEvent(fes.Aggregate{Type: "type", Id: "id"}, []byte("event 1"))
+	err := b.Append(event)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", event.Id)
+
+	// Test if invalid event is rejected by the event store
+	event2 := newEvent(fes.Aggregate{Type: "type", Id: "id"}, []byte("event 2"))
+	event2.Aggregate = &fes.Aggregate{}
+	err = b.Append(event2)
+	assert.Equal(t, err.(fes.EventStoreErr).S, fes.ErrInvalidEvent.Error())
+
+	// Event under a child aggregate is folded into the parent
+	event3 := newEvent(fes.Aggregate{Type: "child", Id: "child"}, []byte("event 3"))
+	event3.Parent = &fes.Aggregate{Type: "type", Id: "id"}
+	err = b.Append(event3)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", event3.Id)
+
+	events, err := b.Get(fes.Aggregate{Type: "type", Id: "id"})
+	assert.NoError(t, err)
+	assertEvents(t, []*fes.Event{event, event3}, events)
+
+	keys, err := b.List(nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []fes.Aggregate{{Type: "type", Id: "id"}}, keys)
+}
+
+func TestBackend_AppendExpectedGeneration(t *testing.T) {
+	b1, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+	b2 := open(t, b1.Config)
+	defer b2.Close()
+	parent := fes.Aggregate{Type: "type", Id: "id"}
+	child := fes.Aggregate{Type: "child", Id: "child"}
+	newChildEvent := func(data string) *fes.Event {
+		event := newEvent(child, []byte(data))
+		event.Parent = &parent
+		return event
+	}
+
+	assert.NoError(t, b1.Append(newEvent(parent, []byte("event 1")), fes.ExpectGeneration(0)))
+	assert.NoError(t, b1.Append(newChildEvent("child 1"), fes.ExpectGeneration(0)))
+
+	// Both instances observed the first child event, but only the first append based on it succeeds.
+	assert.NoError(t, b2.Append(newChildEvent("child 2"), fes.ExpectGeneration(1)))
+	conflicting := newChildEvent("child 2 (conflicting)")
+	err := b1.Append(conflicting, fes.ExpectGeneration(1))
+	assert.True(t, fes.ErrGenerationConflict.Is(err))
+	assert.Empty(t, conflicting.Id)
+
+	assert.NoError(t, b1.Append(newEvent(parent, []byte("event 2")), fes.ExpectGeneration(1)))
+	events, err := b1.Get(parent)
+	assert.NoError(t, err)
+	assert.Len(t, events, 4)
+}
+
+func TestBackend_GetTail(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	events := []*fes.Event{
+		newEvent(key, []byte("event 1")),
+		newEvent(key, []byte("event 2")),
+	}
+	for k := range events {
+		assert.NoError(t, b.Append(events[k]))
+	}
+
+	tail, err := b.GetTail(key, 1)
+	assert.NoError(t, err)
+	assertEvents(t, events[1:], tail)
+
+	tail, err = b.GetTail(key, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, tail)
+
+	_, err = b.GetTail(key, 3)
+	assert.True(t, fes.ErrOffsetOutOfRange.Is(err))
+}
+
+func TestBackend_GetNonexistent(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+	events, err := b.Get(fes.Aggregate{Type: "type", Id: "id"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*fes.Event{}, events)
+}
+
+func TestBackend_List(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+	for i := 0; i < 4; i++ {
+		key := fes.Aggregate{Type: fmt.Sprintf("type-%d", i%2), Id: fmt.Sprintf("%d", i)}
+		assert.NoError(t, b.Append(newEvent(key, []byte("event"))))
+	}
+
+	keys, err := b.List(func(a fes.Aggregate) bool {
+		return a.Id != "0"
+	})
+	assert.NoError(t, err)
+	assert.Len(t, keys, 3)
+
+	keys, err = b.ListType("type-1")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []fes.Aggregate{{Type: "type-1", Id: "1"}, {Type: "type-1", Id: "3"}}, keys)
+}
+
+func TestBackend_Subscribe(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	sub := b.Subscribe(pubsub.SubscriptionOptions{
+		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, key.Type),
+	})
+
+	events := []*fes.Event{
+		newEvent(key, []byte("event 1")),
+		newEvent(key, []byte("event 2")),
+		newEvent(key, []byte("event 3")),
+	}
+	for k := range events {
+		err := b.Append(events[k])
+		assert.NoError(t, err)
+	}
+	b.Unsubscribe(sub)
+
+	var receivedEvents []*fes.Event
+	for msg := range sub.Ch {
+		event, ok := msg.(*fes.Event)
+		assert.True(t, ok)
+		receivedEvents = append(receivedEvents, event)
+	}
+	assert.EqualValues(t, events, receivedEvents)
+}
+
+func TestBackend_Reopen(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	events := []*fes.Event{
+		newEvent(key, []byte("event 1")),
+		newEvent(key, []byte("event 2")),
+	}
+	for k := range events {
+		assert.NoError(t, b.Append(events[k]))
+	}
+	assert.NoError(t, b.Close())
+	assert.Error(t, b.Append(newEvent(key, []byte("closed"))))
+
+	b = open(t, b.Config)
+	defer b.Close()
+	stored, err := b.Get(key)
+	assert.NoError(t, err)
+	assertEvents(t, events, stored)
+
+	// New events should continue the existing generation
+	event3 := newEvent(key, []byte("event 3"))
+	assert.NoError(t, b.Append(event3))
+	assert.Equal(t, "3", event3.Id)
+}
+
+func TestBackend_ConcurrentInstances(t *testing.T) {
+	b1, cleanup := setupBackend(t, Config{PollInterval: 10 * time.Millisecond})
+	defer cleanup()
+	b2 := open(t, b1.Config)
+	defer b2.Close()
+	sub := b1.Subscribe(pubsub.SubscriptionOptions{Buffer: 100})
+
+	// Both instances append to the same aggregate concurrently.
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	n := 10
+	wg := sync.WaitGroup{}
+	for _, b := range []*Backend{b1, b2} {
+		wg.Add(1)
+		go func(b *Backend) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				assert.NoError(t, b.Append(newEvent(key, []byte(fmt.Sprintf("event %d", i)))))
+			}
+		}(b)
+	}
+	wg.Wait()
+
+	events, err := b1.Get(key)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2*n)
+	for i, event := range events {
+		assert.Equal(t, fmt.Sprintf("%d", i+1), event.Id)
+	}
+
+	// The subscribers of the first instance receive both its own events and the events polled from the second.
+	received := map[string]bool{}
+	timeout := time.After(5 * time.Second)
+	for len(received) < 2*n {
+		select {
+		case msg := <-sub.Ch:
+			received[msg.(*fes.Event).Id] = true
+		case <-timeout:
+			t.Fatalf("received %d of the %d events", len(received), 2*n)
+		}
+	}
+	select {
+	case msg := <-sub.Ch:
+		t.Errorf("unexpected duplicate event: %v", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBackend_PollOutOfOrder(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{PollInterval: -1})
+	defer cleanup()
+	sub := b.Subscribe(pubsub.SubscriptionOptions{Buffer: 10})
+
+	// Another instance commits the event with the highest sequence number first, as can happen in PostgreSQL.
+	insert := func(seq int64, event *fes.Event) {
+		data, err := proto.Marshal(event)
+		assert.NoError(t, err)
+		_, err = b.db.Exec(`INSERT INTO fes_events (seq, aggregate_type, aggregate_id, generation, event_type, origin,
+			data) VALUES (?, ?, ?, ?, ?, 'other', ?)`, seq, event.Aggregate.Type, event.Aggregate.Id, seq, event.Type,
+			data)
+		assert.NoError(t, err)
+	}
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	event1 := newEvent(key, []byte("event 1"))
+	event2 := newEvent(key, []byte("event 2"))
+	insert(2, event2)
+	assert.NoError(t, b.poll())
+	insert(1, event1)
+	assert.NoError(t, b.poll())
+	assert.NoError(t, b.poll())
+
+	b.Unsubscribe(sub)
+	var received []*fes.Event
+	for msg := range sub.Ch {
+		received = append(received, msg.(*fes.Event))
+	}
+	assertEvents(t, []*fes.Event{event2, event1}, received)
+}
+
+func TestPollWindow(t *testing.T) {
+	now := time.Now()
+	w := newPollWindow(10)
+	w.see(11, now)
+	w.see(14, now)
+	assert.Equal(t, []int64{12, 13}, w.unseen(now))
+	w.see(12, now)
+	assert.Equal(t, []int64{13}, w.unseen(now))
+
+	// Sequence numbers that remain unseen, such as those of rolled back transactions, are eventually dropped.
+	assert.Empty(t, w.unseen(now.Add(pollGapTimeout+time.Second)))
+
+	// Only the most recent unseen sequence numbers are kept.
+	w.see(14+2*maxPollGaps, now)
+	assert.Len(t, w.unseen(now), maxPollGaps)
+	assert.EqualValues(t, 14+maxPollGaps, w.unseen(now)[0])
+}
+
+func TestNewBackend_Invalid(t *testing.T) {
+	_, err := NewBackend(Config{Driver: "oracle", DataSource: "db"})
+	assert.Error(t, err)
+	_, err = NewBackend(Config{Driver: DriverSQLite})
+	assert.Error(t, err)
+}
+
+func TestDialect_Rebind(t *testing.T) {
+	query := "SELECT * FROM fes_events WHERE aggregate_type = ? AND aggregate_id = ?"
+	assert.Equal(t, query, dialects[DriverSQLite].rebind(query))
+	assert.Equal(t, "SELECT * FROM fes_events WHERE aggregate_type = $1 AND aggregate_id = $2",
+		dialects[DriverPostgres].rebind(query))
+}
+
+func TestBackend_Leases(t *testing.T) {
+	b1, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+	b2 := open(t, b1.Config)
+	defer b2.Close()
+
+	ok, err := b1.Acquire("shards/1", "a", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = b2.Acquire("shards/1", "b", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = b2.Acquire("shards/2", "b", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	holders, err := b2.Holders("shards/")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"shards/1": "a", "shards/2": "b"}, holders)
+
+	// Released or expired leases can be acquired by other holders.
+	assert.NoError(t, b1.Release("shards/1", "a"))
+	ok, err = b2.Acquire("shards/1", "b", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = b1.Acquire("shards/3", "a", time.Nanosecond)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	time.Sleep(time.Millisecond)
+	ok, err = b2.Acquire("shards/3", "b", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
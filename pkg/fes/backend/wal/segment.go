@@ -0,0 +1,284 @@
+package wal
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+)
+
+// Both segments and snapshots consist of a sequence of records. Each record consists of a header containing the
+// length and CRC-32 (Castagnoli) checksum of the payload, followed by the payload: a protobuf-encoded fes.Event.
+const (
+	segmentExt  = ".seg"
+	snapshotExt = ".snap"
+	tmpExt      = ".tmp"
+	headerSize  = 8
+	maxRecord   = 64 * 1024 * 1024
+)
+
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// tailErr indicates that a file ends with an incomplete or corrupted record, starting at offset.
+type tailErr struct {
+	offset int64
+	cause  error
+}
+
+func (err *tailErr) Error() string {
+	return fmt.Sprintf("invalid record at offset %d: %v", err.offset, err.cause)
+}
+
+type segment struct {
+	id   uint64
+	file *os.File
+	size int64
+}
+
+func createSegment(dir string, id uint64) (*segment, error) {
+	f, err := os.OpenFile(segmentPath(dir, id), os.O_WRONLY|os.O_CREATE|os.O_EXCL|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := syncDir(dir); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &segment{
+		id:   id,
+		file: f,
+	}, nil
+}
+
+func openSegment(dir string, id uint64) (*segment, error) {
+	f, err := os.OpenFile(segmentPath(dir, id), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &segment{
+		id:   id,
+		file: f,
+		size: info.Size(),
+	}, nil
+}
+
+// write appends a record to the segment. The record is written in a single write to avoid interleaving partial
+// records in case of failures.
+func (s *segment) write(data []byte, sync bool) error {
+	record := encodeRecord(data)
+	n, err := s.file.Write(record)
+	if err != nil {
+		// Roll back the partial write to keep the segment consistent.
+		if n > 0 {
+			if terr := s.file.Truncate(s.size); terr != nil {
+				logrus.Errorf("Failed to roll back partial write to segment %d: %v", s.id, terr)
+			}
+		}
+		return err
+	}
+	if sync {
+		if err := s.file.Sync(); err != nil {
+			return err
+		}
+	}
+	s.size += int64(n)
+	return nil
+}
+
+func (s *segment) close() error {
+	if err := s.file.Sync(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
+
+func encodeRecord(data []byte) []byte {
+	record := make([]byte, headerSize+len(data))
+	binary.BigEndian.PutUint32(record[0:4], uint32(len(data)))
+	binary.BigEndian.PutUint32(record[4:8], crc32.Checksum(data, crcTable))
+	copy(record[headerSize:], data)
+	return record
+}
+
+// readRecords reads all records in the file, calling fn for each decoded event. If the file ends with an incomplete
+// or corrupted record, a *tailErr is returned.
+func readRecords(path string, fn func(event *fes.Event) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	header := make([]byte, headerSize)
+	var offset int64
+	for {
+		_, err := io.ReadFull(r, header)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return &tailErr{offset: offset, cause: err}
+		}
+		size := binary.BigEndian.Uint32(header[0:4])
+		if size > maxRecord {
+			return &tailErr{offset: offset, cause: fmt.Errorf("record size %d exceeds limit", size)}
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return &tailErr{offset: offset, cause: err}
+		}
+		if crc32.Checksum(data, crcTable) != binary.BigEndian.Uint32(header[4:8]) {
+			return &tailErr{offset: offset, cause: errors.New("checksum mismatch")}
+		}
+		event := &fes.Event{}
+		if err := proto.Unmarshal(data, event); err != nil {
+			return &tailErr{offset: offset, cause: err}
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+		offset += int64(headerSize + len(data))
+	}
+}
+
+// writeSnapshot atomically writes all events to the snapshot with the given id. The events are written in the order
+// of their sequence numbers, which makes a snapshot indistinguishable from a compacted log.
+func writeSnapshot(dir string, id uint64, store map[fes.Aggregate][]*fes.Event) error {
+	var events []*fes.Event
+	for _, stream := range store {
+		events = append(events, stream...)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return parseSeq(events[i].Id) < parseSeq(events[j].Id)
+	})
+
+	path := snapshotPath(dir, id)
+	f, err := os.OpenFile(path+tmpExt, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	for _, event := range events {
+		data, err := proto.Marshal(event)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		if _, err := w.Write(encodeRecord(data)); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(path+tmpExt, path); err != nil {
+		return err
+	}
+	return syncDir(dir)
+}
+
+func removeSnapshotsBefore(dir string, id uint64) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		logrus.Warnf("Failed to list snapshots in %s: %v", dir, err)
+		return
+	}
+	for _, f := range files {
+		snapshotID, ok := parseFileID(f.Name(), snapshotExt)
+		if ok && snapshotID < id {
+			if err := os.Remove(filepath.Join(dir, f.Name())); err != nil {
+				logrus.Warnf("Failed to remove old snapshot %s: %v", f.Name(), err)
+			}
+		}
+	}
+}
+
+// scanDir returns the id of the most recent snapshot (0 if there is none) and the ids of all segments in the
+// directory in ascending order. Leftovers of interrupted snapshots are removed.
+func scanDir(dir string) (snapshotID uint64, segmentIDs []uint64, err error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return 0, nil, err
+	}
+	for _, f := range files {
+		name := f.Name()
+		if strings.HasSuffix(name, tmpExt) {
+			if err := os.Remove(filepath.Join(dir, name)); err != nil {
+				logrus.Warnf("Failed to remove incomplete snapshot %s: %v", name, err)
+			}
+			continue
+		}
+		if id, ok := parseFileID(name, segmentExt); ok {
+			segmentIDs = append(segmentIDs, id)
+		}
+		if id, ok := parseFileID(name, snapshotExt); ok && id > snapshotID {
+			snapshotID = id
+		}
+	}
+	sort.Slice(segmentIDs, func(i, j int) bool {
+		return segmentIDs[i] < segmentIDs[j]
+	})
+	return snapshotID, segmentIDs, nil
+}
+
+func segmentPath(dir string, id uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%020d%s", id, segmentExt))
+}
+
+func snapshotPath(dir string, id uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%020d%s", id, snapshotExt))
+}
+
+func parseFileID(name string, ext string) (uint64, bool) {
+	if !strings.HasSuffix(name, ext) {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(strings.TrimSuffix(name, ext), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+func parseSeq(id string) uint64 {
+	seq, _ := strconv.ParseUint(id, 10, 64)
+	return seq
+}
+
+// syncDir ensures that the creation, removal or renaming of files in the directory is persisted.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
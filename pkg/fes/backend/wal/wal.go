@@ -0,0 +1,372 @@
+// package wal contains a durable, embedded implementation of the fes backend using a write-ahead log on disk.
+//
+// Events are appended to a log of size-bounded segment files. Periodically the sealed segments are compacted into a
+// snapshot, after which they are removed. On startup the backend restores its state from the most recent snapshot and
+// the segments written after it. This allows single-node deployments to survive restarts without the need for an
+// external event store, such as NATS Streaming.
+//
+// All events are kept in memory to serve reads; the files on disk are only read when the backend is (re)opened.
+package wal
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fes/backend"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultMaxSegmentSize   = 64 * 1024 * 1024 // 64 MB
+	DefaultSnapshotInterval = 4
+)
+
+var (
+	ErrBackendClosed = &fes.EventStoreErr{
+		S: "backend is closed",
+	}
+
+	ErrCorruptedLog = &fes.EventStoreErr{
+		S: "log is corrupted",
+	}
+
+	segmentsActive = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "fes",
+		Subsystem: "wal",
+		Name:      "segments",
+		Help:      "Number of log segments on disk that are not yet part of a snapshot.",
+	})
+
+	snapshotsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "fes",
+		Subsystem: "wal",
+		Name:      "snapshots_total",
+		Help:      "Count of snapshots taken of the log by result.",
+	}, []string{"result"})
+)
+
+func init() {
+	prometheus.MustRegister(segmentsActive, snapshotsTotal)
+}
+
+// Config contains the user-configurable options of the write-ahead log backend.
+type Config struct {
+	// Dir is the directory in which the segments and snapshots are stored. It is created if it does not exist yet.
+	Dir string
+
+	// MaxSegmentSize is the size in bytes after which the active segment is sealed and a new segment is started.
+	// If set to 0, DefaultMaxSegmentSize will be used.
+	MaxSegmentSize int64
+
+	// SnapshotInterval is the number of sealed segments after which the log is compacted into a snapshot.
+	// If set to 0, DefaultSnapshotInterval will be used. A negative value disables snapshotting.
+	SnapshotInterval int
+
+	// NoSync disables syncing the active segment to disk after each append. This improves the throughput at the cost
+	// of losing the most recent events in case of a crash.
+	NoSync bool
+}
+
+// Backend is a file-based, fes-compatible backend. Events are persisted to an append-only log before they are
+// published to subscribers.
+type Backend struct {
+	pubsub.Publisher
+	Config
+	store     map[fes.Aggregate][]*fes.Event
+	storeLock sync.RWMutex
+	seq       uint64
+	active    *segment
+	sealed    []uint64 // ids of the sealed segments that have not been compacted yet
+	closed    bool
+}
+
+// NewBackend opens the log in the configured directory, restoring any state persisted by a previous run.
+func NewBackend(cfg Config) (*Backend, error) {
+	if len(cfg.Dir) == 0 {
+		return nil, errors.New("no directory provided for the write-ahead log")
+	}
+	if cfg.MaxSegmentSize <= 0 {
+		cfg.MaxSegmentSize = DefaultMaxSegmentSize
+	}
+	if cfg.SnapshotInterval == 0 {
+		cfg.SnapshotInterval = DefaultSnapshotInterval
+	}
+	if err := os.MkdirAll(cfg.Dir, 0755); err != nil {
+		return nil, err
+	}
+
+	b := &Backend{
+		Publisher: pubsub.NewPublisher(),
+		Config:    cfg,
+		store:     map[fes.Aggregate][]*fes.Event{},
+	}
+	if err := b.recover(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *Backend) Append(event *fes.Event, opts ...fes.AppendOption) error {
+	if err := fes.ValidateEvent(event); err != nil {
+		return err
+	}
+	cfg := fes.ParseAppendOptions(opts)
+	key := *event.Aggregate
+	if event.Parent != nil {
+		key = *event.Parent
+	}
+
+	b.storeLock.Lock()
+	defer b.storeLock.Unlock()
+	if b.closed {
+		return ErrBackendClosed.WithEvent(event)
+	}
+	if err := cfg.CheckGeneration(event, fes.Generation(*event.Aggregate, b.store[key])); err != nil {
+		return err
+	}
+
+	// Events are assigned their sequence number in the log as ID, similar to the NATS backend.
+	event.Id = strconv.FormatUint(b.seq+1, 10)
+	data, err := proto.Marshal(event)
+	if err != nil {
+		event.Id = ""
+		return fes.ErrInvalidEvent.WithEvent(event).WithError(err)
+	}
+	if err := b.active.write(data, !b.NoSync); err != nil {
+		event.Id = ""
+		return err
+	}
+	b.seq++
+	b.store[key] = append(b.store[key], event)
+	logrus.Infof("Event appended: %s - %v", event.Aggregate.Format(), event.Type)
+
+	if b.active.size >= b.MaxSegmentSize {
+		b.rotate()
+	}
+	err = b.Publish(event)
+
+	// Record the time it took for the event to be propagated from publisher to subscriber.
+	ts, _ := ptypes.Timestamp(event.Timestamp)
+	backend.EventDelay.Observe(float64(time.Now().Sub(ts).Nanoseconds()))
+	backend.EventsAppended.WithLabelValues(event.Type).Inc()
+	return err
+}
+
+func (b *Backend) Get(key fes.Aggregate) ([]*fes.Event, error) {
+	if err := fes.ValidateAggregate(&key); err != nil {
+		return nil, err
+	}
+	b.storeLock.RLock()
+	events, ok := b.store[key]
+	b.storeLock.RUnlock()
+	if !ok {
+		events = []*fes.Event{}
+	}
+	return events, nil
+}
+
+func (b *Backend) GetTail(key fes.Aggregate, offset int) ([]*fes.Event, error) {
+	if err := fes.ValidateAggregate(&key); err != nil {
+		return nil, err
+	}
+	b.storeLock.RLock()
+	events := b.store[key]
+	b.storeLock.RUnlock()
+	if offset > len(events) {
+		return nil, fes.ErrOffsetOutOfRange.WithAggregate(&key)
+	}
+	return events[offset:], nil
+}
+
+func (b *Backend) List(matcher fes.AggregateMatcher) ([]fes.Aggregate, error) {
+	var results []fes.Aggregate
+	b.storeLock.RLock()
+	for key := range b.store {
+		if matcher == nil || matcher(key) {
+			results = append(results, key)
+		}
+	}
+	b.storeLock.RUnlock()
+	return results, nil
+}
+
+// Close syncs and closes the active segment, after which no events can be appended anymore.
+func (b *Backend) Close() error {
+	b.storeLock.Lock()
+	defer b.storeLock.Unlock()
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+	err := b.active.close()
+	if perr := b.Publisher.Close(); err == nil {
+		err = perr
+	}
+	return err
+}
+
+// Snapshot compacts all sealed segments into a snapshot. Although snapshots are taken automatically based on the
+// SnapshotInterval, this function allows users to force a snapshot, for example before a backup.
+func (b *Backend) Snapshot() error {
+	b.storeLock.Lock()
+	defer b.storeLock.Unlock()
+	if b.closed {
+		return ErrBackendClosed
+	}
+	// Seal the active segment to include its events in the snapshot.
+	if b.active.size > 0 {
+		if err := b.seal(); err != nil {
+			return err
+		}
+	}
+	if len(b.sealed) == 0 {
+		return nil
+	}
+	return b.snapshot()
+}
+
+// rotate seals the active segment and triggers a snapshot once enough segments have been sealed. Failures are not
+// returned, because the event that triggered the rotation has already been persisted.
+func (b *Backend) rotate() {
+	if err := b.seal(); err != nil {
+		logrus.Errorf("Failed to rotate segment %d, continuing with the current segment: %v", b.active.id, err)
+		return
+	}
+	if b.SnapshotInterval > 0 && len(b.sealed) >= b.SnapshotInterval {
+		if err := b.snapshot(); err != nil {
+			logrus.Errorf("Failed to snapshot the log: %v", err)
+		}
+	}
+}
+
+func (b *Backend) seal() error {
+	next, err := createSegment(b.Dir, b.active.id+1)
+	if err != nil {
+		return err
+	}
+	if err := b.active.close(); err != nil {
+		logrus.Warnf("Failed to close sealed segment %d: %v", b.active.id, err)
+	}
+	b.sealed = append(b.sealed, b.active.id)
+	b.active = next
+	segmentsActive.Inc()
+	return nil
+}
+
+// snapshot writes the events of all sealed segments to a new snapshot and removes the compacted segments.
+//
+// Note: the snapshot is based on the in-memory state, so it also contains the events of the active segment.
+// This is harmless; events that are already part of the snapshot are skipped when replaying the active segment.
+func (b *Backend) snapshot() error {
+	last := b.sealed[len(b.sealed)-1]
+	start := time.Now()
+	err := writeSnapshot(b.Dir, last, b.store)
+	if err != nil {
+		snapshotsTotal.WithLabelValues("failed").Inc()
+		return err
+	}
+	snapshotsTotal.WithLabelValues("succeeded").Inc()
+	for _, id := range b.sealed {
+		if err := os.Remove(segmentPath(b.Dir, id)); err != nil {
+			logrus.Warnf("Failed to remove compacted segment %d: %v", id, err)
+		}
+	}
+	segmentsActive.Sub(float64(len(b.sealed)))
+	b.sealed = nil
+	removeSnapshotsBefore(b.Dir, last)
+	logrus.Infof("Snapshot of log up to segment %d created in %v", last, time.Now().Sub(start))
+	return nil
+}
+
+// recover restores the state of the backend from the snapshot and segments in the directory.
+func (b *Backend) recover() error {
+	snapshotID, segmentIDs, err := scanDir(b.Dir)
+	if err != nil {
+		return err
+	}
+
+	if snapshotID > 0 {
+		err := readRecords(snapshotPath(b.Dir, snapshotID), func(event *fes.Event) error {
+			b.restore(event)
+			return nil
+		})
+		if err != nil {
+			return ErrCorruptedLog.WithError(err)
+		}
+		removeSnapshotsBefore(b.Dir, snapshotID)
+	}
+
+	var active []uint64
+	for _, id := range segmentIDs {
+		if id <= snapshotID {
+			// Left behind by a snapshot that was interrupted before it could clean up.
+			if err := os.Remove(segmentPath(b.Dir, id)); err != nil {
+				logrus.Warnf("Failed to remove compacted segment %d: %v", id, err)
+			}
+			continue
+		}
+		active = append(active, id)
+	}
+
+	for i, id := range active {
+		err := readRecords(segmentPath(b.Dir, id), func(event *fes.Event) error {
+			b.restore(event)
+			return nil
+		})
+		if err == nil {
+			continue
+		}
+		tail, ok := err.(*tailErr)
+		if !ok || i != len(active)-1 {
+			return ErrCorruptedLog.WithError(err)
+		}
+		// A partially written record at the end of the last segment is the result of a crash during an append.
+		// That event was never acknowledged, so it is safe to discard it.
+		logrus.Warnf("Truncating incomplete record at offset %d of segment %d: %v", tail.offset, id, tail.cause)
+		if err := os.Truncate(segmentPath(b.Dir, id), tail.offset); err != nil {
+			return err
+		}
+	}
+
+	if len(active) > 0 {
+		last := active[len(active)-1]
+		b.sealed = active[:len(active)-1]
+		b.active, err = openSegment(b.Dir, last)
+	} else {
+		b.active, err = createSegment(b.Dir, snapshotID+1)
+	}
+	if err != nil {
+		return err
+	}
+	segmentsActive.Set(float64(len(b.sealed) + 1))
+
+	logrus.WithFields(logrus.Fields{
+		"dir":      b.Dir,
+		"snapshot": snapshotID,
+		"segments": len(b.sealed) + 1,
+		"events":   b.seq,
+	}).Info("Restored event store from write-ahead log")
+	return nil
+}
+
+// restore adds a persisted event to the in-memory state, ignoring events that have been restored already.
+func (b *Backend) restore(event *fes.Event) {
+	seq := parseSeq(event.Id)
+	if seq <= b.seq {
+		return
+	}
+	b.seq = seq
+	key := *event.Aggregate
+	if event.Parent != nil {
+		key = *event.Parent
+	}
+	b.store[key] = append(b.store[key], event)
+}
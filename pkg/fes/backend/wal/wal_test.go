@@ -0,0 +1,290 @@
+package wal
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/util/labels"
+	"github.com/fission/fission-workflows/pkg/util/pubsub"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/stretchr/testify/assert"
+)
+
+func newEvent(a fes.Aggregate, data []byte) *fes.Event {
+	event, err := fes.NewEvent(a, &wrappers.BytesValue{
+		Value: data,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return event
+}
+
+func setupBackend(t *testing.T, cfg Config) (*Backend, func()) {
+	dir, err := ioutil.TempDir("", "fes-wal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Dir = dir
+	b, err := NewBackend(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b, func() {
+		b.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func reopen(t *testing.T, b *Backend) *Backend {
+	assert.NoError(t, b.Close())
+	reopened, err := NewBackend(b.Config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reopened
+}
+
+func BenchmarkRoundtripNoSync(b *testing.B) {
+	dir, err := ioutil.TempDir("", "fes-wal")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	backend, err := NewBackend(Config{Dir: dir, NoSync: true})
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer backend.Close()
+	sub := backend.Subscribe()
+
+	// Generate test data
+	events := make([]*fes.Event, b.N)
+	for i := 0; i < b.N; i++ {
+		key := fes.Aggregate{Type: "type", Id: fmt.Sprintf("%d", i%100)}
+		events[i] = newEvent(key, []byte(fmt.Sprintf("event-%d", i)))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := backend.Append(events[i])
+		if err != nil {
+			panic(err)
+		}
+		<-sub.Ch
+	}
+}
+
+func TestBackend_Append(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+
+	event := newEvent(fes.Aggregate{Type: "type", Id: "id"}, []byte("event 1"))
+	err := b.Append(event)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", event.Id)
+
+	// Test if invalid event is rejected by the event store
+	event2 := newEvent(fes.Aggregate{Type: "type", Id: "id"}, []byte("event 2"))
+	event2.Aggregate = &fes.Aggregate{}
+	err = b.Append(event2)
+	assert.Equal(t, err.(fes.EventStoreErr).S, fes.ErrInvalidEvent.Error())
+
+	// Event under a child aggregate is folded into the parent
+	event3 := newEvent(fes.Aggregate{Type: "child", Id: "child"}, []byte("event 3"))
+	event3.Parent = &fes.Aggregate{Type: "type", Id: "id"}
+	err = b.Append(event3)
+	assert.NoError(t, err)
+
+	events, err := b.Get(fes.Aggregate{Type: "type", Id: "id"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*fes.Event{event, event3}, events)
+
+	keys, err := b.List(nil)
+	assert.NoError(t, err)
+	assert.EqualValues(t, []fes.Aggregate{{Type: "type", Id: "id"}}, keys)
+}
+
+func TestBackend_AppendExpectedGeneration(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+
+	assert.NoError(t, b.Append(newEvent(key, []byte("event 1")), fes.ExpectGeneration(0)))
+	conflicting := newEvent(key, []byte("conflicting"))
+	err := b.Append(conflicting, fes.ExpectGeneration(0))
+	assert.True(t, fes.ErrGenerationConflict.Is(err))
+	assert.Empty(t, conflicting.Id)
+	assert.NoError(t, b.Append(newEvent(key, []byte("event 2")), fes.ExpectGeneration(1)))
+
+	events, err := b.Get(key)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+}
+
+func TestBackend_GetTail(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	events := []*fes.Event{
+		newEvent(key, []byte("event 1")),
+		newEvent(key, []byte("event 2")),
+	}
+	for k := range events {
+		assert.NoError(t, b.Append(events[k]))
+	}
+
+	tail, err := b.GetTail(key, 1)
+	assert.NoError(t, err)
+	assert.EqualValues(t, events[1:], tail)
+
+	_, err = b.GetTail(key, 3)
+	assert.True(t, fes.ErrOffsetOutOfRange.Is(err))
+}
+
+func TestBackend_GetNonexistent(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+	events, err := b.Get(fes.Aggregate{Type: "type", Id: "id"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*fes.Event{}, events)
+}
+
+func TestBackend_Subscribe(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	sub := b.Subscribe(pubsub.SubscriptionOptions{
+		LabelMatcher: labels.In(fes.PubSubLabelAggregateType, key.Type),
+	})
+
+	events := []*fes.Event{
+		newEvent(key, []byte("event 1")),
+		newEvent(key, []byte("event 2")),
+		newEvent(key, []byte("event 3")),
+	}
+	for k := range events {
+		err := b.Append(events[k])
+		assert.NoError(t, err)
+	}
+	b.Unsubscribe(sub)
+
+	var receivedEvents []*fes.Event
+	for msg := range sub.Ch {
+		event, ok := msg.(*fes.Event)
+		assert.True(t, ok)
+		receivedEvents = append(receivedEvents, event)
+	}
+	assert.EqualValues(t, events, receivedEvents)
+}
+
+func TestBackend_Recover(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	events := []*fes.Event{
+		newEvent(key, []byte("event 1")),
+		newEvent(key, []byte("event 2")),
+	}
+	for k := range events {
+		assert.NoError(t, b.Append(events[k]))
+	}
+
+	b = reopen(t, b)
+	assertEvents(t, b, key, events)
+
+	// New events should continue the existing sequence
+	event3 := newEvent(key, []byte("event 3"))
+	assert.NoError(t, b.Append(event3))
+	assert.Equal(t, "3", event3.Id)
+}
+
+func TestBackend_RecoverTruncatesIncompleteRecord(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+	key := fes.Aggregate{Type: "type", Id: "id"}
+	event := newEvent(key, []byte("event 1"))
+	assert.NoError(t, b.Append(event))
+
+	// Simulate a crash halfway through writing a record
+	path := segmentPath(b.Dir, b.active.id)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	assert.NoError(t, err)
+	data, err := proto.Marshal(newEvent(key, []byte("event 2")))
+	assert.NoError(t, err)
+	record := encodeRecord(data)
+	_, err = f.Write(record[:len(record)/2])
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+
+	b = reopen(t, b)
+	assertEvents(t, b, key, []*fes.Event{event})
+
+	// The log should be usable again after the truncation
+	event3 := newEvent(key, []byte("event 3"))
+	assert.NoError(t, b.Append(event3))
+	b = reopen(t, b)
+	assertEvents(t, b, key, []*fes.Event{event, event3})
+}
+
+func TestBackend_Snapshot(t *testing.T) {
+	// Rotate a segment after every event and snapshot after every two segments.
+	b, cleanup := setupBackend(t, Config{
+		MaxSegmentSize:   1,
+		SnapshotInterval: 2,
+	})
+	defer cleanup()
+
+	var events []*fes.Event
+	for i := 0; i < 5; i++ {
+		key := fes.Aggregate{Type: "type", Id: fmt.Sprintf("%d", i%2)}
+		event := newEvent(key, []byte(fmt.Sprintf("event-%d", i)))
+		assert.NoError(t, b.Append(event))
+		events = append(events, event)
+	}
+
+	snapshotID, segmentIDs, err := scanDir(b.Dir)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(4), snapshotID)
+	assert.EqualValues(t, []uint64{5, 6}, segmentIDs)
+
+	b = reopen(t, b)
+	assertEvents(t, b, fes.Aggregate{Type: "type", Id: "0"}, []*fes.Event{events[0], events[2], events[4]})
+	assertEvents(t, b, fes.Aggregate{Type: "type", Id: "1"}, []*fes.Event{events[1], events[3]})
+
+	// Forcing a snapshot should compact all remaining segments
+	assert.NoError(t, b.Snapshot())
+	snapshotID, segmentIDs, err = scanDir(b.Dir)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), snapshotID)
+	assert.EqualValues(t, []uint64{6}, segmentIDs)
+
+	b = reopen(t, b)
+	keys, err := b.List(nil)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+	assert.Equal(t, uint64(5), b.seq)
+}
+
+func TestBackend_Closed(t *testing.T) {
+	b, cleanup := setupBackend(t, Config{})
+	defer cleanup()
+	assert.NoError(t, b.Close())
+	err := b.Append(newEvent(fes.Aggregate{Type: "type", Id: "id"}, []byte("event 1")))
+	assert.Equal(t, ErrBackendClosed.S, err.(fes.EventStoreErr).S)
+}
+
+func assertEvents(t *testing.T, b *Backend, key fes.Aggregate, expected []*fes.Event) {
+	events, err := b.Get(key)
+	assert.NoError(t, err)
+	if assert.Len(t, events, len(expected)) {
+		for i := range expected {
+			assert.True(t, proto.Equal(expected[i], events[i]), "event %d: expected %v, got %v", i,
+				expected[i], events[i])
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"sync"
 	"time"
 
 	"github.com/fission/fission-workflows/pkg/fes"
@@ -18,10 +19,17 @@ var (
 		Name:      "current_cache_counts",
 		Help:      "The current number of entries in the caches",
 	}, []string{"name"})
+
+	snapshotsTaken = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "fes",
+		Subsystem: "cache",
+		Name:      "snapshots_total",
+		Help:      "Count of snapshots taken of entities by aggregate type.",
+	}, []string{"type"})
 )
 
 func init() {
-	prometheus.MustRegister(cacheCount)
+	prometheus.MustRegister(cacheCount, snapshotsTaken)
 }
 
 type LRUCache struct {
@@ -79,6 +87,44 @@ func (c *LRUCache) Invalidate(a fes.Aggregate) {
 	c.contents.Remove(a)
 }
 
+// LRUSnapshotStore is an in-memory fes.SnapshotStore, which evicts the least recently used snapshots once it is full.
+type LRUSnapshotStore struct {
+	contents *lru.Cache
+	lock     sync.Mutex
+}
+
+func NewLRUSnapshotStore(size int) *LRUSnapshotStore {
+	c, err := lru.New(size)
+	if err != nil {
+		panic(err)
+	}
+	return &LRUSnapshotStore{
+		contents: c,
+	}
+}
+
+func (s *LRUSnapshotStore) GetSnapshot(a fes.Aggregate) *fes.Snapshot {
+	i, ok := s.contents.Get(a)
+	if !ok {
+		return nil
+	}
+	return i.(*fes.Snapshot)
+}
+
+func (s *LRUSnapshotStore) PutSnapshot(a fes.Aggregate, snapshot *fes.Snapshot) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if current := s.GetSnapshot(a); current != nil && current.Offset >= snapshot.Offset {
+		return
+	}
+	s.contents.Add(a, snapshot)
+	snapshotsTaken.WithLabelValues(a.Type).Inc()
+}
+
+func (s *LRUSnapshotStore) DeleteSnapshot(a fes.Aggregate) {
+	s.contents.Remove(a)
+}
+
 // A SubscribedCache is subscribed to an event emitter
 type SubscribedCache struct {
 	pubsub.Publisher
@@ -206,8 +252,10 @@ func (uc *SubscribedCache) getOrCreateAggregateForEvent(event *fes.Event) (fes.E
 // LoadingCache looks into a backing data store in case there is a cache miss
 type LoadingCache struct {
 	fes.CacheReaderWriter
-	client    fes.Backend
-	projector fes.Projector
+	client           fes.Backend
+	projector        fes.Projector
+	snapshots        fes.SnapshotStore
+	snapshotInterval int
 }
 
 func NewLoadingCache(cache fes.CacheReaderWriter, client fes.Backend, projector fes.Projector) *LoadingCache {
@@ -218,6 +266,18 @@ func NewLoadingCache(cache fes.CacheReaderWriter, client fes.Backend, projector
 	}
 }
 
+// WithSnapshots enables snapshotting of the entities that the cache loads from the event store. Once at least
+// interval events have been replayed on top of the previous snapshot, the resulting entity is stored as the new
+// snapshot. Subsequent loads of the entity, such as refreshes, only need to replay the events after the snapshot.
+func (c *LoadingCache) WithSnapshots(snapshots fes.SnapshotStore, interval int) *LoadingCache {
+	if interval <= 0 {
+		interval = 1
+	}
+	c.snapshots = snapshots
+	c.snapshotInterval = interval
+	return c
+}
+
 // List for a LoadingCache returns the keys of all entities in the cache.
 //
 // TODO provide option to force fallback or only do quick cache lookup.
@@ -256,24 +316,49 @@ func (c *LoadingCache) Load(key fes.Aggregate) error {
 
 // getFromEventStore assumes that it can mutate target entity
 func (c *LoadingCache) getFromEventStore(aggregate fes.Aggregate) (fes.Entity, error) {
+	// Start from the most recent snapshot, if available.
+	var base fes.Entity
+	var offset int
+	if c.snapshots != nil {
+		if snapshot := c.snapshots.GetSnapshot(aggregate); snapshot != nil {
+			base = snapshot.Entity
+			offset = snapshot.Offset
+		}
+	}
+	if base == nil {
+		var err error
+		base, err = c.projector.NewProjection(aggregate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Look up relevant events in event store
-	events, err := c.client.Get(aggregate)
+	events, err := fes.GetTail(c.client, aggregate, offset)
 	if err != nil {
+		if offset > 0 {
+			// The snapshot does not match the event stream; fall back to replaying all events.
+			logrus.Warnf("Discarding snapshot of %v at offset %d: %v", aggregate.Format(), offset, err)
+			c.snapshots.DeleteSnapshot(aggregate)
+			return c.getFromEventStore(aggregate)
+		}
 		return nil, err
 	}
-	if len(events) == 0 {
+	if offset == 0 && len(events) == 0 {
 		return nil, fes.ErrEntityNotFound.WithAggregate(&aggregate)
 	}
 
-	base, err := c.projector.NewProjection(aggregate)
+	// Reconstruct entity by replaying the events
+	entity, err := c.projector.Project(base, events...)
 	if err != nil {
 		return nil, err
 	}
 
-	// Reconstruct entity by replaying all events
-	entity, err := c.projector.Project(base, events...)
-	if err != nil {
-		return nil, err
+	if c.snapshots != nil && len(events) >= c.snapshotInterval {
+		c.snapshots.PutSnapshot(aggregate, &fes.Snapshot{
+			Entity: entity,
+			Offset: offset + len(events),
+		})
 	}
 
 	// Cache retrieved entity
@@ -297,6 +382,73 @@ func (c *LoadingCache) Refresh(key fes.Aggregate) {
 	}
 }
 
+// IndexFunc returns the value under which the entity is indexed, or an empty string to exclude it from the index.
+type IndexFunc func(entity fes.Entity) string
+
+// IndexedCache maintains an index of the entities that are put into the wrapped cache.
+//
+// Entries are not removed from the index when the cache evicts their entities, so a lookup also returns the
+// aggregates of evicted entities. Use a LoadingCache on top of the IndexedCache to load these on demand.
+type IndexedCache struct {
+	fes.CacheReaderWriter
+	indexFn IndexFunc
+	index   map[string]map[fes.Aggregate]struct{}
+	values  map[fes.Aggregate]string
+	lock    sync.RWMutex
+}
+
+func NewIndexedCache(cache fes.CacheReaderWriter, indexFn IndexFunc) *IndexedCache {
+	return &IndexedCache{
+		CacheReaderWriter: cache,
+		indexFn:           indexFn,
+		index:             map[string]map[fes.Aggregate]struct{}{},
+		values:            map[fes.Aggregate]string{},
+	}
+}
+
+func (c *IndexedCache) Put(entity fes.Entity) error {
+	if err := c.CacheReaderWriter.Put(entity); err != nil {
+		return err
+	}
+	key := fes.GetAggregate(entity)
+	value := c.indexFn(entity)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if old, ok := c.values[key]; ok {
+		if old == value {
+			return nil
+		}
+		delete(c.index[old], key)
+		if len(c.index[old]) == 0 {
+			delete(c.index, old)
+		}
+		delete(c.values, key)
+	}
+	if len(value) == 0 {
+		return nil
+	}
+	keys, ok := c.index[value]
+	if !ok {
+		keys = map[fes.Aggregate]struct{}{}
+		c.index[value] = keys
+	}
+	keys[key] = struct{}{}
+	c.values[key] = value
+	return nil
+}
+
+// Lookup returns the aggregates of the entities that are indexed under the value.
+func (c *IndexedCache) Lookup(value string) []fes.Aggregate {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	results := make([]fes.Aggregate, 0, len(c.index[value]))
+	for key := range c.index[value] {
+		results = append(results, key)
+	}
+	return results
+}
+
 // To ensure that tasks end up in invocation entities: if an event has a parent aggregate,
 // this means that the event should be send to the parent aggregate instead.
 func getKey(e *fes.Event) fes.Aggregate {
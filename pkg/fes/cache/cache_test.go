@@ -41,6 +41,52 @@ func TestLoadingCache_GetAggregateStoreAndCache(t *testing.T) {
 	assert.Equal(t, target, cachedEntity.S)
 }
 
+func TestLoadingCache_Snapshots(t *testing.T) {
+	cache, backingCache, eventStore := setupLoadingCache()
+	snapshots := NewLRUSnapshotStore(10)
+	cache.WithSnapshots(snapshots, 2)
+	key := fes.Aggregate{Type: testutil.MockEntityType, Id: "1"}
+	for _, event := range testutil.ToDummyEvents(key, "abc") {
+		assert.NoError(t, eventStore.Append(event))
+	}
+
+	// Loading the entity should result in a snapshot
+	e, err := cache.GetAggregate(key)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", e.(*testutil.MockEntity).S)
+	snapshot := snapshots.GetSnapshot(key)
+	if assert.NotNil(t, snapshot) {
+		assert.Equal(t, 3, snapshot.Offset)
+	}
+
+	// Only the tail of the events should be replayed on top of the snapshot.
+	snapshots.PutSnapshot(key, &fes.Snapshot{
+		Entity: &testutil.MockEntity{Id: key.Id, S: "xyz"},
+		Offset: 4,
+	})
+	for _, event := range testutil.ToDummyEvents(key, "def") {
+		assert.NoError(t, eventStore.Append(event))
+	}
+	cache.Refresh(key)
+	e, err = backingCache.GetAggregate(key)
+	assert.NoError(t, err)
+	assert.Equal(t, "xyzef", e.(*testutil.MockEntity).S)
+	assert.Equal(t, 6, snapshots.GetSnapshot(key).Offset)
+
+	// Older snapshots should not replace more recent snapshots
+	snapshots.PutSnapshot(key, &fes.Snapshot{Entity: &testutil.MockEntity{Id: key.Id}, Offset: 5})
+	assert.Equal(t, 6, snapshots.GetSnapshot(key).Offset)
+
+	// A snapshot beyond the event stream should be discarded in favor of replaying all events
+	snapshots.DeleteSnapshot(key)
+	snapshots.PutSnapshot(key, &fes.Snapshot{Entity: &testutil.MockEntity{Id: key.Id, S: "xyz"}, Offset: 10})
+	cache.Refresh(key)
+	e, err = backingCache.GetAggregate(key)
+	assert.NoError(t, err)
+	assert.Equal(t, "abcdef", e.(*testutil.MockEntity).S)
+	assert.Equal(t, 6, snapshots.GetSnapshot(key).Offset)
+}
+
 func TestLRUCache(t *testing.T) {
 	cache := NewLRUCache(2)
 	e1, _ := testutil.Projector.NewProjection(fes.Aggregate{Type: "test", Id: "1"})
@@ -61,3 +107,25 @@ func TestLRUCache(t *testing.T) {
 	c3, err := cache.GetAggregate(fes.GetAggregate(e3))
 	assert.EqualValues(t, e3, c3)
 }
+
+func TestIndexedCache(t *testing.T) {
+	cache := NewIndexedCache(NewLRUCache(1), func(entity fes.Entity) string {
+		return entity.(*testutil.MockEntity).S
+	})
+	e1 := &testutil.MockEntity{Id: "1", S: "a"}
+	e2 := &testutil.MockEntity{Id: "2", S: "a"}
+	e3 := &testutil.MockEntity{Id: "3"}
+	assert.NoError(t, cache.Put(e1))
+	assert.NoError(t, cache.Put(e2))
+	assert.NoError(t, cache.Put(e3))
+
+	// Evicted entities remain in the index; entities without an index value are not indexed.
+	assert.Len(t, cache.List(), 1)
+	assert.ElementsMatch(t, []fes.Aggregate{fes.GetAggregate(e1), fes.GetAggregate(e2)}, cache.Lookup("a"))
+	assert.Empty(t, cache.Lookup(""))
+
+	// Updated entities are moved to their new index value.
+	assert.NoError(t, cache.Put(&testutil.MockEntity{Id: "1", S: "b"}))
+	assert.Equal(t, []fes.Aggregate{fes.GetAggregate(e2)}, cache.Lookup("a"))
+	assert.Equal(t, []fes.Aggregate{fes.GetAggregate(e1)}, cache.Lookup("b"))
+}
@@ -11,6 +11,7 @@ const (
 	PubSubLabelEventType      = "event.type"
 	PubSubLabelAggregateType  = "aggregate.type"
 	PubSubLabelAggregateID    = "aggregate.id"
+	PubSubLabelParentID       = "parent.id"
 	DefaultNotificationBuffer = 64
 )
 
@@ -98,9 +98,13 @@ func NewBackend() *Backend {
 	}
 }
 
-func (b *Backend) Append(event *fes.Event) error {
+func (b *Backend) Append(event *fes.Event, opts ...fes.AppendOption) error {
 	b.lock.Lock()
 	defer b.lock.Unlock()
+	cfg := fes.ParseAppendOptions(opts)
+	if err := cfg.CheckGeneration(event, int64(len(b.events[*event.Aggregate]))); err != nil {
+		return err
+	}
 	eventCopy := proto.Clone(event).(*fes.Event)
 	eventCopy.Id = fmt.Sprintf("%d", len(b.events[*event.Aggregate]))
 	b.events[*event.Aggregate] = append(b.events[*event.Aggregate], eventCopy)
@@ -23,7 +23,53 @@ type Projector interface {
 }
 
 type EventAppender interface {
-	Append(event *Event) error
+	// Append appends the event to the event stream of its aggregate, or the stream of its parent if it has one.
+	//
+	// With ExpectGeneration the event is only appended if its aggregate still has the generation that the caller
+	// based the event on; otherwise an ErrGenerationConflict is returned.
+	Append(event *Event, opts ...AppendOption) error
+}
+
+// AnyGeneration is the expected generation of appends that do not depend on the current state of the aggregate.
+const AnyGeneration int64 = -1
+
+// AppendOptions contains the options of an append, which are provided as AppendOption to EventAppender.Append.
+type AppendOptions struct {
+	// ExpectedGeneration is the generation that the aggregate of the event should have before the event is appended.
+	// The generation of an aggregate is the number of events that have been appended to it, which corresponds to
+	// the generation in the metadata of its projection.
+	ExpectedGeneration int64
+}
+
+type AppendOption func(opts *AppendOptions)
+
+// ExpectGeneration ensures that the event is only appended if the aggregate of the event has the generation.
+// This allows concurrent writers, such as the controllers of multiple replicas, to detect that their view of the
+// aggregate was outdated, rather than blindly appending conflicting events.
+func ExpectGeneration(generation int64) AppendOption {
+	return func(opts *AppendOptions) {
+		opts.ExpectedGeneration = generation
+	}
+}
+
+func ParseAppendOptions(opts []AppendOption) AppendOptions {
+	cfg := AppendOptions{
+		ExpectedGeneration: AnyGeneration,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// CheckGeneration returns an ErrGenerationConflict if an expected generation was provided that does not match the
+// current generation of the aggregate of the event.
+func (opts AppendOptions) CheckGeneration(event *Event, generation int64) error {
+	if opts.ExpectedGeneration == AnyGeneration || opts.ExpectedGeneration == generation {
+		return nil
+	}
+	return ErrGenerationConflict.WithEvent(event).WithError(fmt.Errorf("expected generation %d, but was %d",
+		opts.ExpectedGeneration, generation))
 }
 
 // Backend is a persistent store for events
@@ -35,6 +81,34 @@ type Backend interface {
 	List(matcher AggregateMatcher) ([]Aggregate, error)
 }
 
+// TailReader is an optional extension of a Backend, which allows the backend to efficiently fetch the tail of the
+// event stream of an aggregate.
+type TailReader interface {
+	// GetTail fetches the events that belong to a specific aggregate, skipping the first offset events.
+	//
+	// If the offset exceeds the number of events of the aggregate, a fes.ErrOffsetOutOfRange error is returned.
+	GetTail(aggregate Aggregate, offset int) ([]*Event, error)
+}
+
+// Snapshot is the projection of an aggregate after applying the first Offset events of its event stream.
+type Snapshot struct {
+	Entity Entity
+	Offset int
+}
+
+// SnapshotStore keeps the most recent snapshot of aggregates. This allows an entity to be rebuilt from its latest
+// snapshot and the tail of its event stream, rather than having to replay all of its events.
+type SnapshotStore interface {
+	// GetSnapshot returns the most recent snapshot of the aggregate, or nil if there is none.
+	GetSnapshot(aggregate Aggregate) *Snapshot
+
+	// PutSnapshot stores the snapshot of the aggregate, unless a more recent snapshot is already present.
+	PutSnapshot(aggregate Aggregate, snapshot *Snapshot)
+
+	// DeleteSnapshot removes the snapshot of the aggregate, for example if it has turned out to be invalid.
+	DeleteSnapshot(aggregate Aggregate)
+}
+
 type CacheReader interface {
 	//Get(entity Entity) error
 	List() []Aggregate
@@ -134,4 +208,6 @@ var (
 	ErrUnsupportedEntityEvent = EventStoreErr{S: "event not supported"}
 	ErrCorruptedEventPayload  = EventStoreErr{S: "failed to parse event payload"}
 	ErrEntityNotFound         = EventStoreErr{S: "entity not found"}
+	ErrOffsetOutOfRange       = EventStoreErr{S: "event offset out of range"}
+	ErrGenerationConflict     = EventStoreErr{S: "aggregate has been modified concurrently"}
 )
@@ -47,6 +47,34 @@ func NewEvent(aggregate Aggregate, payload proto.Message) (*Event, error) {
 	}, nil
 }
 
+// GetTail fetches the events of the aggregate, skipping the first offset events. If the backend does not implement
+// TailReader, all events of the aggregate are fetched and the first offset events are discarded.
+func GetTail(backend Backend, aggregate Aggregate, offset int) ([]*Event, error) {
+	if tailReader, ok := backend.(TailReader); ok {
+		return tailReader.GetTail(aggregate, offset)
+	}
+	events, err := backend.Get(aggregate)
+	if err != nil {
+		return nil, err
+	}
+	if offset > len(events) {
+		return nil, ErrOffsetOutOfRange.WithAggregate(&aggregate)
+	}
+	return events[offset:], nil
+}
+
+// Generation returns the generation of the aggregate in the event stream, which is the number of events of the
+// aggregate. The event stream can contain the events of other aggregates, such as those of its children.
+func Generation(aggregate Aggregate, events []*Event) int64 {
+	var generation int64
+	for _, event := range events {
+		if event.GetAggregate() != nil && *event.Aggregate == aggregate {
+			generation++
+		}
+	}
+	return generation
+}
+
 // ParseEventData parses the payload of the event, returning the generic proto.Message payload.
 //
 // In case it fails to parse the payload it returns an ErrCorruptedEventPayload
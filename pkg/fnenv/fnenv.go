@@ -57,7 +57,8 @@ type Runtime interface {
 	// Invoke executes the task in a blocking way.
 	//
 	// spec contains the complete configuration needed for the execution.
-	// It returns the TaskInvocationStatus with a completed (FINISHED, FAILED, ABORTED) status.
+	// It returns the TaskInvocationStatus with a completed (FINISHED, FAILED, ABORTED) status, or an IN_PROGRESS status
+	// if the task is completed later on by a signal.
 	// An error is returned only when error occurs outside of the runtime's control.
 	Invoke(spec *types.TaskInvocationSpec, opts ...InvokeOption) (*types.TaskInvocationStatus, error)
 }
@@ -0,0 +1,346 @@
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fnenv"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+const Name = "mock"
+
+// fixtureExtensions contains the extensions of the files that are loaded from a directory of fixtures.
+var fixtureExtensions = map[string]bool{
+	".yaml": true,
+	".yml":  true,
+	".json": true,
+}
+
+// Fixture describes how a mocked function responds to an invocation. A fixture file is a YAML (or JSON) list of
+// fixtures, for example:
+//
+//   - function: fortune
+//     output: "Be yourself; everyone else is already taken."
+//   - function: whalesay
+//     inputs:
+//       body: "Hello"
+//     output: "The whale says Hello"
+//     delay: 100ms
+//   - function: whalesay
+//     error: "the whale is asleep"
+//
+// If the inputs of the fixture are set, the fixture is only used for invocations with those inputs. Otherwise, the
+// fixture is used for any invocation of the function for which there is no fixture with matching inputs.
+type Fixture struct {
+	// Function is the mocked function. The runtime of the function reference is ignored, so a fixture for 'fortune'
+	// also applies to 'fission://fortune'.
+	Function string `yaml:"function" json:"function,omitempty"`
+
+	// Inputs optionally restricts the fixture to invocations of which the inputs contain these inputs.
+	Inputs map[string]interface{} `yaml:"inputs" json:"inputs,omitempty"`
+
+	// Output and OutputHeaders are the output of a successful invocation.
+	Output        interface{}            `yaml:"output" json:"output,omitempty"`
+	OutputHeaders map[string]interface{} `yaml:"outputHeaders" json:"outputHeaders,omitempty"`
+
+	// Error is the error message of a failed invocation. If set, the output is ignored.
+	Error string `yaml:"error" json:"error,omitempty"`
+
+	// Delay is the time it takes for the function to respond, formatted as a duration (e.g. 100ms).
+	Delay string `yaml:"delay" json:"delay,omitempty"`
+}
+
+// ParseFixtures parses a YAML or JSON list of fixtures.
+func ParseFixtures(data []byte) ([]*Fixture, error) {
+	var fixtures []*Fixture
+	if err := yaml.Unmarshal(data, &fixtures); err != nil {
+		return nil, err
+	}
+	for i, fixture := range fixtures {
+		if fixture == nil {
+			return nil, fmt.Errorf("fixture %d is empty", i)
+		}
+		fixture.Inputs = normalizeMap(fixture.Inputs)
+		fixture.Output = normalize(fixture.Output)
+		fixture.OutputHeaders = normalizeMap(fixture.OutputHeaders)
+	}
+	return fixtures, nil
+}
+
+// LoadFixtures loads the fixtures in the files. If one of the paths is a directory, the fixtures are loaded from the
+// YAML and JSON files in that directory.
+func LoadFixtures(paths ...string) ([]*Fixture, error) {
+	var fixtures []*Fixture
+	for _, path := range paths {
+		files, err := fixtureFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			parsed, err := ParseFixtures(data)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse fixtures in %s: %v", file, err)
+			}
+			fixtures = append(fixtures, parsed...)
+		}
+	}
+	return fixtures, nil
+}
+
+func fixtureFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && fixtureExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// fixture is a Fixture of which the values have been parsed.
+type fixture struct {
+	*Fixture
+	fnID          string
+	inputs        map[string]string
+	output        *typedvalues.TypedValue
+	outputHeaders *typedvalues.TypedValue
+	delay         time.Duration
+}
+
+// FixtureRuntime is a function runtime that responds to invocations of mocked functions based on fixtures. In contrast
+// to Runtime, in which the behavior of the functions is defined in code, the fixtures can be loaded from files, which
+// allows the runtime to be used by the bundle and the run command. It is safe to use by concurrent tasks.
+//
+// The runtime keeps track of the number of times that each of the functions has been invoked.
+type FixtureRuntime struct {
+	fixtures map[string][]*fixture
+	calls    map[string]int
+	lock     sync.Mutex
+}
+
+// NewFixtureRuntime creates a runtime for the functions in the fixtures. An error is returned if one of the fixtures
+// is invalid.
+func NewFixtureRuntime(fixtures []*Fixture) (*FixtureRuntime, error) {
+	rt := &FixtureRuntime{
+		fixtures: map[string][]*fixture{},
+		calls:    map[string]int{},
+	}
+	for i, f := range fixtures {
+		parsed, err := parseFixture(f)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixture %d (%s): %v", i, f.Function, err)
+		}
+		rt.fixtures[parsed.fnID] = append(rt.fixtures[parsed.fnID], parsed)
+	}
+	return rt, nil
+}
+
+func parseFixture(f *Fixture) (*fixture, error) {
+	if len(f.Function) == 0 {
+		return nil, errors.New("function is required")
+	}
+	ref, err := types.ParseFnRef(f.Function)
+	if err != nil {
+		return nil, err
+	}
+	parsed := &fixture{Fixture: f, fnID: ref.ID}
+	if len(f.Delay) > 0 {
+		delay, err := time.ParseDuration(f.Delay)
+		if err != nil {
+			return nil, fmt.Errorf("invalid delay: %v", err)
+		}
+		parsed.delay = delay
+	}
+	if f.Inputs != nil {
+		parsed.inputs = map[string]string{}
+		for key, input := range f.Inputs {
+			bs, err := json.Marshal(input)
+			if err != nil {
+				return nil, fmt.Errorf("invalid input '%s': %v", key, err)
+			}
+			parsed.inputs[key] = string(bs)
+		}
+	}
+	if parsed.output, err = typedvalues.Wrap(f.Output); err != nil {
+		return nil, fmt.Errorf("invalid output: %v", err)
+	}
+	if f.OutputHeaders != nil {
+		if parsed.outputHeaders, err = typedvalues.Wrap(f.OutputHeaders); err != nil {
+			return nil, fmt.Errorf("invalid output headers: %v", err)
+		}
+	}
+	return parsed, nil
+}
+
+// Resolve resolves the functions for which there are fixtures.
+func (rt *FixtureRuntime) Resolve(ref types.FnRef) (string, error) {
+	if _, ok := rt.fixtures[ref.ID]; !ok {
+		return "", fmt.Errorf("could not resolve mocked function '%s'", ref.ID)
+	}
+	return ref.ID, nil
+}
+
+// Invoke responds to the invocation with the fixture that matches the inputs of the invocation, after the delay of
+// that fixture has passed. If there is no matching fixture, the invocation fails.
+func (rt *FixtureRuntime) Invoke(spec *types.TaskInvocationSpec, opts ...fnenv.InvokeOption) (
+	*types.TaskInvocationStatus, error) {
+	cfg := fnenv.ParseInvokeOptions(opts)
+	fnID := spec.GetFnRef().GetID()
+	fixtures, ok := rt.fixtures[fnID]
+	if !ok {
+		return nil, fmt.Errorf("could not invoke unknown mocked function '%s'", fnID)
+	}
+	rt.lock.Lock()
+	rt.calls[fnID]++
+	rt.lock.Unlock()
+
+	match, err := matchFixture(fixtures, spec.GetInputs())
+	if err != nil {
+		return nil, err
+	}
+	if match == nil {
+		return &types.TaskInvocationStatus{
+			UpdatedAt: ptypes.TimestampNow(),
+			Status:    types.TaskInvocationStatus_FAILED,
+			Error: &types.Error{
+				Message: fmt.Sprintf("no fixture of mocked function '%s' matches the inputs", fnID),
+			},
+		}, nil
+	}
+
+	if match.delay > 0 {
+		select {
+		case <-time.After(match.delay):
+		case <-cfg.Ctx.Done():
+			return nil, cfg.Ctx.Err()
+		}
+	}
+
+	logrus.Debugf("Mocked function '%s' responded using a fixture", fnID)
+	if len(match.Error) > 0 {
+		return &types.TaskInvocationStatus{
+			UpdatedAt: ptypes.TimestampNow(),
+			Status:    types.TaskInvocationStatus_FAILED,
+			Error:     &types.Error{Message: match.Error},
+		}, nil
+	}
+	return &types.TaskInvocationStatus{
+		UpdatedAt:     ptypes.TimestampNow(),
+		Status:        types.TaskInvocationStatus_SUCCEEDED,
+		Output:        match.output,
+		OutputHeaders: match.outputHeaders,
+	}, nil
+}
+
+// Calls returns the number of times that the mocked function has been invoked.
+func (rt *FixtureRuntime) Calls(fnID string) int {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	return rt.calls[fnID]
+}
+
+// matchFixture returns the first fixture of which the inputs match the inputs of the invocation, or otherwise the
+// first fixture without inputs.
+func matchFixture(fixtures []*fixture, inputs map[string]*typedvalues.TypedValue) (*fixture, error) {
+	var fallback *fixture
+	for _, f := range fixtures {
+		if f.inputs == nil {
+			if fallback == nil {
+				fallback = f
+			}
+			continue
+		}
+		ok, err := matchInputs(f.inputs, inputs)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return f, nil
+		}
+	}
+	return fallback, nil
+}
+
+// matchInputs compares the inputs by their JSON representation, so that differences in the types of numbers between
+// the fixture and the invocation, such as int and float64, are ignored.
+func matchInputs(expected map[string]string, inputs map[string]*typedvalues.TypedValue) (bool, error) {
+	for key, value := range expected {
+		tv, ok := inputs[key]
+		if !ok {
+			return false, nil
+		}
+		i, err := typedvalues.Unwrap(tv)
+		if err != nil {
+			return false, err
+		}
+		bs, err := json.Marshal(i)
+		if err != nil {
+			return false, err
+		}
+		if string(bs) != value {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// normalize converts the maps decoded by the YAML parser to maps with string keys, which can be wrapped in typed
+// values and be converted to JSON.
+func normalize(i interface{}) interface{} {
+	switch v := i.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			m[fmt.Sprintf("%v", key)] = normalize(value)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, value := range v {
+			l[i] = normalize(value)
+		}
+		return l
+	default:
+		return i
+	}
+}
+
+func normalizeMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	result := make(map[string]interface{}, len(m))
+	for key, value := range m {
+		result[key] = normalize(value)
+	}
+	return result
+}
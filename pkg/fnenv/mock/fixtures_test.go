@@ -0,0 +1,149 @@
+package mock
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/fnenv"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/stretchr/testify/assert"
+)
+
+const testFixtures = `
+- function: whalesay
+  inputs:
+    default: hello
+    count: 2
+  output:
+    message: The whale says hello
+    count: [1, 2]
+- function: fission://whalesay
+  error: the whale is asleep
+- function: fortune
+  output: Be yourself
+  outputHeaders:
+    Content-Type: text/plain
+  delay: 10ms
+`
+
+func invocation(fn string, inputs map[string]*typedvalues.TypedValue) *types.TaskInvocationSpec {
+	return &types.TaskInvocationSpec{
+		FnRef:  &types.FnRef{Runtime: Name, ID: fn},
+		Inputs: inputs,
+	}
+}
+
+func TestParseFixtures(t *testing.T) {
+	fixtures, err := ParseFixtures([]byte(testFixtures))
+	assert.NoError(t, err)
+	assert.Len(t, fixtures, 3)
+	assert.Equal(t, map[string]interface{}{"default": "hello", "count": 2}, fixtures[0].Inputs)
+	assert.Equal(t, map[string]interface{}{
+		"message": "The whale says hello",
+		"count":   []interface{}{1, 2},
+	}, fixtures[0].Output)
+	assert.Equal(t, "10ms", fixtures[2].Delay)
+
+	_, err = ParseFixtures([]byte("function: noop"))
+	assert.Error(t, err)
+}
+
+func TestFixtureRuntime(t *testing.T) {
+	fixtures, err := ParseFixtures([]byte(testFixtures))
+	assert.NoError(t, err)
+	rt, err := NewFixtureRuntime(fixtures)
+	assert.NoError(t, err)
+
+	id, err := rt.Resolve(types.FnRef{Runtime: "fission", ID: "whalesay"})
+	assert.NoError(t, err)
+	assert.Equal(t, "whalesay", id)
+	_, err = rt.Resolve(types.FnRef{ID: "noop"})
+	assert.Error(t, err)
+
+	// The inputs are matched regardless of the type of the numbers.
+	status, err := rt.Invoke(invocation("whalesay", map[string]*typedvalues.TypedValue{
+		types.InputMain: typedvalues.MustWrap("hello"),
+		"count":         typedvalues.MustWrap(float64(2)),
+		"other":         typedvalues.MustWrap(true),
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, status.GetStatus())
+	output, err := typedvalues.UnwrapMap(status.GetOutput())
+	assert.NoError(t, err)
+	assert.Equal(t, "The whale says hello", output["message"])
+
+	// Other inputs fall back to the fixture without inputs.
+	status, err = rt.Invoke(invocation("whalesay", types.Input("goodbye")))
+	assert.NoError(t, err)
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, status.GetStatus())
+	assert.Equal(t, "the whale is asleep", status.GetError().GetMessage())
+
+	start := time.Now()
+	status, err = rt.Invoke(invocation("fortune", nil))
+	assert.NoError(t, err)
+	assert.True(t, time.Since(start) >= 10*time.Millisecond)
+	assert.Equal(t, "Be yourself", typedvalues.MustUnwrap(status.GetOutput()))
+	assert.NotNil(t, status.GetOutputHeaders())
+
+	assert.Equal(t, 2, rt.Calls("whalesay"))
+	assert.Equal(t, 1, rt.Calls("fortune"))
+	assert.Equal(t, 0, rt.Calls("noop"))
+
+	_, err = rt.Invoke(invocation("noop", nil))
+	assert.Error(t, err)
+}
+
+func TestFixtureRuntime_NoMatch(t *testing.T) {
+	rt, err := NewFixtureRuntime([]*Fixture{
+		{Function: "echo", Inputs: map[string]interface{}{"default": "a"}, Output: "a"},
+	})
+	assert.NoError(t, err)
+
+	status, err := rt.Invoke(invocation("echo", types.Input("b")))
+	assert.NoError(t, err)
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, status.GetStatus())
+	assert.Contains(t, status.GetError().GetMessage(), "no fixture")
+}
+
+func TestFixtureRuntime_Canceled(t *testing.T) {
+	rt, err := NewFixtureRuntime([]*Fixture{{Function: "slow", Delay: "1h"}})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = rt.Invoke(invocation("slow", nil), fnenv.WithContext(ctx))
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestNewFixtureRuntime_Invalid(t *testing.T) {
+	_, err := NewFixtureRuntime([]*Fixture{{Output: "missing function"}})
+	assert.Error(t, err)
+	_, err = NewFixtureRuntime([]*Fixture{{Function: "fn", Delay: "soon"}})
+	assert.Error(t, err)
+}
+
+func TestLoadFixtures(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fixtures")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a.yaml"), []byte(testFixtures), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "b.json"), []byte(`[{"function": "noop"}]`), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("# Fixtures"), 0644))
+
+	fixtures, err := LoadFixtures(dir)
+	assert.NoError(t, err)
+	assert.Len(t, fixtures, 4)
+	assert.Equal(t, "noop", fixtures[3].Function)
+
+	fixtures, err = LoadFixtures(filepath.Join(dir, "b.json"))
+	assert.NoError(t, err)
+	assert.Len(t, fixtures, 1)
+
+	_, err = LoadFixtures(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package mock
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/fission/fission-workflows/pkg/fnenv"
@@ -27,6 +28,7 @@ type Runtime struct {
 	Functions       map[string]Func
 	AsyncResults    map[string]*types.TaskInvocation
 	ManualExecution bool
+	lock            sync.RWMutex
 }
 
 func NewRuntime() *Runtime {
@@ -44,6 +46,7 @@ func (mk *Runtime) InvokeAsync(spec *types.TaskInvocationSpec, opts ...fnenv.Inv
 	}
 
 	invocationID := util.UID()
+	mk.lock.Lock()
 	mk.AsyncResults[invocationID] = &types.TaskInvocation{
 		Metadata: &types.ObjectMetadata{
 			Id:        invocationID,
@@ -55,6 +58,7 @@ func (mk *Runtime) InvokeAsync(spec *types.TaskInvocationSpec, opts ...fnenv.Inv
 			UpdatedAt: ptypes.TimestampNow(),
 		},
 	}
+	mk.lock.Unlock()
 
 	if !mk.ManualExecution {
 		err := mk.MockComplete(invocationID)
@@ -67,7 +71,9 @@ func (mk *Runtime) InvokeAsync(spec *types.TaskInvocationSpec, opts ...fnenv.Inv
 }
 
 func (mk *Runtime) MockComplete(fnInvocationID string) error {
+	mk.lock.RLock()
 	invocation, ok := mk.AsyncResults[fnInvocationID]
+	mk.lock.RUnlock()
 	if !ok {
 		return fmt.Errorf("could not invoke unknown invocation '%s'", fnInvocationID)
 	}
@@ -79,15 +85,18 @@ func (mk *Runtime) MockComplete(fnInvocationID string) error {
 	}
 
 	result, err := fn(invocation.Spec)
+	mk.lock.Lock()
+	defer mk.lock.Unlock()
 	if err != nil {
 		logrus.Infof("Function '%s' invocation resulted in an error: %v", fnName, err)
-		mk.AsyncResults[fnInvocationID].Status = &types.TaskInvocationStatus{
+		invocation.Status = &types.TaskInvocationStatus{
 			Output:    nil,
 			UpdatedAt: ptypes.TimestampNow(),
 			Status:    types.TaskInvocationStatus_FAILED,
+			Error:     &types.Error{Message: err.Error()},
 		}
 	} else {
-		mk.AsyncResults[fnInvocationID].Status = &types.TaskInvocationStatus{
+		invocation.Status = &types.TaskInvocationStatus{
 			Output:    result,
 			UpdatedAt: ptypes.TimestampNow(),
 			Status:    types.TaskInvocationStatus_SUCCEEDED,
@@ -103,9 +112,11 @@ func (mk *Runtime) Invoke(spec *types.TaskInvocationSpec, opts ...fnenv.InvokeOp
 	if err != nil {
 		return nil, err
 	}
-	err = mk.MockComplete(invocationID)
-	if err != nil {
-		return nil, err
+	if mk.ManualExecution {
+		err = mk.MockComplete(invocationID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	logrus.Infof("...completing function execution for '%v'", invocationID)
@@ -113,6 +124,8 @@ func (mk *Runtime) Invoke(spec *types.TaskInvocationSpec, opts ...fnenv.InvokeOp
 }
 
 func (mk *Runtime) Cancel(fnInvocationID string) error {
+	mk.lock.Lock()
+	defer mk.lock.Unlock()
 	invocation, ok := mk.AsyncResults[fnInvocationID]
 	if !ok {
 		return fmt.Errorf("could not invoke unknown invocation '%s'", fnInvocationID)
@@ -128,6 +141,8 @@ func (mk *Runtime) Cancel(fnInvocationID string) error {
 }
 
 func (mk *Runtime) Status(fnInvocationID string) (*types.TaskInvocationStatus, error) {
+	mk.lock.RLock()
+	defer mk.lock.RUnlock()
 	invocation, ok := mk.AsyncResults[fnInvocationID]
 	if !ok {
 		return nil, fmt.Errorf("could not invoke unknown invocation '%s'", fnInvocationID)
@@ -25,6 +25,7 @@ var DefaultBuiltinFunctions = map[string]native.InternalFunction{
 	Foreach:    &FunctionForeach{},
 	Switch:     &FunctionSwitch{},
 	While:      &FunctionWhile{},
+	Wait:       &FunctionWait{},
 }
 
 // ensureInput verifies that the input for the given key exists and is of one of the provided types.
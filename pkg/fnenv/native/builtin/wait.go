@@ -0,0 +1,49 @@
+package builtin
+
+import (
+	"github.com/fission/fission-workflows/pkg/fnenv/native"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+)
+
+const (
+	Wait = "wait"
+)
+
+/*
+FunctionWait pauses the task until an external signal arrives, for example a manual approval or a webhook callback.
+The task remains in progress until it is signaled using the Signal operation of the invocation API, or using
+`fission-workflows invocation signal <invocation> <task>`. The payload of the signal becomes the output of the task.
+
+Unlike `sleep`, waiting does not occupy the workflow engine; the task is simply recorded as in progress. To avoid
+waiting indefinitely, the timeout of the task (or the deadline of the invocation) applies: if the signal has not
+arrived before then, the task fails.
+
+**Specification**
+
+**input**       | required | types             | description
+----------------|----------|-------------------|--------------------------------------------------------
+-               | -        | -                 | -
+
+**output** (*) The payload of the signal.
+
+**Example**
+
+```yaml
+# ...
+Approval:
+  run: wait
+  timeout: 24h
+Deploy:
+  run: deploy
+  inputs: "{ output('Approval') }"
+  requires:
+  - Approval
+# ...
+```
+*/
+type FunctionWait struct{}
+
+func (fn *FunctionWait) Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+	return nil, native.ErrAwaitingSignal
+}
@@ -0,0 +1,33 @@
+package builtin
+
+import (
+	"testing"
+
+	"github.com/fission/fission-workflows/pkg/fnenv/native"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFunctionWait_Invoke(t *testing.T) {
+	fn := &FunctionWait{}
+	out, err := fn.Invoke(&types.TaskInvocationSpec{})
+	assert.Nil(t, out)
+	assert.Equal(t, native.ErrAwaitingSignal, err)
+}
+
+func TestFunctionWait_InProgress(t *testing.T) {
+	env := native.NewFunctionEnv(map[string]native.InternalFunction{
+		Wait: &FunctionWait{},
+	})
+	status, err := env.Invoke(&types.TaskInvocationSpec{
+		InvocationId: "wi-123",
+		TaskId:       "approval",
+		FnRef: &types.FnRef{
+			Runtime: native.Name,
+			ID:      Wait,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, types.TaskInvocationStatus_IN_PROGRESS, status.GetStatus())
+	assert.Nil(t, status.GetOutput())
+}
@@ -2,6 +2,7 @@
 package native
 
 import (
+	"errors"
 	"fmt"
 	"runtime/debug"
 	"time"
@@ -20,6 +21,10 @@ const (
 	Name = "native"
 )
 
+// ErrAwaitingSignal can be returned by an internal function to indicate that it does not complete by itself. Instead
+// of failing, the task is left in progress until it is completed by a signal.
+var ErrAwaitingSignal = errors.New("awaiting signal")
+
 // An InternalFunction is a function that will be executed in the same process as the invoker.
 type InternalFunction interface {
 	Invoke(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error)
@@ -66,6 +71,12 @@ func (fe *FunctionEnv) Invoke(spec *types.TaskInvocationSpec, opts ...fnenv.Invo
 	out, err := fn.Invoke(spec)
 	fnenv.FnActive.WithLabelValues(Name).Dec()
 	fnenv.FnCount.WithLabelValues(Name).Inc()
+	if err == ErrAwaitingSignal {
+		return &types.TaskInvocationStatus{
+			UpdatedAt: ptypes.TimestampNow(),
+			Status:    types.TaskInvocationStatus_IN_PROGRESS,
+		}, nil
+	}
 	if err != nil {
 		log.WithFields(log.Fields{
 			"fnID": fnID,
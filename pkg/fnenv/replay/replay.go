@@ -0,0 +1,152 @@
+// Package replay provides a function runtime that responds to tasks with the results recorded in the event history of
+// a previous invocation.
+//
+// Because invocations are event sourced, their events can be re-projected at any time. This allows an invocation to
+// be replayed against a (modified) workflow: the tasks that ran in the recorded invocation are substituted by their
+// recorded results, whereas the changed or new tasks are run for real.
+package replay
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fnenv"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/sirupsen/logrus"
+)
+
+const Name = "replay"
+
+// Project re-projects the invocation from its events, which should include the events of its task runs.
+func Project(invocationEvents []*fes.Event) (*types.WorkflowInvocation, error) {
+	if len(invocationEvents) == 0 {
+		return nil, errors.New("no events to project")
+	}
+	if t := invocationEvents[0].GetType(); t != string(events.EventInvocationCreated) {
+		return nil, fmt.Errorf("expected the first event to be %s, but was %s", events.EventInvocationCreated, t)
+	}
+	entity, err := projectors.NewWorkflowInvocation().Project(nil, invocationEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return entity.(*types.WorkflowInvocation), nil
+}
+
+// recording is the result of a task in the recorded invocation.
+type recording struct {
+	// task is the spec of the task in the recorded workflow.
+	task *types.TaskSpec
+
+	// inputs are the inputs of the task run, as they were resolved in the recorded invocation.
+	inputs map[string]*typedvalues.TypedValue
+
+	// status is the result of the task run, to which the output transforms of the task have already been applied.
+	status *types.TaskInvocationStatus
+}
+
+// Runtime responds to invocations of tasks with their recorded results. Tasks are referenced by their task ID, for
+// example replay://GenerateFortune.
+type Runtime struct {
+	recordings map[string]*recording
+}
+
+// New creates a runtime for the tasks that completed in the recorded invocation. Tasks that did not complete, such as
+// skipped or aborted tasks, have no recorded result.
+func New(wi *types.WorkflowInvocation) *Runtime {
+	rt := &Runtime{
+		recordings: map[string]*recording{},
+	}
+	tasks := wi.Tasks()
+	for id, ti := range wi.GetStatus().GetTasks() {
+		switch ti.GetStatus().GetStatus() {
+		case types.TaskInvocationStatus_SUCCEEDED, types.TaskInvocationStatus_FAILED:
+			rt.recordings[id] = &recording{
+				task:   tasks[id].GetSpec(),
+				inputs: ti.GetSpec().GetInputs(),
+				status: ti.GetStatus(),
+			}
+		}
+	}
+	return rt
+}
+
+// Resolve resolves the tasks that have a recorded result.
+func (rt *Runtime) Resolve(ref types.FnRef) (string, error) {
+	if _, ok := rt.recordings[ref.ID]; !ok {
+		return "", fmt.Errorf("no recorded result of task '%s'", ref.ID)
+	}
+	return ref.ID, nil
+}
+
+// Invoke returns the recorded result of the task. If the resolved inputs differ from those in the recording, for
+// example because a task that it depends on was run again, a warning is logged as the recorded result may not apply to
+// these inputs.
+func (rt *Runtime) Invoke(spec *types.TaskInvocationSpec, opts ...fnenv.InvokeOption) (*types.TaskInvocationStatus,
+	error) {
+	taskID := spec.GetFnRef().GetID()
+	rec, ok := rt.recordings[taskID]
+	if !ok {
+		return nil, fmt.Errorf("no recorded result of task '%s'", taskID)
+	}
+	if !equalInputs(rec.inputs, spec.GetInputs()) {
+		logrus.Warnf("Replaying the recorded result of task '%s', although its inputs differ from the recording",
+			taskID)
+	}
+	status := proto.Clone(rec.status).(*types.TaskInvocationStatus)
+	status.UpdatedAt = ptypes.TimestampNow()
+	return status, nil
+}
+
+// Substitute returns a copy of the workflow in which the tasks with a recorded result invoke this runtime instead of
+// their function, along with the IDs of these tasks. Tasks of which the function, inputs or output transforms have
+// changed since the recording are not substituted, nor are the tasks for which the filter (if provided) returns false.
+//
+// The recorded results already include the output transforms of the tasks, so these are removed from the substituted
+// tasks to avoid applying them twice.
+func (rt *Runtime) Substitute(wf *types.WorkflowSpec, filter func(taskID string, task *types.TaskSpec) bool) (
+	*types.WorkflowSpec, []string) {
+	updated := proto.Clone(wf).(*types.WorkflowSpec)
+	var substituted []string
+	for id, task := range updated.GetTasks() {
+		rec, ok := rt.recordings[id]
+		if !ok || !proto.Equal(replayedSpec(rec.task), replayedSpec(task)) {
+			continue
+		}
+		if filter != nil && !filter(id, task) {
+			continue
+		}
+		task.FunctionRef = types.FnRef{Runtime: Name, ID: id}.Format()
+		task.Output = nil
+		task.OutputHeaders = nil
+		substituted = append(substituted, id)
+	}
+	return updated, substituted
+}
+
+// replayedSpec returns the part of the task spec that determines the recorded result of the task.
+func replayedSpec(task *types.TaskSpec) *types.TaskSpec {
+	return &types.TaskSpec{
+		FunctionRef:   task.GetFunctionRef(),
+		Inputs:        task.GetInputs(),
+		Output:        task.GetOutput(),
+		OutputHeaders: task.GetOutputHeaders(),
+	}
+}
+
+func equalInputs(a, b map[string]*typedvalues.TypedValue) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for key, value := range a {
+		if !proto.Equal(value, b[key]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,127 @@
+package replay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/api/events"
+	"github.com/fission/fission-workflows/pkg/api/projectors"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+const invocationID = "wi-123"
+
+func recordedEvents(t *testing.T) []*fes.Event {
+	wf := types.NewWorkflow("wf-123")
+	wf.Spec.Tasks = types.Tasks{
+		"fortune": types.NewTaskSpec("fission://fortune"),
+		"whale":   types.NewTaskSpec("whalesay").Input(types.InputMain, typedvalues.MustWrap("{output('fortune')}")),
+		"fail":    types.NewTaskSpec("fail"),
+		"skipped": types.NewTaskSpec("noop"),
+	}
+	wf.Spec.Tasks["fortune"].Output = typedvalues.MustWrap("{output('fortune').toUpperCase()}")
+	spec := types.NewWorkflowInvocationSpec(wf.ID(), time.Now().Add(time.Minute))
+	spec.Workflow = wf
+
+	invocation := projectors.NewInvocationAggregate(invocationID)
+	newEvent := func(aggregate fes.Aggregate, msg proto.Message) *fes.Event {
+		event, err := fes.NewEvent(aggregate, msg)
+		assert.NoError(t, err)
+		if aggregate.Type == types.TypeTaskRun {
+			event.Parent = &invocation
+		}
+		return event
+	}
+	return []*fes.Event{
+		newEvent(invocation, &events.InvocationCreated{Spec: spec}),
+		newEvent(projectors.NewTaskRunAggregate("fortune"), &events.TaskSucceeded{
+			Result: &types.TaskInvocationStatus{Output: typedvalues.MustWrap("Be yourself")},
+		}),
+		newEvent(projectors.NewTaskRunAggregate("whale"), &events.TaskSucceeded{
+			Result: &types.TaskInvocationStatus{Output: typedvalues.MustWrap("The whale says: Be yourself")},
+		}),
+		newEvent(projectors.NewTaskRunAggregate("fail"), &events.TaskFailed{
+			Error: &types.Error{Message: "all has failed"},
+		}),
+		newEvent(projectors.NewTaskRunAggregate("skipped"), &events.TaskSkipped{}),
+		newEvent(invocation, &events.InvocationFailed{Error: &types.Error{Message: "all has failed"}}),
+	}
+}
+
+func TestProject(t *testing.T) {
+	wi, err := Project(recordedEvents(t))
+	assert.NoError(t, err)
+	assert.Equal(t, invocationID, wi.ID())
+	assert.Equal(t, types.WorkflowInvocationStatus_FAILED, wi.GetStatus().GetStatus())
+	assert.Len(t, wi.GetStatus().GetTasks(), 4)
+
+	_, err = Project(nil)
+	assert.Error(t, err)
+	_, err = Project(recordedEvents(t)[1:])
+	assert.Error(t, err)
+}
+
+func TestRuntime(t *testing.T) {
+	wi, err := Project(recordedEvents(t))
+	assert.NoError(t, err)
+	rt := New(wi)
+
+	id, err := rt.Resolve(types.FnRef{Runtime: Name, ID: "fortune"})
+	assert.NoError(t, err)
+	assert.Equal(t, "fortune", id)
+	_, err = rt.Resolve(types.FnRef{Runtime: Name, ID: "skipped"})
+	assert.Error(t, err)
+
+	status, err := rt.Invoke(&types.TaskInvocationSpec{FnRef: &types.FnRef{Runtime: Name, ID: "whale"}})
+	assert.NoError(t, err)
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, status.GetStatus())
+	assert.Equal(t, "The whale says: Be yourself", typedvalues.MustUnwrap(status.GetOutput()))
+
+	status, err = rt.Invoke(&types.TaskInvocationSpec{FnRef: &types.FnRef{Runtime: Name, ID: "fail"}})
+	assert.NoError(t, err)
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, status.GetStatus())
+	assert.Equal(t, "all has failed", status.GetError().GetMessage())
+
+	_, err = rt.Invoke(&types.TaskInvocationSpec{FnRef: &types.FnRef{Runtime: Name, ID: "unknown"}})
+	assert.Error(t, err)
+}
+
+func TestRuntime_Substitute(t *testing.T) {
+	wi, err := Project(recordedEvents(t))
+	assert.NoError(t, err)
+	rt := New(wi)
+
+	wf := types.NewWorkflowSpec()
+	wf.Tasks = types.Tasks{
+		"fortune": types.NewTaskSpec("fission://fortune"),
+		"whale":   types.NewTaskSpec("whalesay").Input(types.InputMain, typedvalues.MustWrap("{$.Invocation.Inputs}")),
+		"fail":    types.NewTaskSpec("fail"),
+		"skipped": types.NewTaskSpec("noop"),
+		"added":   types.NewTaskSpec("noop"),
+	}
+	wf.Tasks["fortune"].Output = typedvalues.MustWrap("{output('fortune').toUpperCase()}")
+	updated, substituted := rt.Substitute(wf, func(taskID string, task *types.TaskSpec) bool {
+		return taskID != "fail"
+	})
+	assert.Equal(t, []string{"fortune"}, substituted)
+	assert.Equal(t, "replay://fortune", updated.Tasks["fortune"].GetFunctionRef())
+	assert.Equal(t, "whalesay", updated.Tasks["whale"].GetFunctionRef())
+	assert.Equal(t, "fail", updated.Tasks["fail"].GetFunctionRef())
+
+	// The recorded result already includes the output transform of the task.
+	assert.Nil(t, updated.Tasks["fortune"].GetOutput())
+
+	// The original workflow is left untouched.
+	assert.Equal(t, "fission://fortune", wf.Tasks["fortune"].GetFunctionRef())
+	assert.NotNil(t, wf.Tasks["fortune"].GetOutput())
+
+	// Tasks of which the function or output transform changed are not substituted either.
+	wf.Tasks["whale"] = types.NewTaskSpec("cowsay")
+	wf.Tasks["fortune"].Output = nil
+	_, substituted = rt.Substitute(wf, nil)
+	assert.ElementsMatch(t, []string{"fail"}, substituted)
+}
@@ -61,6 +61,7 @@ func (rt *Runtime) Invoke(spec *types.TaskInvocationSpec, opts ...fnenv.InvokeOp
 	if err != nil {
 		return nil, err
 	}
+	rt.inheritLabels(wfSpec, spec.GetInvocationId())
 
 	// Note: currently context is not supported in the runtime interface, so we use a background context.
 	wfi, err := rt.InvokeWorkflow(wfSpec, opts...)
@@ -92,6 +93,7 @@ func (rt *Runtime) InvokeWorkflow(spec *types.WorkflowInvocationSpec, opts ...fn
 			span.LogKV("error", err)
 			return nil, err
 		}
+		spec.WorkflowId = wf.ID()
 		spec.Workflow = wf
 	} else {
 		if !spec.Workflow.GetStatus().Ready() {
@@ -103,6 +105,12 @@ func (rt *Runtime) InvokeWorkflow(spec *types.WorkflowInvocationSpec, opts ...fn
 
 	span.SetTag("workflow.name", spec.GetWorkflow().GetMetadata().GetName())
 
+	// Reject inputs that do not match the contract of the workflow, before creating the invocation.
+	if err := validate.WorkflowInputs(spec.GetWorkflow().GetSpec(), spec.GetInputs()); err != nil {
+		span.LogKV("error", err)
+		return nil, err
+	}
+
 	// If debugging mode is enabled, add all inputs to the trace.
 	if logrus.GetLevel() == logrus.DebugLevel {
 		var inputs interface{}
@@ -158,8 +166,8 @@ func (rt *Runtime) checkForInvocationResult(wfiID string) *types.WorkflowInvocat
 }
 
 func (rt *Runtime) checkForReadyWorkflow(workflowID string) (*types.Workflow, error) {
-	wf, err := rt.workflows.GetWorkflow(workflowID)
-	if err != nil {
+	wf, err := rt.workflows.GetWorkflowByRef(workflowID)
+	if err != nil || wf == nil {
 		return nil, fmt.Errorf("failed to find workflow %v for new invocation", workflowID)
 	}
 	if !wf.GetStatus().Ready() {
@@ -174,6 +182,11 @@ func (rt *Runtime) awaitReadyWorkflow(ctx context.Context, workflowID string) (w
 		return wf, nil
 	}
 
+	// The workflow might be referenced by name, whereas the updates are labeled with the id of the workflow.
+	if existing, err := rt.workflows.GetWorkflowByRef(workflowID); err == nil && existing != nil {
+		workflowID = existing.ID()
+	}
+
 	// await the parsing of the workflow
 	if pub, ok := rt.invocations.CacheReader.(pubsub.Publisher); ok {
 		sub := pub.Subscribe(pubsub.SubscriptionOptions{
@@ -292,6 +305,23 @@ func (rt *Runtime) pollUntilWorkflowResult(ctx context.Context, workflowID strin
 	}
 }
 
+// inheritLabels adds the labels of the invocation that created the child invocation, which is either its parent or
+// the invocation of the task that invoked the workflow.
+func (rt *Runtime) inheritLabels(spec *types.WorkflowInvocationSpec, invocationID string) {
+	if len(spec.GetParentId()) > 0 {
+		invocationID = spec.GetParentId()
+	}
+	if len(invocationID) == 0 {
+		return
+	}
+	invocation, err := rt.invocations.GetInvocation(invocationID)
+	if err != nil {
+		logrus.WithField("fnenv", Name).Warnf("Failed to fetch the labels of invocation %s: %v", invocationID, err)
+		return
+	}
+	spec.Labels = labels.Merge(invocation.GetMetadata().GetLabels(), spec.GetLabels())
+}
+
 func toWorkflowSpec(spec *types.TaskInvocationSpec) (*types.WorkflowInvocationSpec, error) {
 	wfSpec := &types.WorkflowInvocationSpec{
 		WorkflowId: spec.FnRef.ID,
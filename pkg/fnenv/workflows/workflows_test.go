@@ -185,6 +185,57 @@ func TestRuntime_Invoke(t *testing.T) {
 	util.AssertProtoEqual(t, outputHeaders, task.GetOutputHeaders())
 }
 
+func TestRuntime_InvokeInheritsLabels(t *testing.T) {
+	runtime, invocationAPI, _, cache := setup()
+	parent := &types.WorkflowInvocation{
+		Metadata: &types.ObjectMetadata{
+			Id:     "parentID",
+			Labels: map[string]string{"team": "payments"},
+		},
+		Spec:   types.NewWorkflowInvocationSpec(workflowID, defaultDeadline()),
+		Status: &types.WorkflowInvocationStatus{},
+	}
+	assert.NoError(t, cache.Put(parent))
+
+	fnref := types.NewFnRef("workflows", "", workflowID)
+	spec := types.NewTaskInvocationSpec(parent, &types.Task{
+		Metadata: types.NewObjectMetadata("ti-123"),
+		Spec:     &types.TaskSpec{},
+		Status: &types.TaskStatus{
+			FnRef: &fnref,
+		},
+	}, time.Now())
+	spec.Inputs = types.Inputs{
+		types.InputParent: typedvalues.MustWrap(parent.ID()),
+	}
+	go func() {
+		// Simulate workflow invocation
+		time.Sleep(50 * time.Millisecond)
+		for _, entity := range cache.List() {
+			if entity.Id != parent.ID() {
+				err := invocationAPI.Complete(entity.Id, typedvalues.MustWrap("foo"), nil)
+				if err != nil {
+					panic(err)
+				}
+			}
+		}
+	}()
+
+	_, err := runtime.Invoke(spec)
+	assert.NoError(t, err)
+	var children int
+	for _, entity := range cache.List() {
+		if entity.Id == parent.ID() {
+			continue
+		}
+		children++
+		child, err := runtime.invocations.GetInvocation(entity.Id)
+		assert.NoError(t, err)
+		assert.Equal(t, parent.GetMetadata().GetLabels(), child.GetMetadata().GetLabels())
+	}
+	assert.Equal(t, 1, children)
+}
+
 func setup() (*Runtime, *api.Invocation, *mem.Backend, fes.CacheReaderWriter) {
 	backend := mem.NewBackend()
 	invocationAPI := api.NewInvocationAPI(backend)
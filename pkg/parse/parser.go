@@ -1,8 +1,10 @@
 package parse
 
 import (
+	"bytes"
 	"errors"
 	"io"
+	"io/ioutil"
 
 	"github.com/fission/fission-workflows/pkg/parse/protobuf"
 	"github.com/fission/fission-workflows/pkg/parse/yaml"
@@ -11,11 +13,17 @@ import (
 )
 
 var (
-	DefaultParser = NewMetaParser(map[string]Parser{
-		"yaml": yaml.DefaultParser,
+	DefaultParser = NewDefaultParser()
+)
+
+// NewDefaultParser returns a MetaParser with the YAML and protobuf parsers, of which the YAML parser is configured with
+// the options.
+func NewDefaultParser(yamlOpts ...yaml.Option) *MetaParser {
+	return NewMetaParser(map[string]Parser{
+		"yaml": yaml.NewParser(yamlOpts...),
 		"pb":   protobuf.DefaultParser,
 	})
-)
+}
 
 type Parser interface {
 	Parse(r io.Reader) (*types.WorkflowSpec, error)
@@ -55,13 +63,18 @@ func (mp *MetaParser) ParseWith(r io.Reader, parsers ...string) (*types.Workflow
 	if parsers == nil {
 		return nil, errors.New("no parsers provided")
 	}
+	// Each parser reads the definition from the start, rather than from where the previous parser stopped.
+	bs, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
 	var result *types.WorkflowSpec
 	for _, name := range parsers {
 		p, ok := mp.parsers[name]
 		if !ok {
 			continue
 		}
-		wf, err := p.Parse(r)
+		wf, err := p.Parse(bytes.NewReader(bs))
 		if err != nil {
 			logrus.WithField("parser", name).Warnf("parser failed: %v", err)
 			wf = nil
@@ -70,11 +83,10 @@ func (mp *MetaParser) ParseWith(r io.Reader, parsers ...string) (*types.Workflow
 		result = wf
 		break
 	}
-	var err error
 	if result == nil {
-		err = errors.New("failed to parse workflow")
+		return nil, errors.New("failed to parse workflow")
 	}
-	return result, err
+	return result, nil
 }
 
 func (mp *MetaParser) Supports(s string) bool {
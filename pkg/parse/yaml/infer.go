@@ -0,0 +1,88 @@
+package yaml
+
+import (
+	"sort"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/expr"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+)
+
+// InferDependencies adds the tasks that are referenced in the expressions of a task, such as 'a' in
+// `{ output('a') }`, to the dependencies of the task as DATA dependencies. The await of a task is increased by the
+// number of dependencies that were added to it.
+//
+// A reference does not result in a dependency if the referenced task is undefined, is the task itself, is already a
+// dependency, has the task as its error handler, or if the dependency would introduce a cycle. Expressions that
+// cannot be parsed are ignored; these are reported when the workflow is validated.
+func InferDependencies(spec *types.WorkflowSpec) {
+	ids := make([]string, 0, len(spec.Tasks))
+	for id := range spec.Tasks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		task := spec.Tasks[id]
+		if task == nil {
+			continue
+		}
+		for _, ref := range taskReferences(task, spec.GetExpressionLanguage()) {
+			dep, ok := spec.Tasks[ref]
+			if !ok || ref == id || dep.GetOnError() == id || dependsOn(spec, ref, id) {
+				continue
+			}
+			if _, ok := task.Requires[ref]; ok {
+				continue
+			}
+			if task.Requires == nil {
+				task.Requires = map[string]*types.TaskDependencyParameters{}
+			}
+			task.Requires[ref] = &types.TaskDependencyParameters{
+				Type: types.TaskDependencyParameters_DATA,
+			}
+			task.Await++
+		}
+	}
+}
+
+// taskReferences returns the ids of the tasks that are referenced in the inputs and outputs of the task.
+func taskReferences(task *types.TaskSpec, lang string) []string {
+	var refs []string
+	values := []*typedvalues.TypedValue{task.Output, task.OutputHeaders}
+	for _, input := range task.Inputs {
+		values = append(values, input)
+	}
+	for _, tv := range values {
+		if tv == nil {
+			continue
+		}
+		ids, err := expr.TaskReferences(tv, lang)
+		if err != nil {
+			continue
+		}
+		refs = append(refs, ids...)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+// dependsOn returns whether the task (indirectly) requires the other task.
+func dependsOn(spec *types.WorkflowSpec, taskID string, other string) bool {
+	visited := map[string]bool{}
+	queue := []string{taskID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for dep := range spec.Tasks[id].GetRequires() {
+			if dep == other {
+				return true
+			}
+			if !visited[dep] {
+				visited[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return false
+}
@@ -5,23 +5,49 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/fnenv/native/builtin"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/fission/fission-workflows/pkg/types/validate"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 )
 
 const defaultFunctionRef = builtin.Noop
 
-var DefaultParser = &Parser{}
+var DefaultParser = NewParser()
 
 func Parse(r io.Reader) (*types.WorkflowSpec, error) {
 	return DefaultParser.Parse(r)
 }
 
-type Parser struct{}
+type Parser struct {
+	disableDependencyInference bool
+}
+
+// Option configures a Parser.
+type Option func(p *Parser)
+
+// WithoutDependencyInference disables adding the tasks that are referenced in the expressions of a task to the
+// dependencies of that task. See InferDependencies.
+func WithoutDependencyInference() Option {
+	return func(p *Parser) {
+		p.disableDependencyInference = true
+	}
+}
+
+func NewParser(opts ...Option) *Parser {
+	p := &Parser{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
 
 func (p *Parser) Parse(r io.Reader) (*types.WorkflowSpec, error) {
 	b, err := read(r)
@@ -34,6 +60,10 @@ func (p *Parser) Parse(r io.Reader) (*types.WorkflowSpec, error) {
 		return nil, fmt.Errorf("failed to parse workflow definition: %v", err)
 	}
 
+	if !p.disableDependencyInference {
+		InferDependencies(spec)
+	}
+
 	return spec, nil
 }
 
@@ -68,9 +98,13 @@ func parseWorkflow(def *workflowSpec) (*types.WorkflowSpec, error) {
 	}
 
 	return &types.WorkflowSpec{
-		ApiVersion: def.APIVersion,
-		OutputTask: def.Output,
-		Tasks:      tasks,
+		ApiVersion:         def.APIVersion,
+		OutputTask:         def.Output,
+		Tasks:              tasks,
+		Concurrency:        parseConcurrencyPolicy(def.Concurrency),
+		Inputs:             parseInputSchema(def.Inputs),
+		Output:             parseSchema(def.OutputSchema),
+		ExpressionLanguage: def.ExpressionLanguage,
 	}, nil
 }
 
@@ -90,16 +124,129 @@ func parseTask(t *taskSpec) (*types.TaskSpec, error) {
 		fn = defaultFunctionRef
 	}
 
+	retry, err := parseRetryPolicy(t.Retry)
+	if err != nil {
+		return nil, err
+	}
+
+	await := int32(len(deps))
+	if t.Await > 0 {
+		await = t.Await
+	}
+
 	result := &types.TaskSpec{
 		FunctionRef: fn,
 		Requires:    deps,
-		Await:       int32(len(deps)),
+		Await:       await,
 		Inputs:      inputs,
+		Retry:       retry,
+		OnError:     t.OnError,
+	}
+
+	if len(t.Timeout) > 0 {
+		timeout, err := time.ParseDuration(t.Timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout: %v", err)
+		}
+		result.Timeout = ptypes.DurationProto(timeout)
 	}
 
 	return result, nil
 }
 
+func parseConcurrencyPolicy(c *concurrencySpec) *types.ConcurrencyPolicy {
+	if c == nil {
+		return nil
+	}
+	return &types.ConcurrencyPolicy{
+		Max:       c.Max,
+		Functions: c.Functions,
+	}
+}
+
+// parseInputSchema converts the declared inputs into a schema of an object with a property per input.
+func parseInputSchema(inputs map[string]*inputSpec) *types.TypeSchema {
+	if inputs == nil {
+		return nil
+	}
+	schema := &types.TypeSchema{
+		Type:       validate.SchemaTypeObject,
+		Properties: map[string]*types.TypeSchema{},
+	}
+	for key, input := range inputs {
+		if input == nil {
+			input = &inputSpec{}
+		}
+		schema.Properties[key] = parseSchema(&schemaSpec{
+			Type:        input.Type,
+			Description: input.Description,
+			Properties:  input.Properties,
+			Items:       input.Items,
+		})
+		if input.Required {
+			schema.Required = append(schema.Required, key)
+		}
+	}
+	sort.Strings(schema.Required)
+	return schema
+}
+
+func parseSchema(s *schemaSpec) *types.TypeSchema {
+	if s == nil {
+		return nil
+	}
+	schema := &types.TypeSchema{
+		Type:        s.Type,
+		Description: s.Description,
+		Required:    s.Required,
+		Items:       parseSchema(s.Items),
+	}
+	if len(s.Properties) > 0 {
+		schema.Properties = map[string]*types.TypeSchema{}
+		for key, property := range s.Properties {
+			schema.Properties[key] = parseSchema(property)
+		}
+	}
+	return schema
+}
+
+func parseRetryPolicy(r *retrySpec) (*types.RetryPolicy, error) {
+	if r == nil {
+		return nil, nil
+	}
+
+	policy := &types.RetryPolicy{
+		MaxAttempts: r.MaxAttempts,
+		RetryOn:     r.RetryOn,
+	}
+
+	if len(r.Backoff) > 0 {
+		backoff, ok := types.RetryPolicy_Backoff_value[strings.ToUpper(r.Backoff)]
+		if !ok {
+			return nil, fmt.Errorf("unknown retry backoff '%v'", r.Backoff)
+		}
+		policy.Backoff = types.RetryPolicy_Backoff(backoff)
+	}
+
+	if len(r.Delay) > 0 {
+		delay, err := time.ParseDuration(r.Delay)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry delay: %v", err)
+		}
+		policy.Delay = ptypes.DurationProto(delay)
+	}
+
+	if len(r.MaxDelay) > 0 {
+		maxDelay, err := time.ParseDuration(r.MaxDelay)
+		if err != nil {
+			return nil, fmt.Errorf("invalid retry maxDelay: %v", err)
+		}
+		policy.MaxDelay = ptypes.DurationProto(maxDelay)
+	}
+
+	return policy, nil
+}
+
 // parseInputs parses the inputs of a task. This is typically a map[interface{}]interface{}.
 func parseInputs(i interface{}) (map[string]*typedvalues.TypedValue, error) {
 	if i == nil {
@@ -229,10 +376,14 @@ func convertInterfaceMaps(src map[interface{}]interface{}) map[string]interface{
 //
 
 type workflowSpec struct {
-	APIVersion  string
-	Description string
-	Output      string
-	Tasks       map[string]*taskSpec
+	APIVersion         string
+	Description        string
+	Output             string
+	Tasks              map[string]*taskSpec
+	Concurrency        *concurrencySpec
+	Inputs             map[string]*inputSpec
+	OutputSchema       *schemaSpec `yaml:"outputSchema"`
+	ExpressionLanguage string      `yaml:"expressionLanguage"`
 }
 
 type taskSpec struct {
@@ -240,4 +391,40 @@ type taskSpec struct {
 	Run      string
 	Inputs   interface{}
 	Requires []string
+	Await    int32
+	Retry    *retrySpec
+	OnError  string `yaml:"onError"`
+	Timeout  string
+}
+
+type concurrencySpec struct {
+	Max       int32            `yaml:"max"`
+	Functions map[string]int32 `yaml:"functions"`
+}
+
+// schemaSpec is the YAML representation of a TypeSchema.
+type schemaSpec struct {
+	Type        string                 `yaml:"type"`
+	Description string                 `yaml:"description"`
+	Properties  map[string]*schemaSpec `yaml:"properties"`
+	Required    []string               `yaml:"required"`
+	Items       *schemaSpec            `yaml:"items"`
+}
+
+// inputSpec declares an input of the workflow. Unlike in a schema, where the required properties are listed by the
+// object, each input indicates whether it is required.
+type inputSpec struct {
+	Type        string                 `yaml:"type"`
+	Description string                 `yaml:"description"`
+	Required    bool                   `yaml:"required"`
+	Properties  map[string]*schemaSpec `yaml:"properties"`
+	Items       *schemaSpec            `yaml:"items"`
+}
+
+type retrySpec struct {
+	MaxAttempts int32    `yaml:"maxAttempts"`
+	Backoff     string   `yaml:"backoff"`
+	Delay       string   `yaml:"delay"`
+	MaxDelay    string   `yaml:"maxDelay"`
+	RetryOn     []string `yaml:"retryOn"`
 }
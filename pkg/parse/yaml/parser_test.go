@@ -3,11 +3,14 @@ package yaml
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"fmt"
 
+	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues/controlflow"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -161,3 +164,214 @@ tasks:
 	assert.NoError(t, err)
 	assert.NotNil(t, wf)
 }
+
+func TestParseTaskWithRetry(t *testing.T) {
+
+	data := `
+tasks:
+  flaky:
+    run: bla
+    retry:
+      maxAttempts: 5
+      backoff: constant
+      delay: 500ms
+      maxDelay: 10s
+      retryOn:
+      - timeout
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+
+	retry := wf.Tasks["flaky"].Retry
+	assert.NotNil(t, retry)
+	assert.Equal(t, int32(5), retry.MaxAttempts)
+	assert.Equal(t, types.RetryPolicy_CONSTANT, retry.Backoff)
+	assert.Equal(t, ptypes.DurationProto(500*time.Millisecond), retry.Delay)
+	assert.Equal(t, ptypes.DurationProto(10*time.Second), retry.MaxDelay)
+	assert.Equal(t, []string{"timeout"}, retry.RetryOn)
+}
+
+func TestParseTaskWithInvalidRetry(t *testing.T) {
+
+	data := `
+tasks:
+  flaky:
+    run: bla
+    retry:
+      backoff: fibonacci
+`
+
+	_, err := Parse(strings.NewReader(data))
+	assert.Error(t, err)
+}
+
+func TestParseTaskWithErrorHandler(t *testing.T) {
+
+	data := `
+output: merge
+tasks:
+  fetch:
+    run: bla
+    onError: fallback
+  fallback:
+    run: noop
+    inputs: "{$.Tasks.fetch.Error}"
+  merge:
+    run: noop
+    await: 1
+    requires:
+    - fetch
+    - fallback
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, "fallback", wf.Tasks["fetch"].OnError)
+	assert.Equal(t, int32(1), wf.Tasks["merge"].Await)
+	assert.Equal(t, int32(0), wf.Tasks["fallback"].Await)
+}
+
+func TestParseTaskWithTimeout(t *testing.T) {
+
+	data := `
+tasks:
+  approval:
+    run: wait
+    timeout: 1h30m
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, ptypes.DurationProto(90*time.Minute), wf.Tasks["approval"].Timeout)
+
+	_, err = Parse(strings.NewReader(strings.Replace(data, "1h30m", "soon", 1)))
+	assert.Error(t, err)
+}
+
+func TestParseWorkflowWithConcurrency(t *testing.T) {
+
+	data := `
+output: resize
+concurrency:
+  max: 10
+  functions:
+    fission://resize: 2
+tasks:
+  resize:
+    run: resize
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(10), wf.Concurrency.Max)
+	assert.Equal(t, map[string]int32{"fission://resize": 2}, wf.Concurrency.Functions)
+}
+
+func TestParseWorkflowWithSchemas(t *testing.T) {
+
+	data := `
+output: greet
+inputs:
+  name:
+    type: string
+    required: true
+  options:
+    type: object
+    properties:
+      loud:
+        type: boolean
+outputSchema:
+  type: string
+tasks:
+  greet:
+    run: noop
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, "object", wf.Inputs.Type)
+	assert.Equal(t, []string{"name"}, wf.Inputs.Required)
+	assert.Equal(t, "string", wf.Inputs.Properties["name"].Type)
+	assert.Equal(t, "boolean", wf.Inputs.Properties["options"].Properties["loud"].Type)
+	assert.Equal(t, "string", wf.Output.Type)
+}
+
+func TestParseWorkflowWithExpressionLanguage(t *testing.T) {
+
+	data := `
+output: greet
+expressionLanguage: path
+tasks:
+  greet:
+    run: noop
+    inputs: "{ param('name') }"
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, "path", wf.ExpressionLanguage)
+}
+
+func TestParseWorkflowInfersDependencies(t *testing.T) {
+
+	data := `
+output: last
+tasks:
+  first:
+    run: noop
+  second:
+    run: noop
+    inputs: "{ output('first') }"
+  last:
+    run: noop
+    inputs:
+      a: "{ $.Tasks.first.Output }"
+      b: "{ path: output('second') }"
+      c: "{ output('undefined') }"
+    requires:
+    - first
+`
+
+	wf, err := Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*types.TaskDependencyParameters{
+		"first": {Type: types.TaskDependencyParameters_DATA},
+	}, wf.Tasks["second"].Requires)
+	assert.Equal(t, int32(1), wf.Tasks["second"].Await)
+	assert.Len(t, wf.Tasks["last"].Requires, 2)
+	assert.Contains(t, wf.Tasks["last"].Requires, "second")
+	assert.Equal(t, int32(2), wf.Tasks["last"].Await)
+
+	// Without inference, only the explicit dependencies are used.
+	parser := NewParser(WithoutDependencyInference())
+	wf, err = parser.Parse(strings.NewReader(data))
+	assert.NoError(t, err)
+	assert.Empty(t, wf.Tasks["second"].Requires)
+	assert.Len(t, wf.Tasks["last"].Requires, 1)
+}
+
+func TestInferDependenciesIgnoresCyclesAndErrorHandlers(t *testing.T) {
+	spec := &types.WorkflowSpec{
+		Tasks: map[string]*types.TaskSpec{
+			"a": {
+				FunctionRef: "noop",
+				Inputs:      types.Input("{ output('b') }"),
+				OnError:     "handler",
+			},
+			"b": {
+				FunctionRef: "noop",
+				Requires:    types.Require("a"),
+				Await:       1,
+			},
+			"handler": {
+				FunctionRef: "noop",
+				Inputs:      types.Input("{ $.Tasks.a.Status }"),
+			},
+		},
+	}
+	InferDependencies(spec)
+	assert.Empty(t, spec.Tasks["a"].Requires)
+	assert.Empty(t, spec.Tasks["handler"].Requires)
+	assert.Equal(t, int32(1), spec.Tasks["b"].Await)
+}
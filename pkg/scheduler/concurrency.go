@@ -0,0 +1,185 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var metricThrottledTasks = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: "workflows",
+	Subsystem: "scheduler",
+	Name:      "throttled_tasks_total",
+	Help:      "Number of times that the execution of a task was postponed due to a concurrency limit",
+})
+
+func init() {
+	prometheus.MustRegister(metricThrottledTasks)
+}
+
+// ConcurrencyLimits contains the default concurrency limits of the workflow engine. A limit of 0 (or a missing
+// entry) means that the number of concurrently running tasks is not limited.
+type ConcurrencyLimits struct {
+	// Workflow is the maximum number of concurrently running tasks per workflow. It is overridden by the concurrency
+	// policy in the spec of a workflow.
+	Workflow int
+
+	// Runtimes contains the maximum number of concurrently running tasks per function runtime, such as 'fission'.
+	Runtimes map[string]int
+
+	// Functions contains the maximum number of concurrently running tasks per function, across all workflows. The
+	// key is the resolved function reference, including the runtime (e.g. 'fission://resize').
+	Functions map[string]int
+}
+
+// ParseConcurrencyLimit parses a limit formatted as <key>=<limit>, such as 'fission=100'.
+func ParseConcurrencyLimit(s string) (key string, limit int, err error) {
+	i := strings.LastIndex(s, "=")
+	if i <= 0 {
+		return "", 0, fmt.Errorf("invalid concurrency limit '%s': expected <key>=<limit>", s)
+	}
+	limit, err = strconv.Atoi(s[i+1:])
+	if err != nil || limit < 0 {
+		return "", 0, fmt.Errorf("invalid concurrency limit '%s': limit should be a non-negative number", s)
+	}
+	return s[:i], limit, nil
+}
+
+// ConcurrencyLimiter keeps track of the running tasks to enforce the concurrency limits per workflow, per runtime and
+// per function. It is shared by all invocations.
+//
+// Rather than failing the tasks that would exceed a limit, the limiter rejects them, upon which the controller keeps
+// them queued in the invocation. The invocations that had tasks rejected are reported to the release listener once
+// running tasks have been released, allowing the queued tasks to be retried.
+type ConcurrencyLimiter struct {
+	limits    ConcurrencyLimits
+	mu        sync.Mutex
+	active    map[string]int
+	waiting   map[string]struct{} // invocation IDs
+	onRelease func(invocationIDs []string)
+}
+
+func NewConcurrencyLimiter(limits ConcurrencyLimits) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		limits:  limits,
+		active:  map[string]int{},
+		waiting: map[string]struct{}{},
+	}
+}
+
+// OnRelease sets the listener that is called with the IDs of the invocations with queued tasks, after a running task
+// has been released.
+func (l *ConcurrencyLimiter) OnRelease(fn func(invocationIDs []string)) {
+	l.mu.Lock()
+	l.onRelease = fn
+	l.mu.Unlock()
+}
+
+// TryAcquire reserves capacity for running the task of the invocation. If one of the limits that apply to the task
+// has been reached, it returns false. Otherwise, the returned release function must be called once the task has
+// finished running.
+func (l *ConcurrencyLimiter) TryAcquire(invocation *types.WorkflowInvocation, taskID string) (release func(),
+	ok bool) {
+	limits := l.limitsOf(invocation, taskID)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for key, max := range limits {
+		if l.active[key] >= max {
+			l.waiting[invocation.ID()] = struct{}{}
+			metricThrottledTasks.Inc()
+			return nil, false
+		}
+	}
+	for key := range limits {
+		l.active[key]++
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.release(limits)
+		})
+	}, true
+}
+
+// Active returns the number of running tasks for the given limit key.
+func (l *ConcurrencyLimiter) Active(key string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.active[key]
+}
+
+func (l *ConcurrencyLimiter) release(limits map[string]int) {
+	l.mu.Lock()
+	for key := range limits {
+		l.active[key]--
+		if l.active[key] <= 0 {
+			delete(l.active, key)
+		}
+	}
+	var waiting []string
+	for invocationID := range l.waiting {
+		waiting = append(waiting, invocationID)
+	}
+	l.waiting = map[string]struct{}{}
+	onRelease := l.onRelease
+	l.mu.Unlock()
+
+	if onRelease != nil && len(waiting) > 0 {
+		onRelease(waiting)
+	}
+}
+
+// limitsOf returns the limits that apply to the task, keyed by what the limit applies to.
+func (l *ConcurrencyLimiter) limitsOf(invocation *types.WorkflowInvocation, taskID string) map[string]int {
+	limits := map[string]int{}
+	add := func(key string, max int) {
+		if max > 0 {
+			limits[key] = max
+		}
+	}
+
+	workflowID := invocation.GetSpec().GetWorkflowId()
+	policy := invocation.Workflow().GetSpec().GetConcurrency()
+	if policy.GetMax() > 0 {
+		add(WorkflowLimitKey(workflowID), int(policy.GetMax()))
+	} else {
+		add(WorkflowLimitKey(workflowID), l.limits.Workflow)
+	}
+
+	task, _ := invocation.Task(taskID)
+	fnRef := task.GetStatus().GetFnRef()
+	if fnRef == nil {
+		return limits
+	}
+	fn := fnRef.Format()
+	add(RuntimeLimitKey(fnRef.Runtime), l.limits.Runtimes[fnRef.Runtime])
+	add(FunctionLimitKey(fn), l.limits.Functions[fn])
+
+	// The function limits of the workflow can refer to the function as written in the task or to the resolved
+	// reference.
+	for _, ref := range []string{task.GetSpec().GetFunctionRef(), fn} {
+		if max, ok := policy.GetFunctions()[ref]; ok {
+			add(WorkflowLimitKey(workflowID)+"/"+FunctionLimitKey(fn), int(max))
+			break
+		}
+	}
+	return limits
+}
+
+func WorkflowLimitKey(workflowID string) string {
+	return "workflow/" + workflowID
+}
+
+func RuntimeLimitKey(runtime string) string {
+	return "runtime/" + runtime
+}
+
+func FunctionLimitKey(fn string) string {
+	return "function/" + fn
+}
@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupConcurrencyInvocation creates an invocation of a workflow with a task 'a' running fission://fn and a task 'b'
+// running internal://noop.
+func setupConcurrencyInvocation(invocationID string, policy *types.ConcurrencyPolicy) *types.WorkflowInvocation {
+	wf := types.NewWorkflow("wf")
+	wf.Spec.Concurrency = policy
+	wf.Spec.AddTask("a", types.NewTaskSpec("fn"))
+	wf.Spec.AddTask("b", types.NewTaskSpec("noop"))
+	wf.Status.Tasks = map[string]*types.Task{
+		"a": {Status: &types.TaskStatus{FnRef: &types.FnRef{Runtime: "fission", ID: "fn"}}},
+		"b": {Status: &types.TaskStatus{FnRef: &types.FnRef{Runtime: "internal", ID: "noop"}}},
+	}
+
+	invocation := types.NewWorkflowInvocation(wf.ID(), invocationID, time.Now().Add(time.Minute))
+	invocation.Spec.Workflow = wf
+	return invocation
+}
+
+func TestConcurrencyLimiter_Unlimited(t *testing.T) {
+	limiter := NewConcurrencyLimiter(ConcurrencyLimits{})
+	invocation := setupConcurrencyInvocation("wi", nil)
+
+	for i := 0; i < 100; i++ {
+		_, ok := limiter.TryAcquire(invocation, "a")
+		assert.True(t, ok)
+	}
+}
+
+func TestConcurrencyLimiter_Workflow(t *testing.T) {
+	limiter := NewConcurrencyLimiter(ConcurrencyLimits{Workflow: 1})
+	invocation := setupConcurrencyInvocation("wi", nil)
+
+	release, ok := limiter.TryAcquire(invocation, "a")
+	assert.True(t, ok)
+	_, ok = limiter.TryAcquire(setupConcurrencyInvocation("wi2", nil), "b")
+	assert.False(t, ok)
+
+	// The policy of the workflow overrides the default limit.
+	_, ok = limiter.TryAcquire(setupConcurrencyInvocation("wi3", &types.ConcurrencyPolicy{Max: 2}), "b")
+	assert.True(t, ok)
+
+	release()
+	release() // releasing twice should have no effect
+	assert.Equal(t, 1, limiter.Active(WorkflowLimitKey("wf")))
+}
+
+func TestConcurrencyLimiter_RuntimeAndFunction(t *testing.T) {
+	limiter := NewConcurrencyLimiter(ConcurrencyLimits{
+		Runtimes:  map[string]int{"fission": 2},
+		Functions: map[string]int{"fission://fn": 1},
+	})
+	invocation := setupConcurrencyInvocation("wi", nil)
+
+	_, ok := limiter.TryAcquire(invocation, "a")
+	assert.True(t, ok)
+	_, ok = limiter.TryAcquire(invocation, "a")
+	assert.False(t, ok)
+	_, ok = limiter.TryAcquire(invocation, "b")
+	assert.True(t, ok)
+	assert.Equal(t, 1, limiter.Active(RuntimeLimitKey("fission")))
+}
+
+func TestConcurrencyLimiter_WorkflowFunction(t *testing.T) {
+	limiter := NewConcurrencyLimiter(ConcurrencyLimits{})
+	policy := &types.ConcurrencyPolicy{
+		Functions: map[string]int32{"fn": 1},
+	}
+	invocation := setupConcurrencyInvocation("wi", policy)
+
+	_, ok := limiter.TryAcquire(invocation, "a")
+	assert.True(t, ok)
+	_, ok = limiter.TryAcquire(invocation, "a")
+	assert.False(t, ok)
+
+	// The limit is scoped to the workflow.
+	_, ok = limiter.TryAcquire(setupConcurrencyInvocation("wi2", policy), "a")
+	assert.False(t, ok)
+	other := setupConcurrencyInvocation("wi3", policy)
+	other.Spec.WorkflowId = "other"
+	_, ok = limiter.TryAcquire(other, "a")
+	assert.True(t, ok)
+}
+
+func TestConcurrencyLimiter_OnRelease(t *testing.T) {
+	limiter := NewConcurrencyLimiter(ConcurrencyLimits{Workflow: 1})
+	var released []string
+	limiter.OnRelease(func(invocationIDs []string) {
+		released = append(released, invocationIDs...)
+	})
+
+	release, ok := limiter.TryAcquire(setupConcurrencyInvocation("wi", nil), "a")
+	assert.True(t, ok)
+	_, ok = limiter.TryAcquire(setupConcurrencyInvocation("wi2", nil), "a")
+	assert.False(t, ok)
+
+	release()
+	assert.Equal(t, []string{"wi2"}, released)
+}
+
+func TestParseConcurrencyLimit(t *testing.T) {
+	key, limit, err := ParseConcurrencyLimit("fission://fn=5")
+	assert.NoError(t, err)
+	assert.Equal(t, "fission://fn", key)
+	assert.Equal(t, 5, limit)
+
+	for _, s := range []string{"fission", "=5", "fission=-1", "fission=many"} {
+		_, _, err := ParseConcurrencyLimit(s)
+		assert.Error(t, err, s)
+	}
+}
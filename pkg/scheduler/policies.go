@@ -14,7 +14,7 @@ var DefaultPolicy = NewHorizonPolicy()
 // HorizonPolicy is the default policy of the workflow engine. It solely schedules tasks that are on the scheduling horizon.
 //
 // The scheduling horizon is the set of tasks that only depend on tasks that have already completed.
-// If a task has failed this policy simply fails the workflow
+// If a task without an error handler has failed this policy simply fails the workflow
 type HorizonPolicy struct {
 }
 
@@ -25,8 +25,8 @@ func NewHorizonPolicy() *HorizonPolicy {
 func (p *HorizonPolicy) Evaluate(invocation *types.WorkflowInvocation) (*Schedule, error) {
 	schedule := &Schedule{InvocationId: invocation.ID(), CreatedAt: ptypes.TimestampNow()}
 
-	// If there are failed tasks halt the workflow
-	if failedTasks := getFailedTasks(invocation); len(failedTasks) > 0 {
+	// If there are unhandled failed tasks halt the workflow
+	if failedTasks := getUnhandledFailedTasks(invocation); len(failedTasks) > 0 {
 		for _, failedTask := range failedTasks {
 			msg := fmt.Sprintf("Task '%v' failed", failedTask.ID())
 			if err := failedTask.GetStatus().GetError(); err != nil {
@@ -39,10 +39,9 @@ func (p *HorizonPolicy) Evaluate(invocation *types.WorkflowInvocation) (*Schedul
 
 	// Find and schedule all tasks on the scheduling horizon
 	openTasks := getOpenTasks(invocation)
-	depGraph := graph.Parse(graph.NewTaskInstanceIterator(openTasks))
-	horizon := graph.Roots(depGraph)
-	for _, node := range horizon {
-		schedule.AddRunTask(newRunTaskAction(node.(*graph.TaskInvocationNode).Task().ID()))
+	horizon := getHorizon(schedule, invocation, openTasks)
+	for _, taskRun := range horizon {
+		schedule.AddRunTask(newRunTaskAction(taskRun.Task().ID()))
 	}
 	return schedule, nil
 }
@@ -67,8 +66,8 @@ func NewPrewarmAllPolicy(coldstartDuration time.Duration) *PrewarmAllPolicy {
 func (p *PrewarmAllPolicy) Evaluate(invocation *types.WorkflowInvocation) (*Schedule, error) {
 	schedule := &Schedule{InvocationId: invocation.ID(), CreatedAt: ptypes.TimestampNow()}
 
-	// If there are failed tasks halt the workflow
-	if failedTasks := getFailedTasks(invocation); len(failedTasks) > 0 {
+	// If there are unhandled failed tasks halt the workflow
+	if failedTasks := getUnhandledFailedTasks(invocation); len(failedTasks) > 0 {
 		for _, failedTask := range failedTasks {
 			msg := fmt.Sprintf("Task '%v' failed", failedTask.ID())
 			if err := failedTask.GetStatus().GetError(); err != nil {
@@ -81,10 +80,8 @@ func (p *PrewarmAllPolicy) Evaluate(invocation *types.WorkflowInvocation) (*Sche
 
 	// Find and schedule all tasks on the scheduling horizon
 	openTasks := getOpenTasks(invocation)
-	depGraph := graph.Parse(graph.NewTaskInstanceIterator(openTasks))
-	horizon := graph.Roots(depGraph)
-	for _, node := range horizon {
-		taskRun := node.(*graph.TaskInvocationNode)
+	horizon := getHorizon(schedule, invocation, openTasks)
+	for _, taskRun := range horizon {
 		schedule.AddRunTask(newRunTaskAction(taskRun.TaskInvocation.ID()))
 		delete(openTasks, taskRun.GetMetadata().GetId())
 	}
@@ -117,8 +114,8 @@ func NewPrewarmHorizonPolicy(coldstartDuration time.Duration) *PrewarmHorizonPol
 func (p *PrewarmHorizonPolicy) Evaluate(invocation *types.WorkflowInvocation) (*Schedule, error) {
 	schedule := &Schedule{InvocationId: invocation.ID(), CreatedAt: ptypes.TimestampNow()}
 
-	// If there are failed tasks halt the workflow
-	if failedTasks := getFailedTasks(invocation); len(failedTasks) > 0 {
+	// If there are unhandled failed tasks halt the workflow
+	if failedTasks := getUnhandledFailedTasks(invocation); len(failedTasks) > 0 {
 		for _, failedTask := range failedTasks {
 			msg := fmt.Sprintf("Task '%v' failed", failedTask.ID())
 			if err := failedTask.GetStatus().GetError(); err != nil {
@@ -131,10 +128,8 @@ func (p *PrewarmHorizonPolicy) Evaluate(invocation *types.WorkflowInvocation) (*
 
 	// Find and schedule all tasks on the scheduling horizon
 	openTasks := getOpenTasks(invocation)
-	depGraph := graph.Parse(graph.NewTaskInstanceIterator(openTasks))
-	horizon := graph.Roots(depGraph)
-	for _, node := range horizon {
-		taskRun := node.(*graph.TaskInvocationNode)
+	horizon := getHorizon(schedule, invocation, openTasks)
+	for _, taskRun := range horizon {
 		schedule.AddRunTask(newRunTaskAction(taskRun.TaskInvocation.ID()))
 		delete(openTasks, taskRun.GetMetadata().GetId())
 	}
@@ -152,31 +147,119 @@ func (p *PrewarmHorizonPolicy) Evaluate(invocation *types.WorkflowInvocation) (*
 	return schedule, nil
 }
 
-func getFailedTasks(invocation *types.WorkflowInvocation) []*types.TaskInvocation {
+// getUnhandledFailedTasks returns the failed tasks that will not be retried and do not have an error handler.
+func getUnhandledFailedTasks(invocation *types.WorkflowInvocation) []*types.TaskInvocation {
 	var failedTasks []*types.TaskInvocation
-	for _, task := range invocation.TaskInvocations() {
-		if task.GetStatus().GetStatus() == types.TaskInvocationStatus_FAILED {
-			failedTasks = append(failedTasks, task)
+	for id, task := range invocation.TaskInvocations() {
+		if task.GetStatus().GetStatus() != types.TaskInvocationStatus_FAILED || awaitingRetry(invocation, id) {
+			continue
 		}
+		if spec, ok := invocation.Task(id); ok && len(spec.GetSpec().GetOnError()) > 0 {
+			continue
+		}
+		failedTasks = append(failedTasks, task)
 	}
 	return failedTasks
 }
 
+// getHorizon returns the open tasks that only depend on tasks that have already completed. Tasks on the horizon that
+// will not be run at all, due to the error handling in the workflow, are added to the schedule to be skipped instead.
+func getHorizon(schedule *Schedule, invocation *types.WorkflowInvocation,
+	openTasks map[string]*types.TaskInvocation) []*graph.TaskInvocationNode {
+	handlers := invocation.ErrorHandlers()
+	depGraph := graph.Parse(graph.NewTaskInstanceIterator(openTasks))
+	var horizon []*graph.TaskInvocationNode
+	for _, node := range graph.Roots(depGraph) {
+		taskRun := node.(*graph.TaskInvocationNode)
+		taskID := taskRun.TaskInvocation.ID()
+
+		// Error handlers can only run once the task that they handle has failed.
+		if handledTaskID, ok := handlers[taskID]; ok {
+			handled, ok := invocation.TaskInvocation(handledTaskID)
+			if !ok {
+				continue
+			}
+			if awaitingRetry(invocation, handledTaskID) {
+				continue
+			}
+			if handled.GetStatus().GetStatus() == types.TaskInvocationStatus_FAILED {
+				horizon = append(horizon, taskRun)
+			} else if handled.GetStatus().Finished() {
+				schedule.AddSkipTask(newSkipTaskAction(taskID, fmt.Sprintf("task '%v' did not fail", handledTaskID)))
+			}
+			continue
+		}
+
+		// Like open dependencies, dependencies that are still in progress, such as tasks awaiting a signal or a
+		// retry, block the task from running.
+		if hasDependencyInProgress(invocation, taskRun.Task()) {
+			continue
+		}
+
+		if !awaitSatisfiable(invocation, taskRun.Task()) {
+			schedule.AddSkipTask(newSkipTaskAction(taskID, "not enough dependencies succeeded"))
+			continue
+		}
+		horizon = append(horizon, taskRun)
+	}
+	return horizon
+}
+
+func hasDependencyInProgress(invocation *types.WorkflowInvocation, task *types.Task) bool {
+	for depID := range task.GetSpec().GetRequires() {
+		dep, ok := invocation.TaskInvocation(depID)
+		if ok && dep.GetStatus().GetStatus() == types.TaskInvocationStatus_IN_PROGRESS {
+			return true
+		}
+		if awaitingRetry(invocation, depID) {
+			return true
+		}
+	}
+	return false
+}
+
+// awaitingRetry checks if the task has failed, but will be retried according to its retry policy.
+func awaitingRetry(invocation *types.WorkflowInvocation, taskID string) bool {
+	task, ok := invocation.Task(taskID)
+	if !ok {
+		return false
+	}
+	taskRun, ok := invocation.TaskInvocation(taskID)
+	return ok && task.GetSpec().GetRetry().ShouldRetry(taskRun.GetStatus())
+}
+
+// awaitSatisfiable checks if enough of the dependencies of the task can still succeed to satisfy its await.
+func awaitSatisfiable(invocation *types.WorkflowInvocation, task *types.Task) bool {
+	requires := task.GetSpec().GetRequires()
+	await := int(task.GetSpec().GetAwait())
+	if await <= 0 || await > len(requires) {
+		await = len(requires)
+	}
+	var satisfiable int
+	for depID := range requires {
+		dep, ok := invocation.TaskInvocation(depID)
+		if !ok || !dep.GetStatus().Finished() || dep.GetStatus().Successful() {
+			satisfiable++
+		}
+	}
+	return satisfiable >= await
+}
+
 func getOpenTasks(invocation *types.WorkflowInvocation) map[string]*types.TaskInvocation {
 	openTasks := map[string]*types.TaskInvocation{}
 	for id, task := range invocation.Tasks() {
 		taskRun, ok := invocation.TaskInvocation(id)
-		if !ok {
-			taskRun = &types.TaskInvocation{
-				Metadata: types.NewObjectMetadata(id),
-				Spec:     types.NewTaskInvocationSpec(invocation, task, time.Now()),
-				Status: &types.TaskInvocationStatus{
-					Status: types.TaskInvocationStatus_UNKNOWN,
-				},
-			}
+		if ok && taskRun.GetStatus().GetStatus() != types.TaskInvocationStatus_UNKNOWN {
+			continue
 		}
-		if taskRun.GetStatus().GetStatus() == types.TaskInvocationStatus_UNKNOWN {
-			openTasks[id] = taskRun
+		// A task run in the UNKNOWN state has been reset for a retry; like unstarted tasks, it is rebuilt from the task.
+		openTasks[id] = &types.TaskInvocation{
+			Metadata: types.NewObjectMetadata(id),
+			Spec:     types.NewTaskInvocationSpec(invocation, task, time.Now()),
+			Status: &types.TaskInvocationStatus{
+				Status:  types.TaskInvocationStatus_UNKNOWN,
+				Retries: taskRun.GetStatus().GetRetries(),
+			},
 		}
 	}
 	return openTasks
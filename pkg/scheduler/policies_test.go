@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupInvocation creates an invocation of a workflow in which task 'a' is handled by 'handler'. Task 'b' depends on
+// 'a', whereas 'c' depends on the error handler.
+func setupInvocation() *types.WorkflowInvocation {
+	wf := types.NewWorkflow("wf")
+	wf.Spec.OutputTask = "b"
+	wf.Spec.AddTask("a", &types.TaskSpec{FunctionRef: "fn", OnError: "handler"})
+	wf.Spec.AddTask("b", types.NewTaskSpec("fn").Require("a"))
+	wf.Spec.AddTask("handler", types.NewTaskSpec("fn"))
+	wf.Spec.AddTask("c", types.NewTaskSpec("fn").Require("handler"))
+
+	invocation := types.NewWorkflowInvocation(wf.ID(), "wi", time.Now().Add(time.Minute))
+	invocation.Spec.Workflow = wf
+	return invocation
+}
+
+func setTaskStatus(invocation *types.WorkflowInvocation, taskID string, status types.TaskInvocationStatus_Status) {
+	if invocation.Status.Tasks == nil {
+		invocation.Status.Tasks = map[string]*types.TaskInvocation{}
+	}
+	invocation.Status.Tasks[taskID] = &types.TaskInvocation{
+		Metadata: types.NewObjectMetadata(taskID),
+		Status: &types.TaskInvocationStatus{
+			Status: status,
+			Error:  &types.Error{Message: "expected error"},
+		},
+	}
+}
+
+func runTaskIDs(schedule *Schedule) []string {
+	var ids []string
+	for _, action := range schedule.GetRunTasks() {
+		ids = append(ids, action.TaskID)
+	}
+	return ids
+}
+
+func skipTaskIDs(schedule *Schedule) []string {
+	var ids []string
+	for _, action := range schedule.GetSkipTasks() {
+		ids = append(ids, action.TaskID)
+	}
+	return ids
+}
+
+func TestHorizonPolicy_ErrorHandlerWaits(t *testing.T) {
+	schedule, err := NewHorizonPolicy().Evaluate(setupInvocation())
+	assert.NoError(t, err)
+	assert.Nil(t, schedule.GetAbort())
+	assert.Equal(t, []string{"a"}, runTaskIDs(schedule))
+	assert.Empty(t, schedule.GetSkipTasks())
+}
+
+func TestHorizonPolicy_ErrorHandlerOnFailure(t *testing.T) {
+	invocation := setupInvocation()
+	setTaskStatus(invocation, "a", types.TaskInvocationStatus_FAILED)
+
+	schedule, err := NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Nil(t, schedule.GetAbort())
+	assert.Equal(t, []string{"handler"}, runTaskIDs(schedule))
+	assert.Equal(t, []string{"b"}, skipTaskIDs(schedule))
+}
+
+func TestHorizonPolicy_ErrorHandlerOnSuccess(t *testing.T) {
+	invocation := setupInvocation()
+	setTaskStatus(invocation, "a", types.TaskInvocationStatus_SUCCEEDED)
+
+	schedule, err := NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, runTaskIDs(schedule))
+	assert.Equal(t, []string{"handler"}, skipTaskIDs(schedule))
+
+	// Tasks depending on the skipped error handler should be skipped as well
+	setTaskStatus(invocation, "b", types.TaskInvocationStatus_SUCCEEDED)
+	setTaskStatus(invocation, "handler", types.TaskInvocationStatus_SKIPPED)
+	schedule, err = NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Empty(t, schedule.GetRunTasks())
+	assert.Equal(t, []string{"c"}, skipTaskIDs(schedule))
+}
+
+func TestHorizonPolicy_UnhandledFailure(t *testing.T) {
+	invocation := setupInvocation()
+	setTaskStatus(invocation, "a", types.TaskInvocationStatus_FAILED)
+	setTaskStatus(invocation, "handler", types.TaskInvocationStatus_FAILED)
+
+	schedule, err := NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.NotNil(t, schedule.GetAbort())
+	assert.Equal(t, "expected error", schedule.GetAbort().GetReason())
+}
+
+func TestHorizonPolicy_DependencyInProgress(t *testing.T) {
+	invocation := setupInvocation()
+	setTaskStatus(invocation, "a", types.TaskInvocationStatus_IN_PROGRESS)
+
+	schedule, err := NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Nil(t, schedule.GetAbort())
+	assert.Empty(t, schedule.GetRunTasks())
+	assert.Empty(t, schedule.GetSkipTasks())
+}
+
+func TestHorizonPolicy_AwaitingRetry(t *testing.T) {
+	invocation := setupInvocation()
+	invocation.Spec.Workflow.Spec.AddTask("d", types.NewTaskSpec("fn"))
+	invocation.Spec.Workflow.Spec.Tasks["a"].Retry = &types.RetryPolicy{MaxAttempts: 2}
+	setTaskStatus(invocation, "a", types.TaskInvocationStatus_FAILED)
+
+	// Neither the dependents nor the error handler of the task should run while it awaits its retry.
+	schedule, err := NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Nil(t, schedule.GetAbort())
+	assert.Equal(t, []string{"d"}, runTaskIDs(schedule))
+	assert.Empty(t, schedule.GetSkipTasks())
+
+	// Once the task has no attempts left, the failure is handled.
+	invocation.Status.Tasks["a"].Status.Retries = 1
+	schedule, err = NewHorizonPolicy().Evaluate(invocation)
+	assert.NoError(t, err)
+	assert.Nil(t, schedule.GetAbort())
+	assert.ElementsMatch(t, []string{"handler", "d"}, runTaskIDs(schedule))
+	assert.Equal(t, []string{"b"}, skipTaskIDs(schedule))
+}
@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"strings"
+
+	"github.com/fission/fission-workflows/pkg/types"
+)
+
+// PriorityWeights contains the default weights of the priority classes of invocations. An invocation of a class with
+// weight 4 receives four times the capacity of an invocation of a class with weight 1, if both have work queued.
+var PriorityWeights = map[string]int{
+	PriorityClass(types.WorkflowInvocationSpec_HIGH):   16,
+	PriorityClass(types.WorkflowInvocationSpec_NORMAL): 4,
+	PriorityClass(types.WorkflowInvocationSpec_LOW):    1,
+}
+
+// PriorityClass returns the name of the priority class, such as 'high', which is used in metrics and in queues.
+func PriorityClass(priority types.WorkflowInvocationSpec_Priority) string {
+	return strings.ToLower(priority.String())
+}
+
+// PriorityClassOf returns the priority class of the invocation.
+func PriorityClassOf(invocation *types.WorkflowInvocation) string {
+	return PriorityClass(invocation.GetSpec().GetPriority())
+}
@@ -76,6 +76,13 @@ func newAbortAction(msg string) *AbortAction {
 	}
 }
 
+func newSkipTaskAction(taskID string, reason string) *SkipTaskAction {
+	return &SkipTaskAction{
+		TaskID: taskID,
+		Reason: reason,
+	}
+}
+
 func newPrepareTaskAction(taskID string, expectedAt time.Time) *PrepareTaskAction {
 	ts, _ := ptypes.TimestampProto(expectedAt)
 	return &PrepareTaskAction{
@@ -92,6 +99,10 @@ func (m *Schedule) AddPrepareTask(action *PrepareTaskAction) {
 	m.PrepareTasks = append(m.PrepareTasks, action)
 }
 
+func (m *Schedule) AddSkipTask(action *SkipTaskAction) {
+	m.SkipTasks = append(m.SkipTasks, action)
+}
+
 func (m *Schedule) Actions() (actions []interface{}) {
 	if m.Abort != nil {
 		actions = append(actions, m.Abort)
@@ -106,6 +117,11 @@ func (m *Schedule) Actions() (actions []interface{}) {
 			actions = append(actions, t)
 		}
 	}
+	if len(m.SkipTasks) > 0 {
+		for _, t := range m.SkipTasks {
+			actions = append(actions, t)
+		}
+	}
 	return actions
 }
 
@@ -12,6 +12,7 @@ It has these top-level messages:
 	AbortAction
 	RunTaskAction
 	PrepareTaskAction
+	SkipTaskAction
 */
 package scheduler
 
@@ -43,6 +44,7 @@ type Schedule struct {
 	Abort        *AbortAction               `protobuf:"bytes,4,opt,name=abort" json:"abort,omitempty"`
 	RunTasks     []*RunTaskAction           `protobuf:"bytes,5,rep,name=runTasks" json:"runTasks,omitempty"`
 	PrepareTasks []*PrepareTaskAction       `protobuf:"bytes,6,rep,name=prepareTasks" json:"prepareTasks,omitempty"`
+	SkipTasks    []*SkipTaskAction          `protobuf:"bytes,7,rep,name=skipTasks" json:"skipTasks,omitempty"`
 }
 
 func (m *Schedule) Reset()                    { *m = Schedule{} }
@@ -85,6 +87,13 @@ func (m *Schedule) GetPrepareTasks() []*PrepareTaskAction {
 	return nil
 }
 
+func (m *Schedule) GetSkipTasks() []*SkipTaskAction {
+	if m != nil {
+		return m.SkipTasks
+	}
+	return nil
+}
+
 type AbortAction struct {
 	Reason string `protobuf:"bytes,1,opt,name=reason" json:"reason,omitempty"`
 }
@@ -142,11 +151,36 @@ func (m *PrepareTaskAction) GetExpectedAt() *google_protobuf.Timestamp {
 	return nil
 }
 
+type SkipTaskAction struct {
+	TaskID string `protobuf:"bytes,1,opt,name=taskID" json:"taskID,omitempty"`
+	Reason string `protobuf:"bytes,2,opt,name=reason" json:"reason,omitempty"`
+}
+
+func (m *SkipTaskAction) Reset()                    { *m = SkipTaskAction{} }
+func (m *SkipTaskAction) String() string            { return proto.CompactTextString(m) }
+func (*SkipTaskAction) ProtoMessage()               {}
+func (*SkipTaskAction) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *SkipTaskAction) GetTaskID() string {
+	if m != nil {
+		return m.TaskID
+	}
+	return ""
+}
+
+func (m *SkipTaskAction) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*Schedule)(nil), "fission.workflows.scheduler.Schedule")
 	proto.RegisterType((*AbortAction)(nil), "fission.workflows.scheduler.AbortAction")
 	proto.RegisterType((*RunTaskAction)(nil), "fission.workflows.scheduler.RunTaskAction")
 	proto.RegisterType((*PrepareTaskAction)(nil), "fission.workflows.scheduler.PrepareTaskAction")
+	proto.RegisterType((*SkipTaskAction)(nil), "fission.workflows.scheduler.SkipTaskAction")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -224,29 +258,31 @@ var _Scheduler_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("pkg/scheduler/scheduler.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 374 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x4f, 0x4b, 0xeb, 0x40,
-	0x14, 0xc5, 0x5f, 0xfb, 0x5e, 0x4b, 0x73, 0xd3, 0xb7, 0x70, 0x16, 0x12, 0x22, 0x62, 0x09, 0x14,
-	0x83, 0xe2, 0x04, 0xea, 0x46, 0xba, 0x10, 0x2a, 0x22, 0x74, 0x27, 0xb1, 0x20, 0xb8, 0x72, 0x92,
-	0x4e, 0xd3, 0x90, 0x3f, 0x13, 0x66, 0x26, 0xad, 0x7e, 0x3f, 0x3f, 0x98, 0x24, 0x93, 0xa4, 0x2d,
-	0x6a, 0x70, 0x93, 0xe4, 0x0e, 0xbf, 0x73, 0xce, 0xcd, 0x9d, 0x0b, 0xa7, 0x59, 0x14, 0x38, 0xc2,
-	0x5f, 0xd3, 0x65, 0x1e, 0x53, 0xbe, 0xfb, 0xc2, 0x19, 0x67, 0x92, 0xa1, 0x93, 0x55, 0x28, 0x44,
-	0xc8, 0x52, 0xbc, 0x65, 0x3c, 0x5a, 0xc5, 0x6c, 0x2b, 0x70, 0x83, 0x98, 0xd3, 0x20, 0x94, 0xeb,
-	0xdc, 0xc3, 0x3e, 0x4b, 0x9c, 0x8a, 0xab, 0xdf, 0x57, 0x0d, 0xef, 0x14, 0x01, 0xf2, 0x3d, 0xa3,
-	0x42, 0x3d, 0x95, 0xb1, 0x79, 0x16, 0x30, 0x16, 0xc4, 0xd4, 0x29, 0x2b, 0x2f, 0x5f, 0x39, 0x32,
-	0x4c, 0xa8, 0x90, 0x24, 0xc9, 0x14, 0x60, 0x7d, 0x74, 0x61, 0xf0, 0x54, 0x45, 0x21, 0x0b, 0x86,
-	0x61, 0xba, 0x61, 0x3e, 0x91, 0x21, 0x4b, 0xe7, 0x4b, 0xa3, 0x33, 0xea, 0xd8, 0x9a, 0x7b, 0x70,
-	0x86, 0x6e, 0x40, 0xf3, 0x39, 0x25, 0x92, 0x2e, 0x67, 0xd2, 0xe8, 0x8e, 0x3a, 0xb6, 0x3e, 0x31,
-	0xb1, 0x4a, 0xc1, 0x75, 0x0a, 0x5e, 0xd4, 0x29, 0xee, 0x0e, 0x46, 0xb7, 0xd0, 0x23, 0x1e, 0xe3,
-	0xd2, 0xf8, 0x57, 0xaa, 0x6c, 0xdc, 0xf2, 0xd3, 0x78, 0x56, 0x90, 0x33, 0xbf, 0x08, 0x75, 0x95,
-	0x0c, 0x3d, 0xc0, 0x80, 0xe7, 0xe9, 0x82, 0x88, 0x48, 0x18, 0xbd, 0xd1, 0x5f, 0x5b, 0x9f, 0x5c,
-	0xb4, 0x5a, 0xb8, 0x0a, 0xae, 0x4c, 0x1a, 0x2d, 0x72, 0x61, 0x98, 0x71, 0x9a, 0x11, 0x4e, 0x95,
-	0x57, 0xbf, 0xf4, 0xc2, 0xad, 0x5e, 0x8f, 0x3b, 0x41, 0xe5, 0x77, 0xe0, 0x61, 0x8d, 0x41, 0xdf,
-	0xeb, 0x18, 0x1d, 0x43, 0x9f, 0x53, 0x22, 0x58, 0x5a, 0x8d, 0xb0, 0xaa, 0xac, 0x73, 0xf8, 0x7f,
-	0xd0, 0x55, 0x01, 0x4a, 0x22, 0xa2, 0xf9, 0x7d, 0x0d, 0xaa, 0xca, 0x0a, 0xe0, 0xe8, 0x4b, 0xe4,
-	0x4f, 0x30, 0x9a, 0x02, 0xd0, 0xb7, 0x8c, 0xfa, 0xbf, 0xbd, 0x93, 0x3d, 0x7a, 0x92, 0x80, 0x56,
-	0x5f, 0x3f, 0x47, 0xaf, 0x30, 0xa0, 0x1b, 0x12, 0xe7, 0x44, 0x52, 0x74, 0xf9, 0xcd, 0x3c, 0xd4,
-	0x66, 0x3d, 0x57, 0xf5, 0xbc, 0x59, 0x0b, 0x73, 0xdc, 0x3a, 0xbc, 0x3a, 0xc0, 0xfa, 0x73, 0xa7,
-	0xbf, 0x68, 0xcd, 0xb9, 0xd7, 0x2f, 0x7b, 0xbb, 0xfe, 0x0c, 0x00, 0x00, 0xff, 0xff, 0xc1, 0x32,
-	0xe7, 0xf1, 0x1d, 0x03, 0x00, 0x00,
+	// 403 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x5f, 0xab, 0xd3, 0x30,
+	0x18, 0xc6, 0xdd, 0x39, 0x9e, 0xb9, 0xbe, 0x3d, 0x0a, 0xe6, 0x42, 0x4a, 0x45, 0x1c, 0x85, 0x83,
+	0x45, 0x31, 0x85, 0x79, 0x23, 0xe7, 0x42, 0x9c, 0x88, 0xd0, 0x3b, 0xe9, 0x06, 0x82, 0x57, 0xa6,
+	0x5d, 0xd6, 0x95, 0xfe, 0x49, 0x48, 0xd2, 0x4d, 0x3f, 0x85, 0x5f, 0x59, 0xda, 0xa4, 0xed, 0x8a,
+	0xae, 0x78, 0xd3, 0xe6, 0x0d, 0xcf, 0xfb, 0x7b, 0xf2, 0x24, 0x2f, 0xbc, 0xe0, 0x79, 0x1a, 0xc8,
+	0xe4, 0x40, 0x77, 0x75, 0x41, 0xc5, 0xb0, 0xc2, 0x5c, 0x30, 0xc5, 0xd0, 0xf3, 0x7d, 0x26, 0x65,
+	0xc6, 0x2a, 0x7c, 0x62, 0x22, 0xdf, 0x17, 0xec, 0x24, 0x71, 0x2f, 0x71, 0xef, 0xd3, 0x4c, 0x1d,
+	0xea, 0x18, 0x27, 0xac, 0x0c, 0x8c, 0xae, 0xfb, 0xbf, 0xed, 0xf5, 0x41, 0x63, 0xa0, 0x7e, 0x71,
+	0x2a, 0xf5, 0x57, 0x83, 0xdd, 0x97, 0x29, 0x63, 0x69, 0x41, 0x83, 0xb6, 0x8a, 0xeb, 0x7d, 0xa0,
+	0xb2, 0x92, 0x4a, 0x45, 0x4a, 0xae, 0x05, 0xde, 0xef, 0x6b, 0x58, 0x6c, 0x8c, 0x15, 0xf2, 0xe0,
+	0x36, 0xab, 0x8e, 0x2c, 0x21, 0x2a, 0x63, 0x55, 0xb8, 0x73, 0x66, 0xcb, 0x99, 0x6f, 0x45, 0xa3,
+	0x3d, 0xf4, 0x1e, 0xac, 0x44, 0x50, 0xa2, 0xe8, 0x6e, 0xad, 0x9c, 0xab, 0xe5, 0xcc, 0xb7, 0x57,
+	0x2e, 0xd6, 0x2e, 0xb8, 0x73, 0xc1, 0xdb, 0xce, 0x25, 0x1a, 0xc4, 0xe8, 0x03, 0xdc, 0x90, 0x98,
+	0x09, 0xe5, 0x3c, 0x6c, 0xbb, 0x7c, 0x3c, 0x11, 0x1a, 0xaf, 0x1b, 0xe5, 0x3a, 0x69, 0x4c, 0x23,
+	0xdd, 0x86, 0xbe, 0xc0, 0x42, 0xd4, 0xd5, 0x96, 0xc8, 0x5c, 0x3a, 0x37, 0xcb, 0x6b, 0xdf, 0x5e,
+	0xbd, 0x9e, 0x44, 0x44, 0x5a, 0x6c, 0x20, 0x7d, 0x2f, 0x8a, 0xe0, 0x96, 0x0b, 0xca, 0x89, 0xa0,
+	0x9a, 0x35, 0x6f, 0x59, 0x78, 0x92, 0xf5, 0x75, 0x68, 0x30, 0xbc, 0x11, 0x03, 0x85, 0x60, 0xc9,
+	0x3c, 0xe3, 0x1a, 0xf8, 0xa8, 0x05, 0xbe, 0x99, 0x04, 0x6e, 0x8c, 0xda, 0xd0, 0x86, 0x6e, 0xef,
+	0x0e, 0xec, 0xb3, 0xf0, 0xe8, 0x19, 0xcc, 0x05, 0x25, 0x92, 0x55, 0xe6, 0x35, 0x4c, 0xe5, 0xbd,
+	0x82, 0xc7, 0xa3, 0x80, 0x8d, 0x50, 0x11, 0x99, 0x87, 0x9f, 0x3b, 0xa1, 0xae, 0xbc, 0x14, 0x9e,
+	0xfe, 0x75, 0xfa, 0x4b, 0x62, 0x74, 0x0f, 0x40, 0x7f, 0x72, 0x9a, 0xfc, 0xef, 0xf3, 0x9e, 0xa9,
+	0xbd, 0x8f, 0xf0, 0x64, 0x9c, 0xea, 0xa2, 0xcb, 0x90, 0xe9, 0xea, 0x3c, 0xd3, 0xaa, 0x04, 0xab,
+	0x9b, 0x45, 0x81, 0x7e, 0xc0, 0x82, 0x1e, 0x49, 0x51, 0x13, 0x45, 0xd1, 0xbf, 0xee, 0x52, 0x8f,
+	0xf9, 0x37, 0x53, 0x87, 0xfd, 0x8c, 0xba, 0x77, 0xd3, 0x17, 0x6f, 0x56, 0xde, 0x83, 0x4f, 0xf6,
+	0x77, 0xab, 0xdf, 0x8f, 0xe7, 0x6d, 0xba, 0x77, 0x7f, 0x06, 0x00, 0x6b, 0x28, 0x1d, 0x53, 0xaa,
+	0x03, 0x00, 0x00,
 }
@@ -0,0 +1,48 @@
+// Package expr contains the syntax of the expressions in workflows, which is shared by the components that analyze
+// expressions, such as the validation and the parsers, and the controller that evaluates them.
+package expr
+
+import (
+	"regexp"
+)
+
+const (
+	// VarScope is the variable that holds the scope of the invocation.
+	VarScope = "$"
+	// VarCurrentTask is the variable that holds the id of the task of which the expression is evaluated.
+	VarCurrentTask = "taskId"
+
+	LangJavascript = "js"
+	LangPath       = "path"
+)
+
+var (
+	// langPrefixRe matches the language prefix of an expression. The expression itself can span multiple lines.
+	langPrefixRe = regexp.MustCompile("(?s)^\\s*([a-zA-Z]+)\\s*:(.*)$")
+
+	// languages contains the expression languages that have been registered with RegisterLanguage.
+	languages = map[string]bool{}
+)
+
+// RegisterLanguage registers an expression language. The languages are registered by the package that evaluates
+// expressions, based on its resolvers, so that the validation of workflows accepts the same languages as the
+// evaluation. It should only be called during initialization.
+func RegisterLanguage(lang string) {
+	languages[lang] = true
+}
+
+// IsLanguage checks if the language is one of the registered expression languages.
+func IsLanguage(lang string) bool {
+	return languages[lang]
+}
+
+// SplitLanguage splits the language prefix, such as `path:`, from the source of an expression without delimiters.
+// It returns false if the expression does not start with a prefix, in which case the prefix could also be part of the
+// expression itself.
+func SplitLanguage(src string) (lang string, expr string, ok bool) {
+	match := langPrefixRe.FindStringSubmatch(src)
+	if match == nil {
+		return "", src, false
+	}
+	return match[1], match[2], true
+}
@@ -0,0 +1,178 @@
+package expr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Path is a parsed path expression, which consists of a single reference to data in the scope, such as
+// `output('task').field`.
+//
+// A path starts with the scope (`$`), the id of the current task (`taskId`), or a function call with string literal
+// arguments. The root can be followed by any number of selectors: `.field`, `["field"]` or `[0]`.
+type Path struct {
+	// Root is VarScope, VarCurrentTask or the name of the function that the path starts with.
+	Root string
+
+	// Args contains the arguments of the function call, if the path starts with one.
+	Args []string
+
+	// Selectors contains the keys of the selectors that follow the root, which are either a string or an int.
+	Selectors []interface{}
+}
+
+// ParsePath parses the source of a path expression without delimiters.
+func ParsePath(src string) (*Path, error) {
+	p := &pathParser{src: src}
+	path := &Path{}
+	p.skipSpace()
+	if p.consume('$') {
+		path.Root = VarScope
+	} else {
+		path.Root = p.parseIdent()
+		if len(path.Root) == 0 {
+			return nil, p.errorf("expected '$', '%s' or a function", VarCurrentTask)
+		}
+		if path.Root != VarCurrentTask {
+			args, err := p.parseArgs()
+			if err != nil {
+				return nil, err
+			}
+			path.Args = args
+		}
+	}
+	for p.skipSpace(); p.pos < len(p.src); p.skipSpace() {
+		key, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		path.Selectors = append(path.Selectors, key)
+	}
+	return path, nil
+}
+
+// pathParser is a minimal, single-pass parser of path expressions.
+type pathParser struct {
+	src string
+	pos int
+}
+
+// parseArgs parses a list of string literals, such as `("a", 'b')`.
+func (p *pathParser) parseArgs() ([]string, error) {
+	p.skipSpace()
+	if !p.consume('(') {
+		return nil, p.errorf("expected '('")
+	}
+	var args []string
+	for {
+		p.skipSpace()
+		if p.consume(')') {
+			return args, nil
+		}
+		if len(args) > 0 && !p.consume(',') {
+			return nil, p.errorf("expected ',' or ')'")
+		}
+		p.skipSpace()
+		arg, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+}
+
+// parseSelector parses a field (`.field`) or index (`["field"]`, `[0]`) selector. The key is either a string or int.
+func (p *pathParser) parseSelector() (interface{}, error) {
+	if p.consume('.') {
+		p.skipSpace()
+		name := p.parseIdent()
+		if len(name) == 0 {
+			return nil, p.errorf("expected field name")
+		}
+		return name, nil
+	}
+	if !p.consume('[') {
+		return nil, p.errorf("expected '.' or '['")
+	}
+	p.skipSpace()
+	var key interface{}
+	if p.pos < len(p.src) && (p.src[p.pos] == '"' || p.src[p.pos] == '\'') {
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+		key = s
+	} else {
+		start := p.pos
+		for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+			p.pos++
+		}
+		i, err := strconv.Atoi(p.src[start:p.pos])
+		if err != nil {
+			return nil, p.errorf("expected string or integer index")
+		}
+		key = i
+	}
+	p.skipSpace()
+	if !p.consume(']') {
+		return nil, p.errorf("expected ']'")
+	}
+	return key, nil
+}
+
+func (p *pathParser) parseIdent() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (p.pos > start && c >= '0' && c <= '9') {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.src[start:p.pos]
+}
+
+// parseString parses a single- or double-quoted string literal, in which a backslash escapes the next character.
+func (p *pathParser) parseString() (string, error) {
+	if p.pos >= len(p.src) || (p.src[p.pos] != '"' && p.src[p.pos] != '\'') {
+		return "", p.errorf("expected string")
+	}
+	quote := p.src[p.pos]
+	p.pos++
+	var sb strings.Builder
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		p.pos++
+		switch {
+		case c == quote:
+			return sb.String(), nil
+		case c == '\\' && p.pos < len(p.src):
+			sb.WriteByte(p.src[p.pos])
+			p.pos++
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", p.errorf("unterminated string")
+}
+
+func (p *pathParser) skipSpace() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *pathParser) consume(c byte) bool {
+	if p.pos < len(p.src) && p.src[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *pathParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("%s at position %d", fmt.Sprintf(format, args...), p.pos)
+}
@@ -0,0 +1,38 @@
+package expr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePath(t *testing.T) {
+	cases := map[string]*Path{
+		"$.Tasks['a'][0]":              {Root: VarScope, Selectors: []interface{}{"Tasks", "a", 0}},
+		" output('a', \"b\") . field ": {Root: "output", Args: []string{"a", "b"}, Selectors: []interface{}{"field"}},
+		"output()":                     {Root: "output"},
+		"taskId":                       {Root: VarCurrentTask},
+	}
+	for src, expected := range cases {
+		path, err := ParsePath(src)
+		if assert.NoError(t, err, src) {
+			assert.Equal(t, expected, path, src)
+		}
+	}
+
+	for _, src := range []string{"", "'a'", "output('a'", "$.", "$[a]", "taskId()", "$ + 1"} {
+		_, err := ParsePath(src)
+		assert.Error(t, err, src)
+	}
+}
+
+func TestSplitLanguage(t *testing.T) {
+	lang, expr, ok := SplitLanguage(" path:\n output('a')\n .field")
+	assert.True(t, ok)
+	assert.Equal(t, LangPath, lang)
+	assert.Equal(t, "\n output('a')\n .field", expr)
+
+	_, expr, ok = SplitLanguage("output('a')")
+	assert.False(t, ok)
+	assert.Equal(t, "output('a')", expr)
+}
@@ -0,0 +1,195 @@
+package expr
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/robertkrimen/otto/ast"
+	"github.com/robertkrimen/otto/parser"
+)
+
+// taskReferenceFunctions contains the builtin functions of which the first argument is the id of a task.
+var taskReferenceFunctions = map[string]bool{
+	"input":         true,
+	"output":        true,
+	"outputHeaders": true,
+	"task":          true,
+}
+
+// TaskReferences returns the ids of the tasks that the expressions in the value refer to, such as 'a' in
+// `{output('a')}` or `{$.Tasks.a.Output}`. Lists and maps are searched recursively.
+//
+// Only references that can be determined without evaluating the expression are returned. This excludes references to
+// the current task, such as `{output()}`, and references that are constructed at runtime, such as `{$.Tasks[taskId]}`.
+// Expressions without a language prefix are parsed as expressions of the provided language, or as JavaScript if it
+// is empty. An error is returned if one of the expressions cannot be parsed.
+func TaskReferences(tv *typedvalues.TypedValue, lang string) ([]string, error) {
+	if len(lang) == 0 {
+		lang = LangJavascript
+	}
+	if !IsLanguage(lang) {
+		return nil, fmt.Errorf("unknown expression language '%s'", lang)
+	}
+	refs := map[string]bool{}
+	if err := collectTaskReferences(tv, lang, refs); err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(refs))
+	for id := range refs {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func collectTaskReferences(tv *typedvalues.TypedValue, lang string, refs map[string]bool) error {
+	switch tv.ValueType() {
+	case typedvalues.TypeList, typedvalues.TypeMap:
+		i, err := typedvalues.Unwrap(tv)
+		if err != nil {
+			return err
+		}
+		var items []interface{}
+		switch v := i.(type) {
+		case []interface{}:
+			items = v
+		case map[string]interface{}:
+			for _, item := range v {
+				items = append(items, item)
+			}
+		}
+		for _, item := range items {
+			field, err := typedvalues.Wrap(item)
+			if err != nil {
+				return err
+			}
+			if err := collectTaskReferences(field, lang, refs); err != nil {
+				return err
+			}
+		}
+		return nil
+	case typedvalues.TypeExpression:
+		e, err := typedvalues.UnwrapExpression(tv)
+		if err != nil {
+			return err
+		}
+		return collectExpressionReferences(e, lang, refs)
+	default:
+		return nil
+	}
+}
+
+func collectExpressionReferences(e string, lang string, refs map[string]bool) error {
+	src := typedvalues.RemoveExpressionDelimiters(e)
+	if prefix, body, ok := SplitLanguage(src); ok && IsLanguage(prefix) {
+		lang = prefix
+		src = body
+	}
+
+	var err error
+	switch lang {
+	case LangPath:
+		err = collectPathReferences(src, refs)
+	default:
+		err = collectJavascriptReferences(src, refs)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid expression '%s': %v", e, err)
+	}
+	return nil
+}
+
+// collectPathReferences parses the path expression, adding the task referenced by the root of the path to refs.
+func collectPathReferences(src string, refs map[string]bool) error {
+	path, err := ParsePath(src)
+	if err != nil {
+		return err
+	}
+	switch {
+	case path.Root == VarScope:
+		if len(path.Selectors) >= 2 && path.Selectors[0] == "Tasks" {
+			if id, ok := path.Selectors[1].(string); ok {
+				refs[id] = true
+			}
+		}
+	case taskReferenceFunctions[path.Root] && len(path.Args) > 0:
+		refs[path.Args[0]] = true
+	}
+	return nil
+}
+
+// collectJavascriptReferences parses the JavaScript expression and adds the tasks referenced by calls to the builtin
+// functions with a string literal, such as `output('a')`, and by selections in the scope, such as `$.Tasks.a`.
+func collectJavascriptReferences(src string, refs map[string]bool) error {
+	program, err := parser.ParseFile(nil, "", src, 0)
+	if err != nil {
+		return err
+	}
+	walkAST(reflect.ValueOf(program), func(node interface{}) {
+		switch n := node.(type) {
+		case *ast.CallExpression:
+			callee, ok := n.Callee.(*ast.Identifier)
+			if !ok || !taskReferenceFunctions[callee.Name] || len(n.ArgumentList) == 0 {
+				return
+			}
+			if arg, ok := n.ArgumentList[0].(*ast.StringLiteral); ok {
+				refs[arg.Value] = true
+			}
+		case *ast.DotExpression, *ast.BracketExpression:
+			tasks, id, ok := selection(n)
+			if !ok {
+				return
+			}
+			scope, key, ok := selection(tasks)
+			if !ok || key != "Tasks" {
+				return
+			}
+			if ident, ok := scope.(*ast.Identifier); ok && ident.Name == VarScope {
+				refs[id] = true
+			}
+		}
+	})
+	return nil
+}
+
+// selection returns the object and the key of a selection with a static key, such as `obj.key` or `obj["key"]`.
+func selection(node interface{}) (obj ast.Expression, key string, ok bool) {
+	switch n := node.(type) {
+	case *ast.DotExpression:
+		return n.Left, n.Identifier.Name, true
+	case *ast.BracketExpression:
+		if member, ok := n.Member.(*ast.StringLiteral); ok {
+			return n.Left, member.Value, true
+		}
+	}
+	return nil, "", false
+}
+
+// walkAST calls visit for each of the nodes in the (sub)tree. Rather than enumerating all node types of the otto AST,
+// it uses reflection to traverse the fields of the nodes.
+func walkAST(v reflect.Value, visit func(node interface{})) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			walkAST(v.Elem(), visit)
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			return
+		}
+		if v.CanInterface() {
+			visit(v.Interface())
+		}
+		walkAST(v.Elem(), visit)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			walkAST(v.Field(i), visit)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walkAST(v.Index(i), visit)
+		}
+	}
+}
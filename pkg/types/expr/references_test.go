@@ -0,0 +1,80 @@
+package expr
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	// The languages are registered by the resolvers of the controller, which depends on this package.
+	RegisterLanguage(LangJavascript)
+	RegisterLanguage(LangPath)
+}
+
+func mustParseExpr(s string) *typedvalues.TypedValue {
+	tv := typedvalues.MustWrap(s)
+	if tv.ValueType() != typedvalues.TypeExpression {
+		panic(fmt.Sprintf("Should be %v, but was '%v'", typedvalues.TypeExpression, tv.ValueType()))
+	}
+	return tv
+}
+
+func TestTaskReferences(t *testing.T) {
+	cases := map[string][]string{
+		"{ output('a') }":                                        {"a"},
+		"{ input(\"a\", 'key') + output('b') }":                  {"a", "b"},
+		"{ outputHeaders('a')['key'] }":                          {"a"},
+		"{ task('a').Status }":                                   {"a"},
+		"{ $.Tasks.a.Output }":                                   {"a"},
+		"{ $['Tasks'][\"a\"].Output }":                           {"a"},
+		"{ [1, 2].map(function(i) { return output('a') + i }) }": {"a"},
+		"{ path: output('a').field }":                            {"a"},
+		"{ path: $.Tasks.a.Output }":                             {"a"},
+		"{ path: $.Invocation.Inputs.default }":                  {},
+		"{ output() }":                                           {},
+		"{ output(taskId) }":                                     {},
+		"{ $.Tasks[taskId].Output }":                             {},
+		"{ param('a') }":                                         {},
+		"{ $.Invocation.Inputs.Tasks }":                          {},
+		"{ path: taskId }":                                       {},
+	}
+
+	for expr, expected := range cases {
+		refs, err := TaskReferences(mustParseExpr(expr), "")
+		if assert.NoError(t, err, expr) {
+			assert.Equal(t, expected, refs, expr)
+		}
+	}
+}
+
+func TestTaskReferences_Nested(t *testing.T) {
+	refs, err := TaskReferences(typedvalues.MustWrap(map[string]interface{}{
+		"list":  []interface{}{"{output('b')}", "plain"},
+		"value": "{$.Tasks.a.Output}",
+		"other": 42,
+	}), "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, refs)
+}
+
+func TestTaskReferences_Language(t *testing.T) {
+	// Function calls are not supported by path expressions.
+	_, err := TaskReferences(mustParseExpr("{ output('a').field.trim() }"), LangPath)
+	assert.Error(t, err)
+	refs, err := TaskReferences(mustParseExpr("{ js:\n output('a').field.trim() }"), LangPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a"}, refs)
+
+	_, err = TaskReferences(mustParseExpr("{ output('a') }"), "cobol")
+	assert.Error(t, err)
+}
+
+func TestTaskReferences_Invalid(t *testing.T) {
+	for _, expr := range []string{"{ output('a' }", "{ path: output('a') + 1 }"} {
+		_, err := TaskReferences(mustParseExpr(expr), "")
+		assert.Error(t, err, expr)
+	}
+}
@@ -1,8 +1,12 @@
 package types
 
 import (
+	"regexp"
+	"time"
+
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 )
 
 // Types other than specified in protobuf
@@ -13,15 +17,21 @@ const (
 	InputQuery   = "query"
 	InputMethod  = "method"
 	InputParent  = "_parent"
+	InputError   = "error"
 
 	typedValueShortMaxLen = 32
 	WorkflowAPIVersion    = "v1"
 
-	TypeWorkflow   = "workflow"
-	TypeInvocation = "invocation"
-	TypeTaskRun    = "taskrun"
+	TypeWorkflow     = "workflow"
+	TypeWorkflowName = "workflowname"
+	TypeInvocation   = "invocation"
+	TypeTaskRun      = "taskrun"
+	TypeTrigger      = "trigger"
 )
 
+// DefaultMaxRuntime is the maximum runtime of invocations that do not specify a deadline.
+const DefaultMaxRuntime = 10 * time.Minute
+
 // InvocationEvent
 var invocationFinalStates = []WorkflowInvocationStatus_Status{
 	WorkflowInvocationStatus_ABORTED,
@@ -64,6 +74,19 @@ func (m *WorkflowInvocation) Workflow() *Workflow {
 	return m.GetSpec().GetWorkflow()
 }
 
+// Deadline returns the deadline of the invocation. If the spec does not specify a deadline, the invocation is allowed
+// to run for maxRuntime after its creation.
+func (m *WorkflowInvocation) Deadline(maxRuntime time.Duration) (time.Time, error) {
+	if deadline, err := ptypes.Timestamp(m.GetSpec().GetDeadline()); err == nil {
+		return deadline, nil
+	}
+	createdAt, err := ptypes.Timestamp(m.GetMetadata().GetCreatedAt())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return createdAt.Add(maxRuntime), nil
+}
+
 // TODO how do we know which tasks are not being run
 func (m *WorkflowInvocation) TaskInvocation(id string) (*TaskInvocation, bool) {
 	ti, ok := m.Status.Tasks[id]
@@ -89,6 +112,18 @@ func (m *WorkflowInvocation) Task(id string) (*Task, bool) {
 	return m.Workflow().Task(id)
 }
 
+// ErrorHandlers returns a mapping of the error handlers in the invocation to the tasks of which they handle the
+// failures.
+func (m *WorkflowInvocation) ErrorHandlers() map[string]string {
+	handlers := map[string]string{}
+	for id, task := range m.Tasks() {
+		if handler := task.GetSpec().GetOnError(); len(handler) > 0 {
+			handlers[handler] = id
+		}
+	}
+	return handlers
+}
+
 // Tasks gets all tasks in a workflow. This includes the dynamic tasks added during
 // the invocation.
 func (m *WorkflowInvocation) Tasks() map[string]*Task {
@@ -181,6 +216,32 @@ func (ti TaskInvocationStatus) Successful() bool {
 	return ti.GetStatus() == TaskInvocationStatus_SUCCEEDED
 }
 
+//
+// RetryPolicy
+//
+
+// ShouldRetry checks if a task that failed with the provided status should be retried according to the retry policy.
+// This is the case if the task has attempts left and its error matches one of the retryable errors.
+func (m *RetryPolicy) ShouldRetry(status *TaskInvocationStatus) bool {
+	if m == nil || status.GetStatus() != TaskInvocationStatus_FAILED {
+		return false
+	}
+	// The initial attempt is not counted as a retry.
+	if status.GetRetries()+1 >= m.GetMaxAttempts() {
+		return false
+	}
+	if len(m.GetRetryOn()) == 0 {
+		return true
+	}
+	msg := status.GetError().GetMessage()
+	for _, pattern := range m.GetRetryOn() {
+		if matched, err := regexp.MatchString(pattern, msg); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
 //
 // Task
 //
@@ -380,3 +441,27 @@ func (m *WorkflowStatus) AddTask(id string, t *Task) {
 	}
 	m.Tasks[id] = t
 }
+
+//
+// Trigger
+//
+
+func (m *Trigger) ID() string {
+	return m.GetMetadata().GetId()
+}
+
+func (m *Trigger) Copy() *Trigger {
+	return proto.Clone(m).(*Trigger)
+}
+
+func (m *Trigger) Type() string {
+	return TypeTrigger
+}
+
+//
+// TriggerStatus
+//
+
+func (m *TriggerStatus) Active() bool {
+	return m.GetStatus() == TriggerStatus_ACTIVE
+}
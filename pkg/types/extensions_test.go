@@ -0,0 +1,35 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicy_ShouldRetry(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxAttempts: 3,
+		RetryOn:     []string{"timeout", "^status code 5[0-9]{2}$"},
+	}
+	failed := func(retries int32, msg string) *TaskInvocationStatus {
+		return &TaskInvocationStatus{
+			Status:  TaskInvocationStatus_FAILED,
+			Error:   &Error{Message: msg},
+			Retries: retries,
+		}
+	}
+
+	assert.True(t, policy.ShouldRetry(failed(0, "connection timeout")))
+	assert.True(t, policy.ShouldRetry(failed(1, "status code 503")))
+	assert.False(t, policy.ShouldRetry(failed(2, "connection timeout")))
+	assert.False(t, policy.ShouldRetry(failed(0, "status code 404")))
+	assert.False(t, policy.ShouldRetry(&TaskInvocationStatus{Status: TaskInvocationStatus_SUCCEEDED}))
+
+	// Without matchers all errors are retryable
+	policy.RetryOn = nil
+	assert.True(t, policy.ShouldRetry(failed(0, "status code 404")))
+
+	// A task without a retry policy is never retried
+	var noPolicy *RetryPolicy
+	assert.False(t, noPolicy.ShouldRetry(failed(0, "connection timeout")))
+}
@@ -0,0 +1,224 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fission/fission-workflows/pkg/types"
+)
+
+const (
+	FormatDot     = "dot"
+	FormatMermaid = "mermaid"
+	FormatJSON    = "json"
+)
+
+// Formats contains the formats that a Diagram can be rendered in.
+var Formats = []string{FormatDot, FormatMermaid, FormatJSON}
+
+// statusColors maps the status of a task invocation to the color used to fill its node.
+var statusColors = map[types.TaskInvocationStatus_Status]string{
+	types.TaskInvocationStatus_UNKNOWN:     "#ffffff",
+	types.TaskInvocationStatus_SCHEDULED:   "#bfdfff",
+	types.TaskInvocationStatus_IN_PROGRESS: "#80bfff",
+	types.TaskInvocationStatus_SUCCEEDED:   "#a3e4a3",
+	types.TaskInvocationStatus_FAILED:      "#f4a3a3",
+	types.TaskInvocationStatus_ABORTED:     "#d9d9d9",
+	types.TaskInvocationStatus_SKIPPED:     "#d9d9d9",
+}
+
+// Diagram is a representation of the dependency graph of the tasks in a workflow or invocation, which can be rendered
+// in one of the Formats. The nodes and edges are sorted by the ids of the tasks.
+type Diagram struct {
+	Nodes []*DiagramNode `json:"nodes"`
+	Edges []*DiagramEdge `json:"edges"`
+}
+
+// DiagramNode is a task in the diagram. For the diagrams of invocations the status of the task is included; tasks
+// that have not been started yet have the status 'UNKNOWN'.
+type DiagramNode struct {
+	ID       string `json:"id"`
+	Function string `json:"function"`
+	Status   string `json:"status,omitempty"`
+	OnError  string `json:"onError,omitempty"`
+
+	status types.TaskInvocationStatus_Status
+}
+
+// DiagramEdge is a dependency between two tasks: the To task requires the From task.
+type DiagramEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// NewWorkflowDiagram creates a diagram of the tasks of the workflow.
+func NewWorkflowDiagram(spec *types.WorkflowSpec) *Diagram {
+	return newDiagram(spec.GetTasks(), nil)
+}
+
+// NewInvocationDiagram creates a diagram of the tasks of the invocation, including the dynamic tasks that have been
+// added to the invocation, in which the nodes are annotated with the status of the tasks.
+func NewInvocationDiagram(invocation *types.WorkflowInvocation) *Diagram {
+	tasks := map[string]*types.TaskSpec{}
+	for id, task := range invocation.Tasks() {
+		if spec := task.GetSpec(); spec != nil {
+			tasks[id] = spec
+		}
+	}
+	statuses := map[string]types.TaskInvocationStatus_Status{}
+	for id := range tasks {
+		ti, _ := invocation.TaskInvocation(id)
+		statuses[id] = ti.GetStatus().GetStatus()
+	}
+	return newDiagram(tasks, statuses)
+}
+
+func newDiagram(tasks map[string]*types.TaskSpec, statuses map[string]types.TaskInvocationStatus_Status) *Diagram {
+	diagram := &Diagram{}
+	g := Parse(NewTaskSpecIterator(tasks))
+	for _, n := range g.Nodes() {
+		task := n.(*TaskSpecNode)
+		node := &DiagramNode{
+			ID:       task.id,
+			Function: task.GetFunctionRef(),
+			OnError:  task.GetOnError(),
+		}
+		if statuses != nil {
+			node.status = statuses[task.id]
+			node.Status = node.status.String()
+		}
+		diagram.Nodes = append(diagram.Nodes, node)
+		for _, dependent := range g.From(n) {
+			diagram.Edges = append(diagram.Edges, &DiagramEdge{
+				From: task.id,
+				To:   dependent.(*TaskSpecNode).id,
+			})
+		}
+	}
+	sort.Slice(diagram.Nodes, func(i, j int) bool {
+		return diagram.Nodes[i].ID < diagram.Nodes[j].ID
+	})
+	sort.Slice(diagram.Edges, func(i, j int) bool {
+		if diagram.Edges[i].From != diagram.Edges[j].From {
+			return diagram.Edges[i].From < diagram.Edges[j].From
+		}
+		return diagram.Edges[i].To < diagram.Edges[j].To
+	})
+	return diagram
+}
+
+// Render renders the diagram in the format, which should be one of the Formats.
+func (d *Diagram) Render(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case FormatDot:
+		return d.Dot(), nil
+	case FormatMermaid:
+		return d.Mermaid(), nil
+	case FormatJSON:
+		bs, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(bs), nil
+	default:
+		return "", fmt.Errorf("unknown graph format '%s' (supported: %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// Dot renders the diagram in the DOT language of Graphviz. Error handlers are connected to the task that they handle
+// with a dashed edge.
+func (d *Diagram) Dot() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString("digraph workflow {\n")
+	buf.WriteString("  node [shape=box, style=\"rounded,filled\", fillcolor=\"#ffffff\"];\n")
+	for _, node := range d.Nodes {
+		// The line break between the id and the function is an escape sequence of DOT, so it must not be escaped.
+		attrs := fmt.Sprintf("label=\"%s\\n%s\"", escape(node.ID), escape(node.Function))
+		if len(node.Status) > 0 {
+			attrs += fmt.Sprintf(", fillcolor=%s, tooltip=%s", quote(statusColors[node.status]), quote(node.Status))
+		}
+		fmt.Fprintf(buf, "  %s [%s];\n", quote(node.ID), attrs)
+	}
+	for _, edge := range d.Edges {
+		fmt.Fprintf(buf, "  %s -> %s;\n", quote(edge.From), quote(edge.To))
+	}
+	for _, node := range d.Nodes {
+		if len(node.OnError) > 0 {
+			fmt.Fprintf(buf, "  %s -> %s [style=dashed, label=\"onError\"];\n", quote(node.ID), quote(node.OnError))
+		}
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+// Mermaid renders the diagram as a Mermaid flowchart. Error handlers are connected to the task that they handle with
+// a dotted edge.
+func (d *Diagram) Mermaid() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString("graph TD\n")
+	classes := map[string]bool{}
+	for _, node := range d.Nodes {
+		fmt.Fprintf(buf, "  %s[\"%s<br/>%s\"]\n", mermaidID(node.ID), mermaidText(node.ID), mermaidText(node.Function))
+		if len(node.Status) > 0 {
+			fmt.Fprintf(buf, "  class %s %s\n", mermaidID(node.ID), strings.ToLower(node.Status))
+			classes[node.Status] = true
+		}
+	}
+	for _, edge := range d.Edges {
+		fmt.Fprintf(buf, "  %s --> %s\n", mermaidID(edge.From), mermaidID(edge.To))
+	}
+	for _, node := range d.Nodes {
+		if len(node.OnError) > 0 {
+			fmt.Fprintf(buf, "  %s -. onError .-> %s\n", mermaidID(node.ID), mermaidID(node.OnError))
+		}
+	}
+	for _, status := range sortedStatuses(classes) {
+		value := types.TaskInvocationStatus_Status(types.TaskInvocationStatus_Status_value[status])
+		fmt.Fprintf(buf, "  classDef %s fill:%s\n", strings.ToLower(status), statusColors[value])
+	}
+	return buf.String()
+}
+
+func sortedStatuses(statuses map[string]bool) []string {
+	var result []string
+	for status := range statuses {
+		result = append(result, status)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// quote quotes the string as a DOT identifier.
+func quote(s string) string {
+	return "\"" + escape(s) + "\""
+}
+
+// escape escapes the backslashes and quotes in the string, so that it can be included in a quoted DOT identifier.
+// The backslashes are escaped first, so that the backslashes that escape the quotes are kept.
+func escape(s string) string {
+	s = strings.Replace(s, "\\", "\\\\", -1)
+	return strings.Replace(s, "\"", "\\\"", -1)
+}
+
+// mermaidID returns an identifier for the task that is safe to use in Mermaid, which does not allow most special
+// characters in node ids. Other bytes than letters and digits, including the underscore itself, are escaped as '_'
+// followed by their hex value, so that distinct task ids never map to the same identifier.
+func mermaidID(id string) string {
+	buf := bytes.NewBufferString("t_")
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			buf.WriteByte(c)
+		} else {
+			fmt.Fprintf(buf, "_%02x", c)
+		}
+	}
+	return buf.String()
+}
+
+func mermaidText(s string) string {
+	return strings.Replace(s, "\"", "#quot;", -1)
+}
@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func testWorkflowSpec() *types.WorkflowSpec {
+	return &types.WorkflowSpec{
+		OutputTask: "c",
+		Tasks: map[string]*types.TaskSpec{
+			"a":       {FunctionRef: "fnA", OnError: "handler"},
+			"b":       {FunctionRef: "fnB", Requires: types.Require("a")},
+			"c":       {FunctionRef: "fnC", Requires: types.Require("a", "b")},
+			"handler": {FunctionRef: "noop"},
+		},
+	}
+}
+
+func TestNewWorkflowDiagram(t *testing.T) {
+	d := NewWorkflowDiagram(testWorkflowSpec())
+	assert.Len(t, d.Nodes, 4)
+	assert.Equal(t, "a", d.Nodes[0].ID)
+	assert.Equal(t, "fnA", d.Nodes[0].Function)
+	assert.Empty(t, d.Nodes[0].Status)
+	assert.Equal(t, []*DiagramEdge{
+		{From: "a", To: "b"},
+		{From: "a", To: "c"},
+		{From: "b", To: "c"},
+	}, d.Edges)
+}
+
+func TestNewInvocationDiagram(t *testing.T) {
+	wf := &types.Workflow{
+		Metadata: types.NewObjectMetadata("wf"),
+		Spec:     testWorkflowSpec(),
+	}
+	invocation := &types.WorkflowInvocation{
+		Metadata: types.NewObjectMetadata("wi"),
+		Spec:     &types.WorkflowInvocationSpec{Workflow: wf},
+		Status: &types.WorkflowInvocationStatus{
+			Tasks: map[string]*types.TaskInvocation{
+				"a": {Status: &types.TaskInvocationStatus{Status: types.TaskInvocationStatus_SUCCEEDED}},
+				"b": {Status: &types.TaskInvocationStatus{Status: types.TaskInvocationStatus_FAILED}},
+			},
+		},
+	}
+
+	d := NewInvocationDiagram(invocation)
+	statuses := map[string]string{}
+	for _, node := range d.Nodes {
+		statuses[node.ID] = node.Status
+	}
+	assert.Equal(t, map[string]string{
+		"a":       "SUCCEEDED",
+		"b":       "FAILED",
+		"c":       "UNKNOWN",
+		"handler": "UNKNOWN",
+	}, statuses)
+
+	dot := d.Dot()
+	assert.Contains(t, dot, "\"b\" [label=\"b\\nfnB\", fillcolor=\"#f4a3a3\", tooltip=\"FAILED\"];")
+	assert.Contains(t, dot, "\"a\" -> \"c\";")
+	assert.Contains(t, dot, "\"a\" -> \"handler\" [style=dashed")
+
+	mermaid := d.Mermaid()
+	assert.Contains(t, mermaid, "t_a --> t_b")
+	assert.Contains(t, mermaid, "class t_b failed")
+	assert.Contains(t, mermaid, "classDef failed fill:#f4a3a3")
+}
+
+func TestDiagramRender(t *testing.T) {
+	d := NewWorkflowDiagram(testWorkflowSpec())
+
+	out, err := d.Render(FormatJSON)
+	assert.NoError(t, err)
+	parsed := &Diagram{}
+	assert.NoError(t, json.Unmarshal([]byte(out), parsed))
+	assert.Equal(t, d.Edges, parsed.Edges)
+	assert.Len(t, parsed.Nodes, 4)
+
+	out, err = d.Render("DOT")
+	assert.NoError(t, err)
+	assert.Equal(t, d.Dot(), out)
+
+	_, err = d.Render("png")
+	assert.Error(t, err)
+}
+
+func TestMermaidID(t *testing.T) {
+	ids := map[string]bool{}
+	for _, task := range []string{"a-b", "a_b", "a.b", "a b", "a_2db"} {
+		id := mermaidID(task)
+		assert.Regexp(t, "^[a-zA-Z0-9_]+$", id)
+		assert.False(t, ids[id], task)
+		ids[id] = true
+	}
+}
+
+func TestDotEscape(t *testing.T) {
+	d := NewWorkflowDiagram(&types.WorkflowSpec{
+		OutputTask: "a\\",
+		Tasks: map[string]*types.TaskSpec{
+			"a\\":   {FunctionRef: "fn\"a\""},
+			"b\\\"": {FunctionRef: "noop", Requires: types.Require("a\\")},
+		},
+	})
+	dot := d.Dot()
+	assert.Contains(t, dot, `"a\\" [label="a\\\nfn\"a\""];`)
+	assert.Contains(t, dot, `"a\\" -> "b\\\"";`)
+}
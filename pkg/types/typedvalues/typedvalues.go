@@ -34,6 +34,14 @@ var (
 	expressionRe            = regexp.MustCompile("^\\{(.*)\\}$")
 	ErrIllegalTypeAssertion = errors.New("illegal type assertion")
 	ErrUnsupportedType      = errors.New("unsupported type")
+
+	// multilineExpressionRe matches expressions that span multiple lines, which are only allowed with a language
+	// prefix, such as `{path: ...}`. Other multi-line strings between braces, such as JSON documents, are not
+	// expressions.
+	multilineExpressionRe = regexp.MustCompile("(?s)^\\{\\s*[a-zA-Z]+\\s*:.*\\}$")
+
+	// expressionDelimitersRe matches the delimiters of any expression, including multi-line ones.
+	expressionDelimitersRe = regexp.MustCompile("(?s)^\\{(.*)\\}$")
 )
 
 // TODO add caching to formatting and parsing
@@ -207,7 +215,7 @@ func Wrap(val interface{}) (*TypedValue, error) {
 	case proto.Message:
 		msg = t
 	case string:
-		if expressionRe.MatchString(t) {
+		if IsExpression(t) {
 			msg = &Expression{Value: t}
 		} else {
 			msg = &wrappers.StringValue{Value: t}
@@ -454,18 +462,21 @@ func UnwrapExpression(tv *TypedValue) (string, error) {
 		return "", err
 	}
 
-	if !IsExpression(s) {
+	// Values that were explicitly wrapped as an Expression, such as the body of a multi-line expression of which the
+	// language prefix has been removed, only need to be delimited.
+	isExpression := IsExpression(s) || (tv.ValueType() == TypeExpression && expressionDelimitersRe.MatchString(s))
+	if !isExpression {
 		return "", errors.Wrapf(ErrIllegalTypeAssertion, "failed to unwrap %s to expression", tv.ValueType())
 	}
 	return s, nil
 }
 
 func RemoveExpressionDelimiters(expr string) string {
-	return expressionRe.ReplaceAllString(expr, "$1")
+	return expressionDelimitersRe.ReplaceAllString(expr, "$1")
 }
 
 func IsExpression(s string) bool {
-	return expressionRe.MatchString(s)
+	return expressionRe.MatchString(s) || multilineExpressionRe.MatchString(s)
 }
 
 // marshalAny takes the protocol buffer and encodes it into google.protobuf.Any, without prepending the Google API URL.
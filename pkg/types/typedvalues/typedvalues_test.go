@@ -67,6 +67,14 @@ func parseFormatTestCases() []testCase {
 			input:        "{}",
 			expectedType: TypeExpression,
 		},
+		{
+			input:        "{path: foo\n.bar}",
+			expectedType: TypeExpression,
+		},
+		{
+			input:        "{\n  \"foo\": \"bar\"\n}",
+			expectedType: TypeString,
+		},
 		{
 			input:        []interface{}{},
 			expectedType: TypeList,
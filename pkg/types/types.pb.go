@@ -10,6 +10,8 @@ It is generated from these files:
 It has these top-level messages:
 	Workflow
 	WorkflowSpec
+	TypeSchema
+	ConcurrencyPolicy
 	WorkflowStatus
 	WorkflowInvocation
 	WorkflowInvocationSpec
@@ -17,11 +19,15 @@ It has these top-level messages:
 	DependencyConfig
 	Task
 	TaskSpec
+	RetryPolicy
 	TaskStatus
 	TaskDependencyParameters
 	TaskInvocation
 	TaskInvocationSpec
 	TaskInvocationStatus
+	Trigger
+	TriggerSpec
+	TriggerStatus
 	ObjectMetadata
 	Error
 	FnRef
@@ -73,7 +79,33 @@ var WorkflowStatus_Status_value = map[string]int32{
 func (x WorkflowStatus_Status) String() string {
 	return proto.EnumName(WorkflowStatus_Status_name, int32(x))
 }
-func (WorkflowStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{2, 0} }
+func (WorkflowStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{4, 0} }
+
+type WorkflowInvocationSpec_Priority int32
+
+const (
+	WorkflowInvocationSpec_NORMAL WorkflowInvocationSpec_Priority = 0
+	WorkflowInvocationSpec_HIGH   WorkflowInvocationSpec_Priority = 1
+	WorkflowInvocationSpec_LOW    WorkflowInvocationSpec_Priority = 2
+)
+
+var WorkflowInvocationSpec_Priority_name = map[int32]string{
+	0: "NORMAL",
+	1: "HIGH",
+	2: "LOW",
+}
+var WorkflowInvocationSpec_Priority_value = map[string]int32{
+	"NORMAL": 0,
+	"HIGH":   1,
+	"LOW":    2,
+}
+
+func (x WorkflowInvocationSpec_Priority) String() string {
+	return proto.EnumName(WorkflowInvocationSpec_Priority_name, int32(x))
+}
+func (WorkflowInvocationSpec_Priority) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{6, 0}
+}
 
 type WorkflowInvocationStatus_Status int32
 
@@ -107,8 +139,29 @@ func (x WorkflowInvocationStatus_Status) String() string {
 	return proto.EnumName(WorkflowInvocationStatus_Status_name, int32(x))
 }
 func (WorkflowInvocationStatus_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{5, 0}
+	return fileDescriptor0, []int{7, 0}
+}
+
+type RetryPolicy_Backoff int32
+
+const (
+	RetryPolicy_EXPONENTIAL RetryPolicy_Backoff = 0
+	RetryPolicy_CONSTANT    RetryPolicy_Backoff = 1
+)
+
+var RetryPolicy_Backoff_name = map[int32]string{
+	0: "EXPONENTIAL",
+	1: "CONSTANT",
+}
+var RetryPolicy_Backoff_value = map[string]int32{
+	"EXPONENTIAL": 0,
+	"CONSTANT":    1,
+}
+
+func (x RetryPolicy_Backoff) String() string {
+	return proto.EnumName(RetryPolicy_Backoff_name, int32(x))
 }
+func (RetryPolicy_Backoff) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{11, 0} }
 
 type TaskStatus_Status int32
 
@@ -132,7 +185,7 @@ var TaskStatus_Status_value = map[string]int32{
 func (x TaskStatus_Status) String() string {
 	return proto.EnumName(TaskStatus_Status_name, int32(x))
 }
-func (TaskStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{9, 0} }
+func (TaskStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{12, 0} }
 
 type TaskDependencyParameters_DependencyType int32
 
@@ -157,7 +210,7 @@ func (x TaskDependencyParameters_DependencyType) String() string {
 	return proto.EnumName(TaskDependencyParameters_DependencyType_name, int32(x))
 }
 func (TaskDependencyParameters_DependencyType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{10, 0}
+	return fileDescriptor0, []int{13, 0}
 }
 
 type TaskInvocationStatus_Status int32
@@ -195,10 +248,33 @@ func (x TaskInvocationStatus_Status) String() string {
 	return proto.EnumName(TaskInvocationStatus_Status_name, int32(x))
 }
 func (TaskInvocationStatus_Status) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor0, []int{13, 0}
+	return fileDescriptor0, []int{16, 0}
 }
 
-//
+type TriggerStatus_Status int32
+
+const (
+	TriggerStatus_UNKNOWN TriggerStatus_Status = 0
+	TriggerStatus_ACTIVE  TriggerStatus_Status = 1
+	TriggerStatus_DELETED TriggerStatus_Status = 2
+)
+
+var TriggerStatus_Status_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "ACTIVE",
+	2: "DELETED",
+}
+var TriggerStatus_Status_value = map[string]int32{
+	"UNKNOWN": 0,
+	"ACTIVE":  1,
+	"DELETED": 2,
+}
+
+func (x TriggerStatus_Status) String() string {
+	return proto.EnumName(TriggerStatus_Status_name, int32(x))
+}
+func (TriggerStatus_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{19, 0} }
+
 // Workflow Model
 //
 type Workflow struct {
@@ -251,10 +327,32 @@ type WorkflowSpec struct {
 	Description string `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
 	// The UID that the workflow should have. Only use this in case you want to force a specific UID.
 	ForceId string `protobuf:"bytes,5,opt,name=forceId" json:"forceId,omitempty"`
-	// Name is solely for human-readablity
+	// Name is an optional, human-readable name of the workflow.
+	//
+	// All revisions of a workflow share the same name, which allows the workflow to be referenced by
+	// <name>[@<revision>] instead of by the id of a specific revision.
 	Name string `protobuf:"bytes,6,opt,name=name" json:"name,omitempty"`
 	// Internal indicates whether is a workflow should be visible to a human (default) or not.
 	Internal bool `protobuf:"varint,7,opt,name=internal" json:"internal,omitempty"`
+	// Concurrency limits the number of tasks of this workflow that run concurrently, across all of its invocations.
+	//
+	// Tasks that would exceed the limits are not failed, but queued until running tasks have completed. If not set,
+	// the default limits of the workflow engine apply.
+	Concurrency *ConcurrencyPolicy `protobuf:"bytes,8,opt,name=concurrency" json:"concurrency,omitempty"`
+	// Inputs describes the inputs that the workflow expects, as a schema of type 'object' with a property per
+	// input.
+	//
+	// Invocations, including invocations of the workflow as a task of another workflow, are rejected if their inputs
+	// do not match the schema. If not set, any inputs are accepted.
+	Inputs *TypeSchema `protobuf:"bytes,9,opt,name=inputs" json:"inputs,omitempty"`
+	// Output describes the output of the workflow. An invocation of which the output does not match the schema fails.
+	Output *TypeSchema `protobuf:"bytes,10,opt,name=output" json:"output,omitempty"`
+	// Labels and annotations are added to the metadata of the workflow.
+	Labels      map[string]string `protobuf:"bytes,11,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Annotations map[string]string `protobuf:"bytes,12,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// ExpressionLanguage is the language of the expressions in the workflow that do not select a language with a
+	// prefix, such as 'js' or 'path'. If not set, these expressions are interpreted as JavaScript.
+	ExpressionLanguage string `protobuf:"bytes,13,opt,name=expressionLanguage" json:"expressionLanguage,omitempty"`
 }
 
 func (m *WorkflowSpec) Reset()                    { *m = WorkflowSpec{} }
@@ -311,6 +409,132 @@ func (m *WorkflowSpec) GetInternal() bool {
 	return false
 }
 
+func (m *WorkflowSpec) GetConcurrency() *ConcurrencyPolicy {
+	if m != nil {
+		return m.Concurrency
+	}
+	return nil
+}
+
+func (m *WorkflowSpec) GetInputs() *TypeSchema {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *WorkflowSpec) GetOutput() *TypeSchema {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (m *WorkflowSpec) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *WorkflowSpec) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+func (m *WorkflowSpec) GetExpressionLanguage() string {
+	if m != nil {
+		return m.ExpressionLanguage
+	}
+	return ""
+}
+
+// TypeSchema describes the expected type and structure of a value. It is a subset of JSON Schema.
+type TypeSchema struct {
+	// Type is the type of the value: one of 'string', 'number', 'integer', 'boolean', 'object', 'array' or
+	// 'null'. If empty, any type of value is accepted.
+	Type string `protobuf:"bytes,1,opt,name=type" json:"type,omitempty"`
+	// Properties contains the schemas of the properties of an object. Properties that are not listed are allowed.
+	Properties map[string]*TypeSchema `protobuf:"bytes,2,rep,name=properties" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Required lists the properties that an object should contain.
+	Required []string `protobuf:"bytes,3,rep,name=required" json:"required,omitempty"`
+	// Items is the schema of the elements of an array.
+	Items       *TypeSchema `protobuf:"bytes,4,opt,name=items" json:"items,omitempty"`
+	Description string      `protobuf:"bytes,5,opt,name=description" json:"description,omitempty"`
+}
+
+func (m *TypeSchema) Reset()                    { *m = TypeSchema{} }
+func (m *TypeSchema) String() string            { return proto.CompactTextString(m) }
+func (*TypeSchema) ProtoMessage()               {}
+func (*TypeSchema) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *TypeSchema) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *TypeSchema) GetProperties() map[string]*TypeSchema {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *TypeSchema) GetRequired() []string {
+	if m != nil {
+		return m.Required
+	}
+	return nil
+}
+
+func (m *TypeSchema) GetItems() *TypeSchema {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+func (m *TypeSchema) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+// ConcurrencyPolicy describes the maximum number of tasks of a workflow that can run concurrently.
+type ConcurrencyPolicy struct {
+	// Max is the maximum number of concurrently running tasks of the workflow. If 0, the default limit of the
+	// workflow engine is used.
+	Max int32 `protobuf:"varint,1,opt,name=max" json:"max,omitempty"`
+	// Functions contains the maximum number of concurrently running tasks of the workflow per function. The key is
+	// either the function reference as used by the tasks (e.g. 'resize') or the resolved reference including the
+	// runtime (e.g. 'fission://resize').
+	Functions map[string]int32 `protobuf:"bytes,2,rep,name=functions" json:"functions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+}
+
+func (m *ConcurrencyPolicy) Reset()                    { *m = ConcurrencyPolicy{} }
+func (m *ConcurrencyPolicy) String() string            { return proto.CompactTextString(m) }
+func (*ConcurrencyPolicy) ProtoMessage()               {}
+func (*ConcurrencyPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *ConcurrencyPolicy) GetMax() int32 {
+	if m != nil {
+		return m.Max
+	}
+	return 0
+}
+
+func (m *ConcurrencyPolicy) GetFunctions() map[string]int32 {
+	if m != nil {
+		return m.Functions
+	}
+	return nil
+}
+
 type WorkflowStatus struct {
 	Status    WorkflowStatus_Status      `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.WorkflowStatus_Status" json:"status,omitempty"`
 	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
@@ -322,7 +546,7 @@ type WorkflowStatus struct {
 func (m *WorkflowStatus) Reset()                    { *m = WorkflowStatus{} }
 func (m *WorkflowStatus) String() string            { return proto.CompactTextString(m) }
 func (*WorkflowStatus) ProtoMessage()               {}
-func (*WorkflowStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+func (*WorkflowStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
 
 func (m *WorkflowStatus) GetStatus() WorkflowStatus_Status {
 	if m != nil {
@@ -364,7 +588,7 @@ type WorkflowInvocation struct {
 func (m *WorkflowInvocation) Reset()                    { *m = WorkflowInvocation{} }
 func (m *WorkflowInvocation) String() string            { return proto.CompactTextString(m) }
 func (*WorkflowInvocation) ProtoMessage()               {}
-func (*WorkflowInvocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+func (*WorkflowInvocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
 
 func (m *WorkflowInvocation) GetMetadata() *ObjectMetadata {
 	if m != nil {
@@ -391,6 +615,9 @@ func (m *WorkflowInvocation) GetStatus() *WorkflowInvocationStatus {
 type WorkflowInvocationSpec struct {
 	// WorkflowId contains a reference to the workflow that needs to be executed.
 	//
+	// Besides the id of the workflow, the reference can also be the name of the workflow (to invoke its latest
+	// revision) or <name>@<revision>. Upon invocation the reference is replaced by the id of the workflow.
+	//
 	// Deprecated: use workflow.metadata.id instead to reference the workflow.
 	WorkflowId string                                         `protobuf:"bytes,1,opt,name=workflowId" json:"workflowId,omitempty"`
 	Inputs     map[string]*fission_workflows_types.TypedValue `protobuf:"bytes,2,rep,name=inputs" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
@@ -408,12 +635,26 @@ type WorkflowInvocationSpec struct {
 	// Each invocation has a deadline. If no deadline is provided Fission Workflows uses a default deadline (typically
 	// 10 minutes).
 	Deadline *google_protobuf.Timestamp `protobuf:"bytes,5,opt,name=Deadline" json:"Deadline,omitempty"`
+	// TriggerId contains the id of the trigger that started this invocation, if any.
+	TriggerId string `protobuf:"bytes,6,opt,name=triggerId" json:"triggerId,omitempty"`
+	// Priority determines how the engine orders the evaluation of this invocation, and the execution of its tasks,
+	// relative to other invocations.
+	//
+	// Priorities are not strict: the engine uses weighted-fair queueing, which ensures that high-priority invocations
+	// receive the largest share of the engine's capacity, without starving the invocations of lower priorities.
+	Priority WorkflowInvocationSpec_Priority `protobuf:"varint,7,opt,name=priority,enum=fission.workflows.types.WorkflowInvocationSpec_Priority" json:"priority,omitempty"`
+	// Labels and annotations are added to the metadata of the invocation.
+	//
+	// Invocations created by the workflow engine, such as the invocations of dynamic tasks, inherit the labels of the
+	// invocation that created them, in addition to their own labels.
+	Labels      map[string]string `protobuf:"bytes,8,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Annotations map[string]string `protobuf:"bytes,9,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *WorkflowInvocationSpec) Reset()                    { *m = WorkflowInvocationSpec{} }
 func (m *WorkflowInvocationSpec) String() string            { return proto.CompactTextString(m) }
 func (*WorkflowInvocationSpec) ProtoMessage()               {}
-func (*WorkflowInvocationSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+func (*WorkflowInvocationSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *WorkflowInvocationSpec) GetWorkflowId() string {
 	if m != nil {
@@ -450,6 +691,34 @@ func (m *WorkflowInvocationSpec) GetDeadline() *google_protobuf.Timestamp {
 	return nil
 }
 
+func (m *WorkflowInvocationSpec) GetTriggerId() string {
+	if m != nil {
+		return m.TriggerId
+	}
+	return ""
+}
+
+func (m *WorkflowInvocationSpec) GetPriority() WorkflowInvocationSpec_Priority {
+	if m != nil {
+		return m.Priority
+	}
+	return WorkflowInvocationSpec_NORMAL
+}
+
+func (m *WorkflowInvocationSpec) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *WorkflowInvocationSpec) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
 type WorkflowInvocationStatus struct {
 	Status    WorkflowInvocationStatus_Status     `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.WorkflowInvocationStatus_Status" json:"status,omitempty"`
 	UpdatedAt *google_protobuf.Timestamp          `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
@@ -465,7 +734,7 @@ type WorkflowInvocationStatus struct {
 func (m *WorkflowInvocationStatus) Reset()                    { *m = WorkflowInvocationStatus{} }
 func (m *WorkflowInvocationStatus) String() string            { return proto.CompactTextString(m) }
 func (*WorkflowInvocationStatus) ProtoMessage()               {}
-func (*WorkflowInvocationStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*WorkflowInvocationStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *WorkflowInvocationStatus) GetStatus() WorkflowInvocationStatus_Status {
 	if m != nil {
@@ -526,7 +795,7 @@ type DependencyConfig struct {
 func (m *DependencyConfig) Reset()                    { *m = DependencyConfig{} }
 func (m *DependencyConfig) String() string            { return proto.CompactTextString(m) }
 func (*DependencyConfig) ProtoMessage()               {}
-func (*DependencyConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*DependencyConfig) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *DependencyConfig) GetRequires() map[string]*TaskDependencyParameters {
 	if m != nil {
@@ -554,7 +823,7 @@ type Task struct {
 func (m *Task) Reset()                    { *m = Task{} }
 func (m *Task) String() string            { return proto.CompactTextString(m) }
 func (*Task) ProtoMessage()               {}
-func (*Task) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*Task) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *Task) GetMetadata() *ObjectMetadata {
 	if m != nil {
@@ -591,6 +860,9 @@ type TaskSpec struct {
 	// Dependencies for this task to execute.
 	Requires map[string]*TaskDependencyParameters `protobuf:"bytes,3,rep,name=requires" json:"requires,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Await signals the number of dependencies to wait for before this task can be started.
+	//
+	// If fewer dependencies are able to succeed, for example because they have been skipped, the task is skipped as
+	// well. If not set, all dependencies need to succeed.
 	Await int32 `protobuf:"varint,4,opt,name=await" json:"await,omitempty"`
 	// Output transforms or overrides the output of the executed function.
 	Output *fission_workflows_types.TypedValue `protobuf:"bytes,5,opt,name=output" json:"output,omitempty"`
@@ -601,12 +873,22 @@ type TaskSpec struct {
 	// It overrides the deadline specified by the workflow invocation, but cannot exceed it. If set, this field will be
 	// used in the task invocation spec to compute the deadline.
 	Timeout *google_protobuf1.Duration `protobuf:"bytes,7,opt,name=timeout" json:"timeout,omitempty"`
+	// Retry specifies whether and how the task should be retried when it fails.
+	//
+	// If not set, a failed task is not retried and will typically cause the workflow invocation to fail.
+	Retry *RetryPolicy `protobuf:"bytes,8,opt,name=retry" json:"retry,omitempty"`
+	// OnError is the ID of the task in the workflow that handles the failure of this task.
+	//
+	// The error handler is only run if this task fails, receiving the error as its 'error' input; otherwise it is
+	// skipped. If this task fails, the tasks depending on it are skipped, so that the invocation continues along the
+	// tasks depending on the error handler instead. If not set, a failure of this task fails the invocation.
+	OnError string `protobuf:"bytes,9,opt,name=onError" json:"onError,omitempty"`
 }
 
 func (m *TaskSpec) Reset()                    { *m = TaskSpec{} }
 func (m *TaskSpec) String() string            { return proto.CompactTextString(m) }
 func (*TaskSpec) ProtoMessage()               {}
-func (*TaskSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*TaskSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *TaskSpec) GetFunctionRef() string {
 	if m != nil {
@@ -657,6 +939,75 @@ func (m *TaskSpec) GetTimeout() *google_protobuf1.Duration {
 	return nil
 }
 
+func (m *TaskSpec) GetRetry() *RetryPolicy {
+	if m != nil {
+		return m.Retry
+	}
+	return nil
+}
+
+func (m *TaskSpec) GetOnError() string {
+	if m != nil {
+		return m.OnError
+	}
+	return ""
+}
+
+// RetryPolicy describes how a failed task should be retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times the task is executed, including the initial attempt.
+	MaxAttempts int32 `protobuf:"varint,1,opt,name=maxAttempts" json:"maxAttempts,omitempty"`
+	// Backoff determines how the delay between subsequent attempts grows.
+	Backoff RetryPolicy_Backoff `protobuf:"varint,2,opt,name=backoff,enum=fission.workflows.types.RetryPolicy_Backoff" json:"backoff,omitempty"`
+	// Delay is the delay before the first retry. In case of exponential backoff, this delay doubles for each retry.
+	Delay *google_protobuf1.Duration `protobuf:"bytes,3,opt,name=delay" json:"delay,omitempty"`
+	// MaxDelay is the upper bound on the delay between two attempts. If not set, the delay is unbounded.
+	MaxDelay *google_protobuf1.Duration `protobuf:"bytes,4,opt,name=maxDelay" json:"maxDelay,omitempty"`
+	// RetryOn contains regular expressions that are matched against the error message of the failed attempt. The task
+	// is only retried if the error matches one of them. If empty, all errors are considered to be retryable.
+	RetryOn []string `protobuf:"bytes,5,rep,name=retryOn" json:"retryOn,omitempty"`
+}
+
+func (m *RetryPolicy) Reset()                    { *m = RetryPolicy{} }
+func (m *RetryPolicy) String() string            { return proto.CompactTextString(m) }
+func (*RetryPolicy) ProtoMessage()               {}
+func (*RetryPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *RetryPolicy) GetMaxAttempts() int32 {
+	if m != nil {
+		return m.MaxAttempts
+	}
+	return 0
+}
+
+func (m *RetryPolicy) GetBackoff() RetryPolicy_Backoff {
+	if m != nil {
+		return m.Backoff
+	}
+	return RetryPolicy_EXPONENTIAL
+}
+
+func (m *RetryPolicy) GetDelay() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Delay
+	}
+	return nil
+}
+
+func (m *RetryPolicy) GetMaxDelay() *google_protobuf1.Duration {
+	if m != nil {
+		return m.MaxDelay
+	}
+	return nil
+}
+
+func (m *RetryPolicy) GetRetryOn() []string {
+	if m != nil {
+		return m.RetryOn
+	}
+	return nil
+}
+
 type TaskStatus struct {
 	Status    TaskStatus_Status          `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.TaskStatus_Status" json:"status,omitempty"`
 	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
@@ -667,7 +1018,7 @@ type TaskStatus struct {
 func (m *TaskStatus) Reset()                    { *m = TaskStatus{} }
 func (m *TaskStatus) String() string            { return proto.CompactTextString(m) }
 func (*TaskStatus) ProtoMessage()               {}
-func (*TaskStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*TaskStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *TaskStatus) GetStatus() TaskStatus_Status {
 	if m != nil {
@@ -705,7 +1056,7 @@ type TaskDependencyParameters struct {
 func (m *TaskDependencyParameters) Reset()                    { *m = TaskDependencyParameters{} }
 func (m *TaskDependencyParameters) String() string            { return proto.CompactTextString(m) }
 func (*TaskDependencyParameters) ProtoMessage()               {}
-func (*TaskDependencyParameters) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*TaskDependencyParameters) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *TaskDependencyParameters) GetType() TaskDependencyParameters_DependencyType {
 	if m != nil {
@@ -733,7 +1084,7 @@ type TaskInvocation struct {
 func (m *TaskInvocation) Reset()                    { *m = TaskInvocation{} }
 func (m *TaskInvocation) String() string            { return proto.CompactTextString(m) }
 func (*TaskInvocation) ProtoMessage()               {}
-func (*TaskInvocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*TaskInvocation) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *TaskInvocation) GetMetadata() *ObjectMetadata {
 	if m != nil {
@@ -781,7 +1132,7 @@ type TaskInvocationSpec struct {
 func (m *TaskInvocationSpec) Reset()                    { *m = TaskInvocationSpec{} }
 func (m *TaskInvocationSpec) String() string            { return proto.CompactTextString(m) }
 func (*TaskInvocationSpec) ProtoMessage()               {}
-func (*TaskInvocationSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*TaskInvocationSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *TaskInvocationSpec) GetFnRef() *FnRef {
 	if m != nil {
@@ -831,12 +1182,20 @@ type TaskInvocationStatus struct {
 	Output        *fission_workflows_types.TypedValue `protobuf:"bytes,3,opt,name=output" json:"output,omitempty"`
 	Error         *Error                              `protobuf:"bytes,4,opt,name=error" json:"error,omitempty"`
 	OutputHeaders *fission_workflows_types.TypedValue `protobuf:"bytes,5,opt,name=outputHeaders" json:"outputHeaders,omitempty"`
+	// Retries is the number of times that the task has been retried.
+	Retries int32 `protobuf:"varint,6,opt,name=retries" json:"retries,omitempty"`
+	// Signaled indicates that the task has received the signal that it was awaiting. The task remains in progress
+	// until the output of the signal has been transformed according to the spec of the task.
+	Signaled bool `protobuf:"varint,7,opt,name=signaled" json:"signaled,omitempty"`
+	// AwaitingSignal indicates that the function of the task has returned, but that the task is awaiting a signal to
+	// complete. Other tasks are in progress as long as their function is running.
+	AwaitingSignal bool `protobuf:"varint,8,opt,name=awaitingSignal" json:"awaitingSignal,omitempty"`
 }
 
 func (m *TaskInvocationStatus) Reset()                    { *m = TaskInvocationStatus{} }
 func (m *TaskInvocationStatus) String() string            { return proto.CompactTextString(m) }
 func (*TaskInvocationStatus) ProtoMessage()               {}
-func (*TaskInvocationStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*TaskInvocationStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *TaskInvocationStatus) GetStatus() TaskInvocationStatus_Status {
 	if m != nil {
@@ -873,6 +1232,178 @@ func (m *TaskInvocationStatus) GetOutputHeaders() *fission_workflows_types.Typed
 	return nil
 }
 
+func (m *TaskInvocationStatus) GetRetries() int32 {
+	if m != nil {
+		return m.Retries
+	}
+	return 0
+}
+
+func (m *TaskInvocationStatus) GetSignaled() bool {
+	if m != nil {
+		return m.Signaled
+	}
+	return false
+}
+
+func (m *TaskInvocationStatus) GetAwaitingSignal() bool {
+	if m != nil {
+		return m.AwaitingSignal
+	}
+	return false
+}
+
+// Trigger starts invocations of a workflow on a schedule.
+type Trigger struct {
+	Metadata *ObjectMetadata `protobuf:"bytes,1,opt,name=metadata" json:"metadata,omitempty"`
+	Spec     *TriggerSpec    `protobuf:"bytes,2,opt,name=spec" json:"spec,omitempty"`
+	Status   *TriggerStatus  `protobuf:"bytes,3,opt,name=status" json:"status,omitempty"`
+}
+
+func (m *Trigger) Reset()                    { *m = Trigger{} }
+func (m *Trigger) String() string            { return proto.CompactTextString(m) }
+func (*Trigger) ProtoMessage()               {}
+func (*Trigger) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+
+func (m *Trigger) GetMetadata() *ObjectMetadata {
+	if m != nil {
+		return m.Metadata
+	}
+	return nil
+}
+
+func (m *Trigger) GetSpec() *TriggerSpec {
+	if m != nil {
+		return m.Spec
+	}
+	return nil
+}
+
+func (m *Trigger) GetStatus() *TriggerStatus {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+type TriggerSpec struct {
+	// WorkflowId contains a reference to the workflow that should be invoked by the trigger. Similar to the
+	// WorkflowInvocationSpec, it can be the id, the name or <name>@<revision> of the workflow. The reference is resolved
+	// each time the trigger fires, so a trigger referencing a name always invokes the latest revision of the workflow.
+	WorkflowId string `protobuf:"bytes,1,opt,name=workflowId" json:"workflowId,omitempty"`
+	// Cron is the schedule of the trigger. It is either a standard cron expression with 5 fields (minute, hour, day of
+	// month, month, day of week), or a descriptor, such as @hourly, @daily or @every <duration>.
+	Cron string `protobuf:"bytes,2,opt,name=cron" json:"cron,omitempty"`
+	// Inputs are the inputs that are provided to each invocation started by the trigger.
+	Inputs map[string]*fission_workflows_types.TypedValue `protobuf:"bytes,3,rep,name=inputs" json:"inputs,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Timeout is the duration after which the invocations started by the trigger are canceled. If not provided, the
+	// default deadline of invocations is used.
+	Timeout *google_protobuf1.Duration `protobuf:"bytes,4,opt,name=timeout" json:"timeout,omitempty"`
+	// Labels and annotations are added to the metadata of the trigger. The invocations started by the trigger inherit
+	// the labels of the trigger.
+	Labels      map[string]string `protobuf:"bytes,5,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Annotations map[string]string `protobuf:"bytes,6,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *TriggerSpec) Reset()                    { *m = TriggerSpec{} }
+func (m *TriggerSpec) String() string            { return proto.CompactTextString(m) }
+func (*TriggerSpec) ProtoMessage()               {}
+func (*TriggerSpec) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+
+func (m *TriggerSpec) GetWorkflowId() string {
+	if m != nil {
+		return m.WorkflowId
+	}
+	return ""
+}
+
+func (m *TriggerSpec) GetCron() string {
+	if m != nil {
+		return m.Cron
+	}
+	return ""
+}
+
+func (m *TriggerSpec) GetInputs() map[string]*fission_workflows_types.TypedValue {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *TriggerSpec) GetTimeout() *google_protobuf1.Duration {
+	if m != nil {
+		return m.Timeout
+	}
+	return nil
+}
+
+func (m *TriggerSpec) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *TriggerSpec) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
+type TriggerStatus struct {
+	Status    TriggerStatus_Status       `protobuf:"varint,1,opt,name=status,enum=fission.workflows.types.TriggerStatus_Status" json:"status,omitempty"`
+	UpdatedAt *google_protobuf.Timestamp `protobuf:"bytes,2,opt,name=updatedAt" json:"updatedAt,omitempty"`
+	// LastFiredAt is the scheduled time at which the trigger fired for the last time.
+	LastFiredAt *google_protobuf.Timestamp `protobuf:"bytes,3,opt,name=lastFiredAt" json:"lastFiredAt,omitempty"`
+	// LastInvocationId is the id of the invocation that was started the last time the trigger fired. It is empty if
+	// the trigger failed to start an invocation.
+	LastInvocationId string `protobuf:"bytes,4,opt,name=lastInvocationId" json:"lastInvocationId,omitempty"`
+	// Error contains the reason why the trigger failed to start an invocation the last time it fired.
+	Error *Error `protobuf:"bytes,5,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *TriggerStatus) Reset()                    { *m = TriggerStatus{} }
+func (m *TriggerStatus) String() string            { return proto.CompactTextString(m) }
+func (*TriggerStatus) ProtoMessage()               {}
+func (*TriggerStatus) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+
+func (m *TriggerStatus) GetStatus() TriggerStatus_Status {
+	if m != nil {
+		return m.Status
+	}
+	return TriggerStatus_UNKNOWN
+}
+
+func (m *TriggerStatus) GetUpdatedAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return nil
+}
+
+func (m *TriggerStatus) GetLastFiredAt() *google_protobuf.Timestamp {
+	if m != nil {
+		return m.LastFiredAt
+	}
+	return nil
+}
+
+func (m *TriggerStatus) GetLastInvocationId() string {
+	if m != nil {
+		return m.LastInvocationId
+	}
+	return ""
+}
+
+func (m *TriggerStatus) GetError() *Error {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
 // ObjectMetadata contains common metadata present for all objects in the workflow engine.
 //
 // It closely follows the structure of Kubernetes' ObjectMetadata, leaving out the parameters that do not fit the
@@ -889,12 +1420,23 @@ type ObjectMetadata struct {
 	// Generation is a sequence identifier used and updated by the system to record the number of events or
 	// changes applied to the object.
 	Generation int64 `protobuf:"varint,4,opt,name=generation" json:"generation,omitempty"`
+	// Revision is the revision number of a named object, starting at 1. Each revision is a separate, immutable
+	// object; updating a named object creates a new revision with the same name.
+	//
+	// Unnamed objects do not have a revision (0).
+	Revision int64 `protobuf:"varint,5,opt,name=revision" json:"revision,omitempty"`
+	// Labels are user-defined key-value pairs to organize objects by, which can be selected by the label selectors
+	// of the List requests. The labels are set by the spec of the object upon creation.
+	Labels map[string]string `protobuf:"bytes,6,rep,name=labels" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Annotations are user-defined key-value pairs to attach non-identifying information to objects, such as
+	// descriptions or the tool that created the object. Unlike labels, annotations cannot be selected on.
+	Annotations map[string]string `protobuf:"bytes,7,rep,name=annotations" json:"annotations,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *ObjectMetadata) Reset()                    { *m = ObjectMetadata{} }
 func (m *ObjectMetadata) String() string            { return proto.CompactTextString(m) }
 func (*ObjectMetadata) ProtoMessage()               {}
-func (*ObjectMetadata) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*ObjectMetadata) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *ObjectMetadata) GetId() string {
 	if m != nil {
@@ -924,6 +1466,27 @@ func (m *ObjectMetadata) GetGeneration() int64 {
 	return 0
 }
 
+func (m *ObjectMetadata) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *ObjectMetadata) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *ObjectMetadata) GetAnnotations() map[string]string {
+	if m != nil {
+		return m.Annotations
+	}
+	return nil
+}
+
 type Error struct {
 	Message string `protobuf:"bytes,1,opt,name=message" json:"message,omitempty"`
 }
@@ -931,7 +1494,7 @@ type Error struct {
 func (m *Error) Reset()                    { *m = Error{} }
 func (m *Error) String() string            { return proto.CompactTextString(m) }
 func (*Error) ProtoMessage()               {}
-func (*Error) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*Error) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *Error) GetMessage() string {
 	if m != nil {
@@ -955,7 +1518,7 @@ type FnRef struct {
 func (m *FnRef) Reset()                    { *m = FnRef{} }
 func (m *FnRef) String() string            { return proto.CompactTextString(m) }
 func (*FnRef) ProtoMessage()               {}
-func (*FnRef) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*FnRef) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *FnRef) GetRuntime() string {
 	if m != nil {
@@ -986,7 +1549,7 @@ type TypedValueMap struct {
 func (m *TypedValueMap) Reset()                    { *m = TypedValueMap{} }
 func (m *TypedValueMap) String() string            { return proto.CompactTextString(m) }
 func (*TypedValueMap) ProtoMessage()               {}
-func (*TypedValueMap) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*TypedValueMap) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *TypedValueMap) GetValue() map[string]*fission_workflows_types.TypedValue {
 	if m != nil {
@@ -1003,7 +1566,7 @@ type TypedValueList struct {
 func (m *TypedValueList) Reset()                    { *m = TypedValueList{} }
 func (m *TypedValueList) String() string            { return proto.CompactTextString(m) }
 func (*TypedValueList) ProtoMessage()               {}
-func (*TypedValueList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*TypedValueList) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *TypedValueList) GetValue() []*fission_workflows_types.TypedValue {
 	if m != nil {
@@ -1015,6 +1578,8 @@ func (m *TypedValueList) GetValue() []*fission_workflows_types.TypedValue {
 func init() {
 	proto.RegisterType((*Workflow)(nil), "fission.workflows.types.Workflow")
 	proto.RegisterType((*WorkflowSpec)(nil), "fission.workflows.types.WorkflowSpec")
+	proto.RegisterType((*TypeSchema)(nil), "fission.workflows.types.TypeSchema")
+	proto.RegisterType((*ConcurrencyPolicy)(nil), "fission.workflows.types.ConcurrencyPolicy")
 	proto.RegisterType((*WorkflowStatus)(nil), "fission.workflows.types.WorkflowStatus")
 	proto.RegisterType((*WorkflowInvocation)(nil), "fission.workflows.types.WorkflowInvocation")
 	proto.RegisterType((*WorkflowInvocationSpec)(nil), "fission.workflows.types.WorkflowInvocationSpec")
@@ -1022,118 +1587,172 @@ func init() {
 	proto.RegisterType((*DependencyConfig)(nil), "fission.workflows.types.DependencyConfig")
 	proto.RegisterType((*Task)(nil), "fission.workflows.types.Task")
 	proto.RegisterType((*TaskSpec)(nil), "fission.workflows.types.TaskSpec")
+	proto.RegisterType((*RetryPolicy)(nil), "fission.workflows.types.RetryPolicy")
 	proto.RegisterType((*TaskStatus)(nil), "fission.workflows.types.TaskStatus")
 	proto.RegisterType((*TaskDependencyParameters)(nil), "fission.workflows.types.TaskDependencyParameters")
 	proto.RegisterType((*TaskInvocation)(nil), "fission.workflows.types.TaskInvocation")
 	proto.RegisterType((*TaskInvocationSpec)(nil), "fission.workflows.types.TaskInvocationSpec")
 	proto.RegisterType((*TaskInvocationStatus)(nil), "fission.workflows.types.TaskInvocationStatus")
+	proto.RegisterType((*Trigger)(nil), "fission.workflows.types.Trigger")
+	proto.RegisterType((*TriggerSpec)(nil), "fission.workflows.types.TriggerSpec")
+	proto.RegisterType((*TriggerStatus)(nil), "fission.workflows.types.TriggerStatus")
 	proto.RegisterType((*ObjectMetadata)(nil), "fission.workflows.types.ObjectMetadata")
 	proto.RegisterType((*Error)(nil), "fission.workflows.types.Error")
 	proto.RegisterType((*FnRef)(nil), "fission.workflows.types.FnRef")
 	proto.RegisterType((*TypedValueMap)(nil), "fission.workflows.types.TypedValueMap")
 	proto.RegisterType((*TypedValueList)(nil), "fission.workflows.types.TypedValueList")
 	proto.RegisterEnum("fission.workflows.types.WorkflowStatus_Status", WorkflowStatus_Status_name, WorkflowStatus_Status_value)
+	proto.RegisterEnum("fission.workflows.types.WorkflowInvocationSpec_Priority", WorkflowInvocationSpec_Priority_name, WorkflowInvocationSpec_Priority_value)
 	proto.RegisterEnum("fission.workflows.types.WorkflowInvocationStatus_Status", WorkflowInvocationStatus_Status_name, WorkflowInvocationStatus_Status_value)
+	proto.RegisterEnum("fission.workflows.types.RetryPolicy_Backoff", RetryPolicy_Backoff_name, RetryPolicy_Backoff_value)
 	proto.RegisterEnum("fission.workflows.types.TaskStatus_Status", TaskStatus_Status_name, TaskStatus_Status_value)
 	proto.RegisterEnum("fission.workflows.types.TaskDependencyParameters_DependencyType", TaskDependencyParameters_DependencyType_name, TaskDependencyParameters_DependencyType_value)
 	proto.RegisterEnum("fission.workflows.types.TaskInvocationStatus_Status", TaskInvocationStatus_Status_name, TaskInvocationStatus_Status_value)
+	proto.RegisterEnum("fission.workflows.types.TriggerStatus_Status", TriggerStatus_Status_name, TriggerStatus_Status_value)
 }
 
 func init() { proto.RegisterFile("pkg/types/types.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1487 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x58, 0xdd, 0x72, 0xdb, 0x44,
-	0x14, 0xae, 0x2c, 0xcb, 0x3f, 0xc7, 0x8d, 0x31, 0x3b, 0xa5, 0x18, 0x0f, 0x94, 0x54, 0x1d, 0xa6,
-	0x1d, 0xa0, 0x32, 0x49, 0x0b, 0x4d, 0x09, 0x9d, 0xe2, 0x5a, 0x4a, 0xab, 0xc9, 0x8f, 0x83, 0x6c,
-	0xb7, 0x53, 0x98, 0xb6, 0xa3, 0x58, 0x6b, 0xa3, 0x26, 0x96, 0x84, 0x24, 0xb7, 0x93, 0x3b, 0x9e,
-	0x80, 0x87, 0x60, 0xe0, 0x19, 0xb8, 0xe4, 0xa2, 0x37, 0xcc, 0xf0, 0x0c, 0x0c, 0x17, 0x5c, 0x71,
-	0xc1, 0x3b, 0x30, 0xbb, 0x5a, 0xfd, 0xd9, 0x71, 0x6c, 0x67, 0x5c, 0x86, 0x9b, 0x44, 0xbb, 0x7b,
-	0xce, 0xd9, 0xb3, 0xe7, 0xe7, 0xfb, 0x76, 0x0d, 0x6f, 0x39, 0x87, 0x83, 0xba, 0x7f, 0xec, 0x60,
-	0x2f, 0xf8, 0x2b, 0x39, 0xae, 0xed, 0xdb, 0xe8, 0xed, 0xbe, 0xe9, 0x79, 0xa6, 0x6d, 0x49, 0x2f,
-	0x6d, 0xf7, 0xb0, 0x7f, 0x64, 0xbf, 0xf4, 0x24, 0xba, 0x5c, 0x7b, 0x7f, 0x60, 0xdb, 0x83, 0x23,
-	0x5c, 0xa7, 0x62, 0x07, 0xa3, 0x7e, 0xdd, 0x37, 0x87, 0xd8, 0xf3, 0xf5, 0xa1, 0x13, 0x68, 0xd6,
-	0x2e, 0x8d, 0x0b, 0x18, 0x23, 0x57, 0xf7, 0x89, 0xa9, 0x60, 0x7d, 0x67, 0x60, 0xfa, 0xdf, 0x8e,
-	0x0e, 0xa4, 0x9e, 0x3d, 0xac, 0xb3, 0x4d, 0xc2, 0xff, 0xd7, 0xa3, 0xcd, 0xea, 0x69, 0xaf, 0x8c,
-	0x17, 0xfa, 0xd1, 0x28, 0xfd, 0x1d, 0x58, 0x13, 0x7f, 0xe7, 0xa0, 0xf0, 0x88, 0x69, 0xa1, 0x26,
-	0x14, 0x86, 0xd8, 0xd7, 0x0d, 0xdd, 0xd7, 0xab, 0xdc, 0x2a, 0x77, 0xad, 0xb4, 0x7e, 0x55, 0x9a,
-	0x72, 0x0e, 0xa9, 0x75, 0xf0, 0x1c, 0xf7, 0xfc, 0x5d, 0x26, 0xae, 0x45, 0x8a, 0xe8, 0x36, 0x64,
-	0x3d, 0x07, 0xf7, 0xaa, 0x19, 0x6a, 0xe0, 0x83, 0xa9, 0x06, 0xc2, 0x5d, 0xdb, 0x0e, 0xee, 0x69,
-	0x54, 0x05, 0xdd, 0x85, 0x9c, 0xe7, 0xeb, 0xfe, 0xc8, 0xab, 0xf2, 0x33, 0x76, 0x8f, 0x94, 0xa9,
-	0xb8, 0xc6, 0xd4, 0xc4, 0x3f, 0x33, 0x70, 0x3e, 0x69, 0x17, 0x5d, 0x02, 0xd0, 0x1d, 0xf3, 0x21,
-	0x76, 0x89, 0x15, 0x7a, 0xa6, 0xa2, 0x96, 0x98, 0x41, 0x5b, 0x20, 0xf8, 0xba, 0x77, 0xe8, 0x55,
-	0x33, 0xab, 0xfc, 0xb5, 0xd2, 0xfa, 0x27, 0x73, 0x79, 0x2b, 0x75, 0x88, 0x8a, 0x62, 0xf9, 0xee,
-	0xb1, 0x16, 0xa8, 0x93, 0x7d, 0xec, 0x91, 0xef, 0x8c, 0x7c, 0xb2, 0x44, 0xbd, 0x2f, 0x6a, 0x89,
-	0x19, 0xb4, 0x0a, 0x25, 0x03, 0x7b, 0x3d, 0xd7, 0x74, 0x48, 0x26, 0xab, 0x59, 0x2a, 0x90, 0x9c,
-	0x42, 0x55, 0xc8, 0xf7, 0x6d, 0xb7, 0x87, 0x55, 0xa3, 0x2a, 0xd0, 0xd5, 0x70, 0x88, 0x10, 0x64,
-	0x2d, 0x7d, 0x88, 0xab, 0x39, 0x3a, 0x4d, 0xbf, 0x51, 0x0d, 0x0a, 0xa6, 0xe5, 0x63, 0xd7, 0xd2,
-	0x8f, 0xaa, 0xf9, 0x55, 0xee, 0x5a, 0x41, 0x8b, 0xc6, 0xb5, 0x6f, 0x00, 0x62, 0x07, 0x51, 0x05,
-	0xf8, 0x43, 0x7c, 0xcc, 0x8e, 0x4e, 0x3e, 0xd1, 0x2d, 0x10, 0x68, 0x09, 0xb0, 0x0c, 0x5d, 0x9e,
-	0x7a, 0x66, 0x62, 0x85, 0x66, 0x27, 0x90, 0xff, 0x3c, 0xb3, 0xc1, 0x89, 0x3f, 0xf3, 0x50, 0x4e,
-	0x07, 0x1f, 0x6d, 0x45, 0x59, 0x23, 0x9b, 0x94, 0xd7, 0xa5, 0x39, 0xb3, 0x26, 0xa5, 0x93, 0x87,
-	0x36, 0xa0, 0x38, 0x72, 0x0c, 0xdd, 0xc7, 0x46, 0xc3, 0x67, 0xbe, 0xd5, 0xa4, 0xa0, 0x19, 0xa4,
-	0xb0, 0x19, 0xa4, 0x4e, 0xd8, 0x2d, 0x5a, 0x2c, 0x8c, 0x1e, 0x84, 0x59, 0xe4, 0x69, 0x16, 0xd7,
-	0xe7, 0x75, 0x60, 0x32, 0x8f, 0x37, 0x41, 0xc0, 0xae, 0x6b, 0xbb, 0x34, 0x43, 0xa5, 0xf5, 0x4b,
-	0x53, 0x2d, 0x29, 0x44, 0x4a, 0x0b, 0x84, 0x6b, 0x8f, 0x66, 0x44, 0xfc, 0x46, 0x3a, 0xe2, 0xef,
-	0x9d, 0x1a, 0xf1, 0x64, 0xb4, 0x37, 0x20, 0xc7, 0x82, 0x0c, 0x90, 0xfb, 0xaa, 0xab, 0x74, 0x15,
-	0xb9, 0x72, 0x0e, 0x15, 0x41, 0xd0, 0x94, 0x86, 0xfc, 0xb8, 0x92, 0x21, 0xd3, 0x5b, 0x0d, 0x75,
-	0x47, 0x91, 0x2b, 0x3c, 0x2a, 0x41, 0x5e, 0x56, 0x76, 0x94, 0x8e, 0x22, 0x57, 0xb2, 0xe2, 0xdf,
-	0x1c, 0xa0, 0xf0, 0xb4, 0xaa, 0xf5, 0xc2, 0xee, 0x51, 0x08, 0x59, 0x4e, 0x87, 0x37, 0x53, 0x1d,
-	0x5e, 0x9f, 0x19, 0xed, 0x78, 0xff, 0x44, 0xaf, 0xab, 0x63, 0xbd, 0xbe, 0xb6, 0x88, 0x99, 0x74,
-	0xd7, 0x7f, 0xcf, 0xc3, 0xc5, 0x93, 0xf7, 0x22, 0x7d, 0x19, 0x9a, 0x53, 0x8d, 0xb0, 0xff, 0xe3,
-	0x19, 0xd4, 0x86, 0x9c, 0x69, 0x39, 0x23, 0x3f, 0x04, 0x80, 0xcd, 0x05, 0x0f, 0x23, 0xa9, 0x54,
-	0x3b, 0xa8, 0x21, 0x66, 0x8a, 0x34, 0xa7, 0xa3, 0xbb, 0xd8, 0xf2, 0x55, 0x83, 0x41, 0x41, 0x34,
-	0x46, 0x77, 0xa0, 0x10, 0x5a, 0x66, 0x35, 0x76, 0x79, 0xe6, 0x96, 0x5a, 0xa4, 0x82, 0x3e, 0x83,
-	0x82, 0x8c, 0x75, 0xe3, 0xc8, 0xb4, 0x30, 0x85, 0x89, 0xd3, 0x5b, 0x24, 0x92, 0xad, 0x3d, 0x85,
-	0x52, 0xc2, 0xd3, 0x13, 0x4a, 0xf4, 0x76, 0xba, 0x44, 0xaf, 0x4c, 0x2f, 0x51, 0x42, 0x21, 0x0f,
-	0x89, 0x68, 0xb2, 0x50, 0x5f, 0xe5, 0xa0, 0x3a, 0x2d, 0x4f, 0x68, 0x7f, 0x0c, 0x20, 0x36, 0x16,
-	0x4e, 0xf5, 0xf2, 0xa0, 0x42, 0x4b, 0x43, 0xc5, 0x17, 0x8b, 0xbb, 0x32, 0x09, 0x1a, 0x9b, 0x90,
-	0x0b, 0xa0, 0x9e, 0x65, 0x74, 0xae, 0xe0, 0x31, 0x15, 0x34, 0x80, 0xf3, 0xc6, 0xb1, 0xa5, 0x0f,
-	0xcd, 0x1e, 0x35, 0x5c, 0x15, 0xa8, 0x5f, 0xcd, 0xc5, 0xfd, 0x92, 0x13, 0x56, 0x02, 0xf7, 0x52,
-	0x86, 0x63, 0x68, 0xcb, 0x2d, 0x00, 0x6d, 0x48, 0x85, 0x95, 0xc0, 0xd1, 0x07, 0x58, 0x37, 0xb0,
-	0xeb, 0x51, 0xb6, 0x99, 0xf3, 0x88, 0x69, 0xcd, 0x9a, 0x3e, 0x03, 0x25, 0xef, 0xa4, 0x4b, 0xf0,
-	0xea, 0xa9, 0x28, 0x19, 0x1f, 0x3f, 0x51, 0x86, 0xb5, 0xa7, 0xf0, 0xe6, 0x44, 0x18, 0x96, 0x89,
-	0xc7, 0x4f, 0x22, 0x3c, 0x2e, 0x41, 0xbe, 0xbb, 0xb7, 0xbd, 0xd7, 0x7a, 0xb4, 0x57, 0x39, 0x87,
-	0x56, 0xa0, 0xd8, 0x6e, 0x3e, 0x50, 0xe4, 0x2e, 0x01, 0x62, 0x0e, 0xbd, 0x01, 0x25, 0x75, 0xef,
-	0xd9, 0xbe, 0xd6, 0xba, 0xaf, 0x29, 0xed, 0x76, 0x25, 0x43, 0xd7, 0xbb, 0xcd, 0xa6, 0xa2, 0xc8,
-	0x14, 0xa8, 0x63, 0xd0, 0xce, 0x12, 0x3b, 0x8d, 0x7b, 0x2d, 0x8d, 0x80, 0xb6, 0x20, 0xfe, 0xc3,
-	0x41, 0x45, 0xc6, 0x0e, 0xb6, 0x0c, 0x6c, 0xf5, 0x8e, 0x9b, 0xb6, 0xd5, 0x37, 0x07, 0xa8, 0x0d,
-	0x05, 0x17, 0x7f, 0x37, 0x32, 0x5d, 0x4c, 0xfa, 0x87, 0x14, 0xc7, 0xad, 0xa9, 0xfe, 0x8e, 0x2b,
-	0x4b, 0x1a, 0xd3, 0x0c, 0x0a, 0x22, 0x32, 0x84, 0x2e, 0x80, 0xa0, 0xbf, 0xd4, 0xcd, 0xa0, 0x79,
-	0x04, 0x2d, 0x18, 0xd4, 0x2c, 0x58, 0x49, 0x29, 0x9c, 0x10, 0xba, 0xfb, 0xe9, 0xd0, 0xad, 0x9d,
-	0x1a, 0xba, 0xd8, 0x9d, 0x7d, 0xdd, 0xd5, 0x87, 0xd8, 0xc7, 0xae, 0x97, 0x0c, 0xe7, 0xaf, 0x1c,
-	0x64, 0xe9, 0xf5, 0x68, 0x29, 0xb4, 0xf4, 0x69, 0x8a, 0x96, 0xe6, 0xb8, 0xd6, 0x04, 0x44, 0xb4,
-	0x39, 0x46, 0x44, 0x57, 0x4e, 0x57, 0x4c, 0x53, 0xcf, 0x5f, 0x59, 0x28, 0x84, 0xf6, 0xc8, 0x25,
-	0xaf, 0x3f, 0xb2, 0x7a, 0xb4, 0x28, 0x71, 0x9f, 0x45, 0x2d, 0x39, 0x85, 0x94, 0x31, 0xba, 0xb9,
-	0x3e, 0xd3, 0xc9, 0x13, 0x09, 0x66, 0x3b, 0x51, 0x12, 0x01, 0x8e, 0xd5, 0x67, 0x1b, 0x9a, 0x59,
-	0x0a, 0xd9, 0x44, 0x29, 0x24, 0x30, 0x4d, 0x58, 0x1c, 0xd3, 0x26, 0x40, 0x23, 0x77, 0x56, 0xd0,
-	0x40, 0x37, 0x20, 0x4f, 0x1e, 0x48, 0xf6, 0xc8, 0x67, 0xc8, 0xf3, 0xce, 0x04, 0xce, 0xcb, 0xec,
-	0x7d, 0xa4, 0x85, 0x92, 0xaf, 0x9b, 0xed, 0xfe, 0xf3, 0x3e, 0xf9, 0x29, 0x13, 0x40, 0x27, 0xc3,
-	0x9e, 0x7b, 0x63, 0x7c, 0xfa, 0xe1, 0x1c, 0x15, 0xbb, 0x3c, 0x06, 0xbd, 0x09, 0x42, 0x9f, 0xd6,
-	0x37, 0x3f, 0x83, 0x47, 0xb6, 0x88, 0x94, 0x16, 0x08, 0x9f, 0xed, 0x62, 0x2d, 0x7e, 0x9c, 0xc4,
-	0xdb, 0x76, 0xa7, 0x41, 0x71, 0x32, 0x71, 0x01, 0xe6, 0x12, 0x58, 0x9a, 0x11, 0x5f, 0x71, 0x50,
-	0x9d, 0x16, 0x4e, 0xd4, 0x81, 0x2c, 0xd9, 0x80, 0x85, 0xec, 0xcb, 0x85, 0xf3, 0x91, 0xc0, 0x56,
-	0x52, 0x14, 0x1a, 0xb5, 0x46, 0x9b, 0xe7, 0xc8, 0xd4, 0x3d, 0x1a, 0xc2, 0xa2, 0x16, 0x0c, 0xc4,
-	0x4d, 0x28, 0xa7, 0xa5, 0x51, 0x01, 0xb2, 0x72, 0xa3, 0xd3, 0xa8, 0x9c, 0x23, 0x07, 0x69, 0xb6,
-	0xf6, 0x3a, 0x5a, 0x6b, 0xa7, 0xc2, 0x21, 0x04, 0x65, 0xf9, 0xf1, 0x5e, 0x63, 0x57, 0x6d, 0x3e,
-	0x6b, 0x75, 0x3b, 0xfb, 0xdd, 0x4e, 0x25, 0x23, 0xfe, 0xc1, 0x41, 0x39, 0xcd, 0x70, 0xcb, 0x81,
-	0xc7, 0xbb, 0x29, 0x78, 0xfc, 0x68, 0x4e, 0x76, 0x4d, 0x00, 0xa5, 0x32, 0x06, 0x94, 0xd7, 0xe7,
-	0x35, 0x91, 0x86, 0xcc, 0x1f, 0x79, 0x40, 0x93, 0x7b, 0xc4, 0x65, 0xc5, 0x2d, 0x52, 0x56, 0x17,
-	0x21, 0x47, 0xee, 0x60, 0xaa, 0xc1, 0x12, 0xc0, 0x46, 0xa8, 0x15, 0x01, 0x2d, 0x3f, 0x83, 0x32,
-	0x27, 0x5d, 0x39, 0x11, 0x72, 0x45, 0x38, 0x6f, 0x46, 0x52, 0xaa, 0xc1, 0x5e, 0xf0, 0xa9, 0x39,
-	0xb4, 0x06, 0x59, 0xb2, 0x3d, 0x43, 0xcc, 0x19, 0xb7, 0x0a, 0x2a, 0x9a, 0xba, 0xcf, 0xe7, 0xfe,
-	0x47, 0xf7, 0xf9, 0xdf, 0x78, 0xb8, 0x70, 0x52, 0x16, 0xd1, 0xce, 0x18, 0xf6, 0xdc, 0x5c, 0xa8,
-	0x08, 0x96, 0x87, 0x42, 0x31, 0x3f, 0xf1, 0x8b, 0xf3, 0xd3, 0x99, 0xc0, 0x68, 0x92, 0xd5, 0x84,
-	0xb3, 0xb2, 0x9a, 0xf8, 0xfc, 0xb5, 0xde, 0x23, 0x29, 0x58, 0x6e, 0xab, 0xfb, 0xfb, 0x8a, 0x5c,
-	0xc9, 0x89, 0x3f, 0x70, 0x50, 0x4e, 0x83, 0x02, 0x2a, 0x43, 0xc6, 0x0c, 0x5f, 0xc3, 0x19, 0x33,
-	0xfe, 0x85, 0x29, 0x93, 0xf8, 0x85, 0x69, 0x03, 0x8a, 0x3d, 0x17, 0xb3, 0xd4, 0xf0, 0xb3, 0x53,
-	0x13, 0x09, 0x93, 0x37, 0xf7, 0x00, 0x5b, 0x38, 0x20, 0x65, 0x1a, 0x62, 0x5e, 0x4b, 0xcc, 0x88,
-	0x97, 0x41, 0xa0, 0x71, 0x45, 0x55, 0xc8, 0x0f, 0xb1, 0xe7, 0xe9, 0x03, 0xcc, 0x7c, 0x09, 0x87,
-	0x62, 0x0b, 0x04, 0xda, 0xe6, 0x44, 0xc4, 0x1d, 0x59, 0x84, 0xd7, 0x99, 0x73, 0xe1, 0x10, 0xbd,
-	0x0b, 0x45, 0xe2, 0xa7, 0xe7, 0xe8, 0x3d, 0xcc, 0x5e, 0xd9, 0xf1, 0x04, 0x39, 0xa1, 0x2a, 0xb3,
-	0x26, 0xcd, 0xa8, 0xb2, 0xf8, 0x0b, 0x07, 0x2b, 0x71, 0x3a, 0x76, 0x75, 0x87, 0x10, 0x34, 0xfd,
-	0x66, 0x77, 0xea, 0xb5, 0x39, 0xb2, 0xb8, 0xab, 0x3b, 0x12, 0xfd, 0x60, 0xaf, 0x3f, 0xfa, 0x5d,
-	0x7b, 0x02, 0x10, 0x4f, 0x2e, 0xbf, 0x13, 0xb7, 0xa1, 0x1c, 0x2f, 0xec, 0x98, 0x9e, 0x4f, 0x0c,
-	0x26, 0x3d, 0x9f, 0xcf, 0x20, 0xfd, 0x77, 0x2f, 0xff, 0xb5, 0x40, 0x97, 0x0e, 0x72, 0x34, 0x85,
-	0x37, 0xfe, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x41, 0x5e, 0x49, 0xf4, 0xbe, 0x16, 0x00, 0x00,
+	// 2233 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x59, 0xcd, 0x93, 0xe3, 0x46,
+	0x15, 0x5f, 0xd9, 0x96, 0x3f, 0x9e, 0x77, 0x1c, 0xa7, 0x2b, 0x04, 0xe1, 0x82, 0xb0, 0x71, 0x20,
+	0x19, 0x96, 0xac, 0x26, 0x33, 0xbb, 0x9b, 0xcc, 0x64, 0x37, 0x09, 0x5e, 0xdb, 0xb3, 0xa3, 0x1a,
+	0x8f, 0x6d, 0x64, 0xcf, 0x4e, 0x12, 0x2a, 0x49, 0xf5, 0x58, 0x6d, 0x47, 0x19, 0x5b, 0x12, 0x92,
+	0xbc, 0xbb, 0xf3, 0xef, 0xf0, 0xf5, 0x2f, 0x50, 0x54, 0x51, 0x70, 0xc8, 0x85, 0x2a, 0xaa, 0x38,
+	0xc0, 0x99, 0x2a, 0xae, 0x1c, 0x38, 0x73, 0xa5, 0xba, 0xd5, 0xfa, 0xf2, 0xc7, 0x58, 0x1e, 0xbc,
+	0x40, 0x15, 0x17, 0x5b, 0xdd, 0x7a, 0xef, 0x75, 0xf7, 0xeb, 0xf7, 0x7e, 0xbf, 0xa7, 0x6e, 0xf8,
+	0x86, 0x75, 0x31, 0xda, 0x71, 0x2f, 0x2d, 0xe2, 0x78, 0xbf, 0xb2, 0x65, 0x9b, 0xae, 0x89, 0xbe,
+	0x39, 0xd4, 0x1d, 0x47, 0x37, 0x0d, 0xf9, 0x99, 0x69, 0x5f, 0x0c, 0xc7, 0xe6, 0x33, 0x47, 0x66,
+	0xaf, 0x2b, 0xdf, 0x1d, 0x99, 0xe6, 0x68, 0x4c, 0x76, 0x98, 0xd8, 0xf9, 0x74, 0xb8, 0xe3, 0xea,
+	0x13, 0xe2, 0xb8, 0x78, 0x62, 0x79, 0x9a, 0x95, 0xd7, 0x66, 0x05, 0xb4, 0xa9, 0x8d, 0x5d, 0x6a,
+	0xca, 0x7b, 0xdf, 0x1a, 0xe9, 0xee, 0x97, 0xd3, 0x73, 0x79, 0x60, 0x4e, 0x76, 0xf8, 0x20, 0xfe,
+	0xff, 0x9d, 0x60, 0xb0, 0x9d, 0xf8, 0xac, 0xb4, 0xa7, 0x78, 0x3c, 0x8d, 0x3f, 0x7b, 0xd6, 0xaa,
+	0x7f, 0x14, 0x20, 0x7f, 0xc6, 0xb5, 0x50, 0x1d, 0xf2, 0x13, 0xe2, 0x62, 0x0d, 0xbb, 0x58, 0x12,
+	0x6e, 0x09, 0xdb, 0xc5, 0xbd, 0xb7, 0xe4, 0x25, 0xeb, 0x90, 0x3b, 0xe7, 0x5f, 0x91, 0x81, 0x7b,
+	0xc2, 0xc5, 0xd5, 0x40, 0x11, 0x1d, 0x40, 0xc6, 0xb1, 0xc8, 0x40, 0x4a, 0x31, 0x03, 0xdf, 0x5f,
+	0x6a, 0xc0, 0x1f, 0xb5, 0x67, 0x91, 0x81, 0xca, 0x54, 0xd0, 0x47, 0x90, 0x75, 0x5c, 0xec, 0x4e,
+	0x1d, 0x29, 0xbd, 0x62, 0xf4, 0x40, 0x99, 0x89, 0xab, 0x5c, 0xad, 0xfa, 0xcf, 0x2c, 0xdc, 0x8c,
+	0xda, 0x45, 0xaf, 0x01, 0x60, 0x4b, 0x7f, 0x42, 0x6c, 0x6a, 0x85, 0xad, 0xa9, 0xa0, 0x46, 0x7a,
+	0xd0, 0x21, 0x88, 0x2e, 0x76, 0x2e, 0x1c, 0x29, 0x75, 0x2b, 0xbd, 0x5d, 0xdc, 0x7b, 0x27, 0xd1,
+	0x6c, 0xe5, 0x3e, 0x55, 0x69, 0x1a, 0xae, 0x7d, 0xa9, 0x7a, 0xea, 0x74, 0x1c, 0x73, 0xea, 0x5a,
+	0x53, 0x97, 0xbe, 0x62, 0xb3, 0x2f, 0xa8, 0x91, 0x1e, 0x74, 0x0b, 0x8a, 0x1a, 0x71, 0x06, 0xb6,
+	0x6e, 0xd1, 0x9d, 0x94, 0x32, 0x4c, 0x20, 0xda, 0x85, 0x24, 0xc8, 0x0d, 0x4d, 0x7b, 0x40, 0x14,
+	0x4d, 0x12, 0xd9, 0x5b, 0xbf, 0x89, 0x10, 0x64, 0x0c, 0x3c, 0x21, 0x52, 0x96, 0x75, 0xb3, 0x67,
+	0x54, 0x81, 0xbc, 0x6e, 0xb8, 0xc4, 0x36, 0xf0, 0x58, 0xca, 0xdd, 0x12, 0xb6, 0xf3, 0x6a, 0xd0,
+	0x46, 0x2d, 0x28, 0x0e, 0x4c, 0x63, 0x30, 0xb5, 0x6d, 0x62, 0x0c, 0x2e, 0xa5, 0x3c, 0x73, 0xe5,
+	0xed, 0xa5, 0x2b, 0xab, 0x87, 0xb2, 0x5d, 0x73, 0xac, 0x0f, 0x2e, 0xd5, 0xa8, 0x3a, 0x7a, 0x00,
+	0x59, 0xdd, 0xb0, 0xa6, 0xae, 0x23, 0x15, 0x98, 0xa1, 0x37, 0x96, 0x1a, 0xea, 0x5f, 0x5a, 0xa4,
+	0x37, 0xf8, 0x92, 0x4c, 0xb0, 0xca, 0x55, 0xa8, 0xb2, 0xe7, 0x04, 0x09, 0xd6, 0x50, 0xf6, 0x54,
+	0x90, 0x02, 0xd9, 0x31, 0x3e, 0x27, 0x63, 0x47, 0x2a, 0xb2, 0xcd, 0xd9, 0x4d, 0xb6, 0x39, 0x2d,
+	0xa6, 0xe3, 0xed, 0x0e, 0x37, 0x80, 0x3e, 0x86, 0x22, 0x36, 0x0c, 0xd3, 0x65, 0x79, 0xe4, 0x48,
+	0x37, 0x99, 0xbd, 0x77, 0x93, 0xd9, 0xab, 0x85, 0x8a, 0x9e, 0xd1, 0xa8, 0x29, 0x24, 0x03, 0x22,
+	0xcf, 0x2d, 0x9b, 0x30, 0x43, 0x2d, 0x6c, 0x8c, 0xa6, 0x78, 0x44, 0xa4, 0x2d, 0xb6, 0x55, 0x0b,
+	0xde, 0x54, 0x7e, 0x02, 0x10, 0x46, 0x0f, 0x2a, 0x43, 0xfa, 0x82, 0x5c, 0xf2, 0xb8, 0xa4, 0x8f,
+	0xe8, 0x3d, 0x10, 0x59, 0x7e, 0xf2, 0xf4, 0x79, 0x7d, 0xb9, 0xc3, 0xb0, 0x73, 0xc1, 0x52, 0xc7,
+	0x93, 0x7f, 0x3f, 0xb5, 0x2f, 0x54, 0x0e, 0xa0, 0x18, 0x59, 0xfd, 0x02, 0xeb, 0xaf, 0x44, 0xad,
+	0x17, 0xa2, 0xaa, 0x1f, 0x42, 0x79, 0x76, 0xa1, 0xeb, 0xe8, 0x57, 0xff, 0x9c, 0x02, 0x08, 0xf7,
+	0x90, 0xc6, 0x2c, 0x9d, 0x26, 0xd7, 0x65, 0xcf, 0xa8, 0x07, 0x60, 0xd9, 0xa6, 0x45, 0x6c, 0x57,
+	0x27, 0x7e, 0xc2, 0xdd, 0x4d, 0x10, 0x10, 0x72, 0x37, 0xd0, 0xf2, 0x36, 0x20, 0x62, 0x86, 0x26,
+	0x82, 0x4d, 0x7e, 0x3a, 0xd5, 0x6d, 0xa2, 0x49, 0xe9, 0x5b, 0xe9, 0xed, 0x82, 0x1a, 0xb4, 0xd1,
+	0x01, 0x88, 0xba, 0x4b, 0x26, 0x8e, 0x94, 0x49, 0x1e, 0x7c, 0x9e, 0xc6, 0x6c, 0xbe, 0x8a, 0x73,
+	0xf9, 0x5a, 0x39, 0x87, 0x97, 0x66, 0xe6, 0xb5, 0xc0, 0x5f, 0x07, 0xf1, 0xdd, 0x4c, 0x36, 0x83,
+	0xd0, 0xa9, 0xbf, 0x15, 0xe0, 0xe5, 0xb9, 0xf4, 0xa4, 0xc3, 0x4c, 0xf0, 0x73, 0x36, 0x8c, 0xa8,
+	0xd2, 0x47, 0x74, 0x06, 0x85, 0xe1, 0xd4, 0x18, 0x78, 0xc1, 0xed, 0x39, 0xf6, 0x20, 0x79, 0xbe,
+	0xcb, 0x87, 0xbe, 0xae, 0xe7, 0xde, 0xd0, 0x56, 0xe5, 0x21, 0x94, 0xe2, 0x2f, 0x57, 0xc5, 0x84,
+	0x18, 0x9d, 0xfe, 0x2f, 0xd3, 0x50, 0x8a, 0x03, 0x35, 0x3a, 0x0c, 0x10, 0x9e, 0x5a, 0x28, 0xed,
+	0xc9, 0x09, 0x11, 0x5e, 0x8e, 0x03, 0x3d, 0xda, 0x87, 0xc2, 0xd4, 0xd2, 0xb0, 0x4b, 0xb4, 0x9a,
+	0xcb, 0x9d, 0x5b, 0x91, 0x3d, 0xe2, 0x94, 0x7d, 0xe2, 0x94, 0xfb, 0x3e, 0xb3, 0xaa, 0xa1, 0x30,
+	0x3a, 0xf2, 0x11, 0x3f, 0xcd, 0xfc, 0xb4, 0x97, 0x74, 0x02, 0xf3, 0x98, 0x7f, 0x0f, 0x44, 0x62,
+	0xdb, 0xa6, 0xcd, 0xc3, 0xeb, 0xb5, 0xa5, 0x96, 0x9a, 0x54, 0x4a, 0xf5, 0x84, 0x2b, 0x67, 0x2b,
+	0x00, 0xe0, 0x6e, 0x3c, 0x64, 0xbe, 0x73, 0x25, 0x00, 0x44, 0xbd, 0xbd, 0x0f, 0x59, 0xee, 0x64,
+	0x80, 0xec, 0x8f, 0x4f, 0x9b, 0xa7, 0xcd, 0x46, 0xf9, 0x06, 0x2a, 0x80, 0xa8, 0x36, 0x6b, 0x8d,
+	0x4f, 0xca, 0x29, 0xda, 0x7d, 0x58, 0x53, 0x5a, 0xcd, 0x46, 0x39, 0x8d, 0x8a, 0x90, 0x6b, 0x34,
+	0x5b, 0xcd, 0x7e, 0xb3, 0x51, 0xce, 0x54, 0xff, 0x2e, 0x00, 0xf2, 0x57, 0xab, 0x18, 0x4f, 0xcd,
+	0x01, 0x03, 0x81, 0xcd, 0x54, 0x03, 0xf5, 0x58, 0x35, 0xb0, 0xb3, 0xd2, 0xdb, 0xe1, 0xf8, 0x91,
+	0xba, 0x40, 0x99, 0xa9, 0x0b, 0x76, 0xd7, 0x31, 0x13, 0xaf, 0x10, 0xfe, 0x92, 0x85, 0x57, 0x17,
+	0x8f, 0x45, 0x39, 0xdc, 0x37, 0xa7, 0x68, 0x7e, 0xad, 0x10, 0xf6, 0xa0, 0x5e, 0xc0, 0x84, 0x5e,
+	0x8a, 0x3d, 0x58, 0x73, 0x31, 0xb2, 0xc2, 0xb4, 0x39, 0x33, 0x71, 0x86, 0xac, 0x40, 0xde, 0xc2,
+	0x36, 0x31, 0x5c, 0x45, 0xe3, 0x65, 0x43, 0xd0, 0x46, 0x1f, 0x40, 0xde, 0xb7, 0x2c, 0x65, 0x56,
+	0xd0, 0x81, 0x3f, 0xa4, 0x1a, 0xa8, 0xa0, 0x77, 0x21, 0xdf, 0x20, 0x58, 0x1b, 0xeb, 0x06, 0x91,
+	0xc4, 0x95, 0x29, 0x12, 0xc8, 0xa2, 0x6f, 0x43, 0xc1, 0xb5, 0xf5, 0xd1, 0x88, 0xd8, 0x8a, 0xc6,
+	0x8b, 0x8e, 0xb0, 0x03, 0xf5, 0x21, 0x6f, 0xd9, 0xba, 0x69, 0xeb, 0xee, 0x25, 0xab, 0x3c, 0x4a,
+	0x7b, 0xfb, 0xeb, 0xfa, 0xa1, 0xcb, 0xf5, 0xd5, 0xc0, 0x12, 0xf5, 0x2d, 0xe7, 0xfa, 0xfc, 0xf5,
+	0x7c, 0xbb, 0x88, 0xf5, 0xcf, 0xe3, 0xac, 0x5f, 0x60, 0x96, 0x7f, 0xb4, 0xae, 0xe5, 0x2b, 0xf9,
+	0xbf, 0xf2, 0x39, 0x14, 0x23, 0xdb, 0xfa, 0xef, 0x52, 0x80, 0xf6, 0x84, 0x8a, 0xfe, 0x8f, 0x50,
+	0xfa, 0x0f, 0x20, 0xef, 0xef, 0x14, 0xc5, 0x8e, 0x76, 0x47, 0x3d, 0xa9, 0xb5, 0xca, 0x37, 0x50,
+	0x1e, 0x32, 0x47, 0xca, 0xe3, 0xa3, 0xb2, 0x80, 0x72, 0x90, 0x6e, 0x75, 0xce, 0xca, 0xa9, 0xea,
+	0xd7, 0x59, 0x90, 0x96, 0xa5, 0x1e, 0xea, 0xce, 0x60, 0xfe, 0xfe, 0xda, 0xd9, 0xbb, 0x39, 0xf4,
+	0x57, 0xe3, 0xe8, 0xff, 0x70, 0xfd, 0xa9, 0xcc, 0xf3, 0x40, 0x58, 0xe4, 0x66, 0x92, 0x6f, 0x31,
+	0x57, 0x41, 0x23, 0xb8, 0xa9, 0x5d, 0x1a, 0x78, 0xa2, 0x0f, 0x98, 0x61, 0x49, 0x64, 0xf3, 0xaa,
+	0xaf, 0x3f, 0xaf, 0x46, 0xc4, 0x8a, 0x37, 0xbd, 0x98, 0xe1, 0x90, 0xad, 0xb2, 0x6b, 0xb0, 0x15,
+	0x52, 0x60, 0xcb, 0x9b, 0xe8, 0x11, 0xc1, 0x1a, 0xb1, 0x1d, 0x29, 0x97, 0x7c, 0x89, 0x71, 0xcd,
+	0x0a, 0x5e, 0x41, 0x7c, 0x1f, 0xc4, 0x13, 0xe5, 0xad, 0x2b, 0x89, 0x2f, 0x5c, 0x7e, 0x34, 0xe2,
+	0x3f, 0x87, 0x97, 0xe7, 0xdc, 0xb0, 0x49, 0x8a, 0xfd, 0x2c, 0xa0, 0xd8, 0x22, 0xe4, 0x4e, 0xdb,
+	0xc7, 0xed, 0xce, 0x59, 0xbb, 0x7c, 0x03, 0x6d, 0x41, 0xa1, 0x57, 0x3f, 0x6a, 0x36, 0x4e, 0x29,
+	0xb7, 0x0a, 0xe8, 0x25, 0x28, 0x2a, 0xed, 0x2f, 0xba, 0x6a, 0xe7, 0xb1, 0xda, 0xec, 0xf5, 0xca,
+	0x29, 0xf6, 0xfe, 0xb4, 0x5e, 0x6f, 0x36, 0x1b, 0x8c, 0x7b, 0x43, 0x1e, 0xce, 0x50, 0x3b, 0xb5,
+	0x47, 0x1d, 0x95, 0xf2, 0xb0, 0x58, 0xfd, 0x87, 0x00, 0xe5, 0x06, 0xb1, 0x88, 0xa1, 0xd1, 0xe2,
+	0xac, 0x6e, 0x1a, 0x43, 0x7d, 0x84, 0x7a, 0x41, 0x81, 0x4b, 0xf3, 0x87, 0x06, 0xc7, 0x7b, 0x4b,
+	0xe7, 0x3b, 0xab, 0x2c, 0xab, 0x5c, 0xd3, 0x0b, 0x88, 0xc0, 0x10, 0x4d, 0x7a, 0xfc, 0x0c, 0xeb,
+	0xae, 0x5f, 0xb3, 0xb1, 0x46, 0xc5, 0x80, 0xad, 0x98, 0xc2, 0x02, 0xd7, 0x3d, 0x8e, 0xbb, 0x6e,
+	0xf7, 0x4a, 0xd7, 0x85, 0xd3, 0xe9, 0x62, 0x1b, 0x4f, 0x88, 0x4b, 0x6c, 0x27, 0xea, 0xce, 0xdf,
+	0x0b, 0x90, 0xa1, 0x72, 0x9b, 0xa9, 0x34, 0xee, 0xc7, 0x2a, 0x8d, 0x04, 0x1f, 0x4e, 0x4c, 0x9c,
+	0x66, 0x6f, 0xac, 0xb6, 0x78, 0xe3, 0x6a, 0xc5, 0x78, 0x35, 0xf1, 0x1b, 0x11, 0xf2, 0xbe, 0x3d,
+	0xfa, 0xcd, 0xe0, 0x57, 0xce, 0x2a, 0x19, 0x72, 0xaf, 0x45, 0xbb, 0x50, 0x73, 0xa6, 0x82, 0xb8,
+	0xb3, 0x72, 0x92, 0x0b, 0x6b, 0x86, 0xe3, 0x48, 0x48, 0x78, 0x38, 0xb6, 0xb3, 0xda, 0xd0, 0xca,
+	0x50, 0xc8, 0x44, 0x42, 0x21, 0x82, 0x69, 0xe2, 0xfa, 0x98, 0x36, 0x07, 0x1a, 0xd9, 0xeb, 0x82,
+	0x06, 0xba, 0x0b, 0x39, 0x7a, 0x3e, 0x66, 0x4e, 0x5d, 0x8e, 0x3c, 0xdf, 0x9a, 0xc3, 0xf9, 0x06,
+	0x3f, 0x1e, 0x53, 0x7d, 0x49, 0xf4, 0x3e, 0x88, 0x36, 0x71, 0x6d, 0xff, 0xe8, 0xe3, 0x7b, 0x4b,
+	0xc7, 0x55, 0xa9, 0x14, 0x3f, 0xf4, 0xf0, 0x54, 0xe8, 0x31, 0x8c, 0x69, 0x30, 0x08, 0x64, 0xe7,
+	0x1d, 0x05, 0xd5, 0x6f, 0xbe, 0x70, 0xa6, 0xff, 0x4f, 0x67, 0xdf, 0xcf, 0x53, 0x50, 0x8c, 0x38,
+	0x80, 0x86, 0xef, 0x04, 0x3f, 0xaf, 0xb9, 0x2e, 0x99, 0x58, 0xae, 0xc3, 0x3f, 0x2f, 0xa3, 0x5d,
+	0xe8, 0x10, 0x72, 0xe7, 0x78, 0x70, 0x61, 0x0e, 0x87, 0x6c, 0x02, 0xa5, 0xbd, 0xb7, 0x93, 0x78,
+	0x56, 0x7e, 0xe4, 0xe9, 0xa8, 0xbe, 0x32, 0xda, 0x01, 0x51, 0x23, 0x63, 0x7c, 0x29, 0xa5, 0x57,
+	0x6d, 0xa9, 0x27, 0x87, 0xee, 0x43, 0x7e, 0x82, 0x9f, 0x37, 0x98, 0x4e, 0x66, 0x95, 0x4e, 0x20,
+	0x4a, 0xf7, 0x92, 0x6d, 0x6a, 0xc7, 0x60, 0xb4, 0x5a, 0x50, 0xfd, 0x66, 0x75, 0x1b, 0x72, 0x7c,
+	0x56, 0x14, 0xad, 0x9b, 0x1f, 0x77, 0x3b, 0xed, 0x66, 0xbb, 0xaf, 0xb0, 0xf2, 0xe6, 0x26, 0xe4,
+	0xeb, 0x9d, 0x76, 0xaf, 0x5f, 0x6b, 0xf7, 0xcb, 0x42, 0xf5, 0x17, 0x29, 0x80, 0x30, 0xf1, 0xd1,
+	0xa3, 0x99, 0x5a, 0xe6, 0x76, 0x02, 0xb4, 0xd8, 0x5c, 0xf5, 0x72, 0x0f, 0xc4, 0x21, 0xc3, 0x96,
+	0xf4, 0x0a, 0x0e, 0x3f, 0xa4, 0x52, 0xaa, 0x27, 0x7c, 0xbd, 0xef, 0xd4, 0xea, 0xdb, 0x51, 0xae,
+	0xeb, 0xf5, 0x6b, 0x6a, 0x3f, 0xfe, 0x3d, 0x29, 0x44, 0x78, 0x2c, 0x55, 0xfd, 0x5a, 0x00, 0x69,
+	0x59, 0xd0, 0xa1, 0x7e, 0xe4, 0x30, 0xa8, 0x74, 0x45, 0x01, 0xbe, 0xcc, 0x40, 0x84, 0xd7, 0x68,
+	0xea, 0xf0, 0xe3, 0x24, 0x0a, 0x5c, 0x63, 0x1d, 0x3b, 0x7e, 0xe1, 0xca, 0x1a, 0xd5, 0x07, 0x50,
+	0x8a, 0x4b, 0xd3, 0x72, 0xb5, 0x51, 0xeb, 0xd7, 0xca, 0x37, 0xe8, 0x42, 0xea, 0x9d, 0x76, 0x5f,
+	0xed, 0xb4, 0xca, 0x02, 0x42, 0x50, 0x6a, 0x7c, 0xd2, 0xae, 0x9d, 0x28, 0xf5, 0x2f, 0x3a, 0xa7,
+	0xfd, 0xee, 0x69, 0xbf, 0x9c, 0xaa, 0xfe, 0x55, 0x80, 0x52, 0xbc, 0xba, 0xd8, 0x0c, 0x35, 0x7d,
+	0x14, 0xa3, 0xa6, 0x1f, 0x26, 0xac, 0x6c, 0x22, 0x24, 0xd5, 0x9c, 0x21, 0xa9, 0x3b, 0x49, 0x4d,
+	0xc4, 0xe9, 0xea, 0x67, 0x69, 0x40, 0xf3, 0x63, 0x84, 0x61, 0x25, 0xac, 0x13, 0x56, 0xaf, 0x42,
+	0x96, 0xd6, 0xbf, 0x8a, 0xc6, 0x37, 0x80, 0xb7, 0x50, 0x27, 0x20, 0xb9, 0xf4, 0x8a, 0x72, 0x65,
+	0x7e, 0x2a, 0x0b, 0xe9, 0xae, 0x0a, 0x37, 0xf5, 0x40, 0x4a, 0xd1, 0xf8, 0xe1, 0x79, 0xac, 0x0f,
+	0xed, 0x42, 0x86, 0x0e, 0x2f, 0x89, 0x49, 0x2a, 0x3a, 0x26, 0x1a, 0xfb, 0x3c, 0xce, 0x26, 0xff,
+	0x3c, 0x7e, 0xd1, 0x3c, 0x50, 0xfd, 0x55, 0x06, 0x5e, 0x59, 0xb4, 0x8b, 0xa8, 0x35, 0x83, 0x3d,
+	0xf7, 0xd6, 0x0a, 0x82, 0xcd, 0xa1, 0x50, 0x58, 0x1b, 0xa4, 0xd7, 0xaf, 0x0d, 0xae, 0x05, 0x46,
+	0xf3, 0x15, 0x85, 0x78, 0xed, 0x8a, 0x82, 0x93, 0x02, 0x3d, 0x82, 0xce, 0x32, 0x8a, 0xf3, 0x9b,
+	0xf4, 0x28, 0xc6, 0xd1, 0x47, 0x06, 0x1e, 0x13, 0xcd, 0xbf, 0x53, 0xf1, 0xdb, 0xe8, 0x4d, 0x28,
+	0xb1, 0xc2, 0x48, 0x37, 0x46, 0x3d, 0xd6, 0xc7, 0x6a, 0x8b, 0xbc, 0x3a, 0xd3, 0x5b, 0xfd, 0xea,
+	0x85, 0x7e, 0x21, 0xd0, 0x46, 0xef, 0x58, 0xe9, 0x76, 0x9b, 0x8d, 0x72, 0xb6, 0xfa, 0x07, 0x01,
+	0x72, 0x7d, 0xef, 0x5c, 0x66, 0x33, 0x30, 0xb5, 0x1f, 0x83, 0xa9, 0xe5, 0x65, 0x13, 0x1f, 0x34,
+	0x82, 0x4f, 0x1f, 0xce, 0xe0, 0xd3, 0x9b, 0x2b, 0x75, 0xe3, 0xc0, 0xf4, 0xbb, 0x0c, 0x14, 0x23,
+	0x56, 0x57, 0x1e, 0xc5, 0x21, 0xc8, 0x0c, 0x6c, 0xd3, 0xe0, 0xc8, 0xc3, 0x9e, 0xd1, 0xd1, 0x0c,
+	0xee, 0xbc, 0x93, 0x64, 0xfe, 0x0b, 0x01, 0x27, 0x52, 0x74, 0x66, 0x12, 0x17, 0x9d, 0x47, 0xc1,
+	0x09, 0x96, 0xb8, 0xc6, 0xf0, 0x8b, 0x8e, 0xad, 0xce, 0xe2, 0xc7, 0x56, 0x59, 0x66, 0xee, 0x7e,
+	0x22, 0x73, 0xff, 0xb7, 0x67, 0x55, 0x7f, 0x4b, 0xc1, 0x56, 0x2c, 0xb4, 0x22, 0x94, 0xe9, 0xa1,
+	0xe5, 0x9d, 0x64, 0x21, 0xb9, 0x39, 0x98, 0x7c, 0x08, 0xc5, 0x31, 0x76, 0xdc, 0x43, 0x7a, 0x15,
+	0x55, 0xf3, 0xb1, 0xf2, 0x2a, 0xdd, 0xa8, 0x38, 0xba, 0x0d, 0x65, 0xda, 0x54, 0xe6, 0x89, 0x6f,
+	0xae, 0x3f, 0xc4, 0x54, 0x71, 0x9d, 0x02, 0x4f, 0x5e, 0x0c, 0x55, 0x00, 0xd9, 0x5a, 0xbd, 0xaf,
+	0x3c, 0x69, 0x96, 0x85, 0xe8, 0x2d, 0x41, 0xaa, 0xfa, 0xa7, 0x34, 0x94, 0xe2, 0xd0, 0x81, 0x4a,
+	0x90, 0xd2, 0xfd, 0xf4, 0x4c, 0xe9, 0xe1, 0x4d, 0x75, 0x2a, 0x72, 0x53, 0xbd, 0x0f, 0x85, 0x81,
+	0x4d, 0xb0, 0x9b, 0xd0, 0x09, 0xa1, 0x30, 0x05, 0x81, 0x11, 0x31, 0x88, 0x97, 0x68, 0x6c, 0xf1,
+	0x69, 0x35, 0xd2, 0xe3, 0x5d, 0xfd, 0x3d, 0xd5, 0x1d, 0xff, 0x82, 0x2e, 0xad, 0x06, 0x6d, 0x74,
+	0x1c, 0x64, 0x63, 0x76, 0xc5, 0x3d, 0x63, 0x7c, 0x49, 0x0b, 0x13, 0xf2, 0xd3, 0x78, 0x42, 0xe6,
+	0x98, 0xc5, 0xfd, 0xa4, 0x16, 0xaf, 0xce, 0xc9, 0xff, 0x62, 0xce, 0xbc, 0x0e, 0x22, 0x0b, 0x08,
+	0x4a, 0x89, 0x13, 0xe2, 0x38, 0xf4, 0xe2, 0xda, 0x53, 0xf4, 0x9b, 0xd5, 0x0e, 0x88, 0xac, 0xe6,
+	0xa3, 0x22, 0xf6, 0xd4, 0xa0, 0x58, 0xc7, 0xed, 0xf8, 0x4d, 0x7a, 0x5b, 0x40, 0xf7, 0xd9, 0xb1,
+	0xf0, 0x80, 0xf0, 0x1b, 0x8c, 0xb0, 0x83, 0x46, 0x88, 0xd2, 0xe0, 0x81, 0x9b, 0x52, 0x1a, 0xd5,
+	0x5f, 0x0b, 0xb0, 0x15, 0x82, 0xc7, 0x09, 0xb6, 0xe8, 0x37, 0x2d, 0x7b, 0xe6, 0x87, 0x5b, 0xbb,
+	0x09, 0x30, 0xe7, 0x04, 0x5b, 0x32, 0x7b, 0xe0, 0xc7, 0xb0, 0xec, 0xb9, 0xf2, 0x19, 0x40, 0xd8,
+	0xb9, 0xf9, 0xb2, 0xec, 0x18, 0x4a, 0xe1, 0x8b, 0x96, 0xee, 0xb8, 0xd4, 0x60, 0x74, 0xe6, 0xc9,
+	0x0c, 0xb2, 0xbf, 0x47, 0xb9, 0x4f, 0x45, 0xf6, 0xea, 0x3c, 0xcb, 0x52, 0xe0, 0xee, 0xbf, 0x06,
+	0x00, 0xf4, 0x10, 0xe2, 0x90, 0x46, 0x24, 0x00, 0x00,
 }
@@ -0,0 +1,181 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+)
+
+const (
+	SchemaTypeString  = "string"
+	SchemaTypeNumber  = "number"
+	SchemaTypeInteger = "integer"
+	SchemaTypeBoolean = "boolean"
+	SchemaTypeObject  = "object"
+	SchemaTypeArray   = "array"
+	SchemaTypeNull    = "null"
+)
+
+var (
+	ErrInvalidSchemaType    = errors.New("unknown schema type")
+	ErrInvalidInputSchema   = errors.New("input schema should be of type 'object'")
+	ErrSchemaTypeMismatch   = errors.New("value does not match the expected type")
+	ErrMissingRequiredValue = errors.New("missing required value")
+)
+
+var schemaTypes = map[string]bool{
+	"":                true,
+	SchemaTypeString:  true,
+	SchemaTypeNumber:  true,
+	SchemaTypeInteger: true,
+	SchemaTypeBoolean: true,
+	SchemaTypeObject:  true,
+	SchemaTypeArray:   true,
+	SchemaTypeNull:    true,
+}
+
+// TypeSchema validates the schema itself, such as whether the types of the (nested) schemas are known.
+func TypeSchema(schema *types.TypeSchema) error {
+	errs := Error{subject: "TypeSchema"}
+	validateSchema("", schema, &errs)
+	return errs.getOrNil()
+}
+
+func validateSchema(path string, schema *types.TypeSchema, errs *Error) {
+	if schema == nil {
+		return
+	}
+	if !schemaTypes[schema.Type] {
+		errs.append(fmt.Errorf("%v: '%s'%s", ErrInvalidSchemaType, schema.Type, formatPath(path)))
+	}
+	for key, property := range schema.Properties {
+		validateSchema(path+"."+key, property, errs)
+	}
+	validateSchema(path+"[]", schema.Items, errs)
+}
+
+// WorkflowInputs validates the inputs of an invocation against the input schema of the workflow.
+func WorkflowInputs(spec *types.WorkflowSpec, inputs map[string]*typedvalues.TypedValue) error {
+	errs := Error{subject: "WorkflowInputs"}
+	schema := spec.GetInputs()
+	if schema == nil {
+		return nil
+	}
+	for _, key := range schema.Required {
+		if _, ok := inputs[key]; !ok {
+			errs.append(fmt.Errorf("%v: input '%s'", ErrMissingRequiredValue, key))
+		}
+	}
+	for key, property := range schema.Properties {
+		input, ok := inputs[key]
+		if !ok {
+			continue
+		}
+		validateTypedValue(key, property, input, &errs)
+	}
+	return errs.getOrNil()
+}
+
+// WorkflowOutput validates the output of an invocation against the output schema of the workflow.
+func WorkflowOutput(spec *types.WorkflowSpec, output *typedvalues.TypedValue) error {
+	errs := Error{subject: "WorkflowOutput"}
+	if spec.GetOutput() == nil {
+		return nil
+	}
+	validateTypedValue("output", spec.GetOutput(), output, &errs)
+	return errs.getOrNil()
+}
+
+// validateTypedValue checks the value against the schema. Expressions cannot be checked before they have been
+// evaluated, so these match any schema.
+func validateTypedValue(path string, schema *types.TypeSchema, tv *typedvalues.TypedValue, errs *Error) {
+	if tv.ValueType() == typedvalues.TypeExpression {
+		return
+	}
+	value, err := typedvalues.Unwrap(tv)
+	if err != nil {
+		errs.append(fmt.Errorf("%v: %s (%v)", ErrSchemaTypeMismatch, path, err))
+		return
+	}
+	validateValue(path, schema, value, errs)
+}
+
+func validateValue(path string, schema *types.TypeSchema, value interface{}, errs *Error) {
+	if schema == nil {
+		return
+	}
+	if len(schema.Type) > 0 && schemaTypeOf(value, schema.Type) != schema.Type {
+		errs.append(fmt.Errorf("%v: %s should be of type '%s', but was '%s'", ErrSchemaTypeMismatch, path,
+			schema.Type, schemaTypeOf(value, schema.Type)))
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range schema.Required {
+			if _, ok := v[key]; !ok {
+				errs.append(fmt.Errorf("%v: %s.%s", ErrMissingRequiredValue, path, key))
+			}
+		}
+		keys := make([]string, 0, len(schema.Properties))
+		for key := range schema.Properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if property, ok := v[key]; ok {
+				validateValue(path+"."+key, schema.Properties[key], property, errs)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			validateValue(fmt.Sprintf("%s[%d]", path, i), schema.Items, item, errs)
+		}
+	}
+}
+
+// schemaTypeOf returns the schema type of the value. Because numbers in JSON are not typed, a number is considered to
+// be an integer if it has no fractional part and the expected type is 'integer'.
+func schemaTypeOf(value interface{}, expected string) string {
+	switch v := value.(type) {
+	case nil:
+		return SchemaTypeNull
+	case string, []byte:
+		return SchemaTypeString
+	case bool:
+		return SchemaTypeBoolean
+	case int, int32, int64, uint32, uint64:
+		if expected == SchemaTypeNumber {
+			return SchemaTypeNumber
+		}
+		return SchemaTypeInteger
+	case float32:
+		if expected == SchemaTypeInteger && v == float32(math.Trunc(float64(v))) {
+			return SchemaTypeInteger
+		}
+		return SchemaTypeNumber
+	case float64:
+		if expected == SchemaTypeInteger && v == math.Trunc(v) {
+			return SchemaTypeInteger
+		}
+		return SchemaTypeNumber
+	case map[string]interface{}:
+		return SchemaTypeObject
+	case []interface{}:
+		return SchemaTypeArray
+	default:
+		return strings.ToLower(fmt.Sprintf("%T", v))
+	}
+}
+
+func formatPath(path string) string {
+	if len(path) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" at '%s'", strings.TrimPrefix(path, "."))
+}
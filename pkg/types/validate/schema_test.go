@@ -0,0 +1,101 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/stretchr/testify/assert"
+)
+
+func inputSchemaSpec() *types.WorkflowSpec {
+	return &types.WorkflowSpec{
+		Inputs: &types.TypeSchema{
+			Type:     SchemaTypeObject,
+			Required: []string{"name"},
+			Properties: map[string]*types.TypeSchema{
+				"name":  {Type: SchemaTypeString},
+				"count": {Type: SchemaTypeInteger},
+				"tags": {
+					Type:  SchemaTypeArray,
+					Items: &types.TypeSchema{Type: SchemaTypeString},
+				},
+				"options": {
+					Type:     SchemaTypeObject,
+					Required: []string{"loud"},
+					Properties: map[string]*types.TypeSchema{
+						"loud": {Type: SchemaTypeBoolean},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestWorkflowInputs(t *testing.T) {
+	spec := inputSchemaSpec()
+	err := WorkflowInputs(spec, typedvalues.MustWrapMapTypedValue(map[string]interface{}{
+		"name":    "world",
+		"count":   float64(3), // JSON numbers are floats
+		"tags":    []interface{}{"a", "b"},
+		"options": map[string]interface{}{"loud": true},
+		"other":   42,
+	}))
+	assert.NoError(t, err, Format(err))
+
+	// Expressions are evaluated later on, so they are not checked.
+	err = WorkflowInputs(spec, map[string]*typedvalues.TypedValue{
+		"name": typedvalues.MustWrap("{$.Invocation.Inputs.name}"),
+	})
+	assert.NoError(t, err, Format(err))
+
+	// Without a schema, any inputs are valid.
+	assert.NoError(t, WorkflowInputs(&types.WorkflowSpec{}, nil))
+}
+
+func TestWorkflowInputsInvalid(t *testing.T) {
+	spec := inputSchemaSpec()
+	err := WorkflowInputs(spec, typedvalues.MustWrapMapTypedValue(map[string]interface{}{
+		"count":   1.5,
+		"tags":    []interface{}{"a", 2},
+		"options": map[string]interface{}{},
+	}))
+	assert.Error(t, err)
+	assert.Len(t, err.(Error).Reasons(), 4)
+	assert.Contains(t, err.Error(), "input 'name'")
+	assert.Contains(t, err.Error(), "count should be of type 'integer', but was 'number'")
+	assert.Contains(t, err.Error(), "tags[1] should be of type 'string'")
+	assert.Contains(t, err.Error(), "options.loud")
+}
+
+func TestWorkflowOutput(t *testing.T) {
+	spec := &types.WorkflowSpec{
+		Output: &types.TypeSchema{Type: SchemaTypeString},
+	}
+	assert.NoError(t, WorkflowOutput(spec, typedvalues.MustWrap("hello")))
+
+	err := WorkflowOutput(spec, typedvalues.MustWrap(map[string]interface{}{}))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrSchemaTypeMismatch.Error())
+}
+
+func TestWorkflowSpecInvalidSchemas(t *testing.T) {
+	spec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "fakeFinalTask",
+		Tasks: map[string]*types.TaskSpec{
+			"fakeFinalTask": {
+				FunctionRef: "noop",
+			},
+		},
+		Inputs: &types.TypeSchema{Type: SchemaTypeString},
+		Output: &types.TypeSchema{
+			Type:  SchemaTypeArray,
+			Items: &types.TypeSchema{Type: "text"},
+		},
+	}
+	err := WorkflowSpec(spec)
+	assert.Error(t, err)
+	assert.True(t, err.(Error).Contains(ErrInvalidInputSchema))
+	assert.Contains(t, err.Error(), "unknown schema type: 'text' at '[]'")
+}
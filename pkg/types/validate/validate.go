@@ -7,12 +7,22 @@ package validate
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/expr"
 	"github.com/fission/fission-workflows/pkg/types/graph"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues/controlflow"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/robfig/cron"
 	"gonum.org/v1/gonum/graph/topo"
+
+	// Register the expression languages of the resolvers that evaluate expressions.
+	_ "github.com/fission/fission-workflows/pkg/controller/expr"
 )
 
 var (
@@ -34,6 +44,23 @@ var (
 	ErrNoWorkflow                   = errors.New("workflow id is required")
 	ErrNoID                         = errors.New("id is required")
 	ErrNoStatus                     = errors.New("status is required")
+	ErrUndefinedErrorHandler        = errors.New("task contains undefined error handler")
+	ErrErrorHandlerNotUnique        = errors.New("error handler is used by multiple tasks")
+	ErrInvalidErrorHandler          = errors.New("error handler cannot be the task itself or one of its dependencies")
+	ErrInvalidMaxAttempts           = errors.New("max attempts should be at least 1")
+	ErrInvalidBackoff               = errors.New("unknown backoff")
+	ErrInvalidDelay                 = errors.New("delay should be a non-negative duration")
+	ErrInvalidRetryOn               = errors.New("retryOn contains an invalid regular expression")
+	ErrInvalidWorkflowName          = errors.New("workflow name cannot contain '@'")
+	ErrInvalidSchedule              = errors.New("invalid cron expression")
+	ErrInvalidTimeout               = errors.New("timeout should be a positive duration")
+	ErrInvalidConcurrency           = errors.New("concurrency limit should be a non-negative number")
+	ErrInvalidPriority              = errors.New("unknown priority")
+	ErrInvalidExpression            = errors.New("task contains invalid expression")
+	ErrInvalidExpressionLanguage    = errors.New("unknown expression language")
+	ErrUndefinedTaskReference       = errors.New("expression references undefined task")
+	ErrTaskReferenceNotRequired     = errors.New("expression references task that is not a dependency")
+	ErrInvalidLabel                 = errors.New("label cannot be empty or contain whitespace or any of ',=!()'")
 )
 
 type Error struct {
@@ -110,10 +137,18 @@ func WorkflowSpec(spec *types.WorkflowSpec) error {
 		errs.append(fmt.Errorf("%v: '%v'", ErrInvalidAPIVersion, spec.GetApiVersion()))
 	}
 
+	if strings.Contains(spec.Name, types.RevisionDelimiter) {
+		errs.append(ErrInvalidWorkflowName)
+	}
+
 	if len(spec.Tasks) == 0 {
 		errs.append(ErrWorkflowWithoutTasks)
 	}
 
+	if lang := spec.GetExpressionLanguage(); len(lang) > 0 && !expr.IsLanguage(lang) {
+		errs.append(fmt.Errorf("%v: '%v'", ErrInvalidExpressionLanguage, lang))
+	}
+
 	_, ok := spec.Tasks[spec.OutputTask]
 	if !ok {
 		errs.append(ErrInvalidOutputTask)
@@ -149,6 +184,49 @@ func WorkflowSpec(spec *types.WorkflowSpec) error {
 		}
 	}
 
+	// Check the error handlers
+	handlers := map[string]string{}
+	for taskID, task := range spec.GetTasks() {
+		handler := task.GetOnError()
+		if len(handler) == 0 {
+			continue
+		}
+		if _, ok := refTable[handler]; !ok {
+			errs.append(fmt.Errorf("%v: '%v->%v'", ErrUndefinedErrorHandler, taskID, handler))
+		}
+		if _, ok := task.Requires[handler]; ok || handler == taskID {
+			errs.append(fmt.Errorf("%v: '%v->%v'", ErrInvalidErrorHandler, taskID, handler))
+		}
+		if other, ok := handlers[handler]; ok {
+			errs.append(fmt.Errorf("%v: '%v' (%v, %v)", ErrErrorHandlerNotUnique, handler, other, taskID))
+		}
+		handlers[handler] = taskID
+	}
+
+	if spec.Concurrency != nil {
+		errs.append(ConcurrencyPolicy(spec.Concurrency))
+	}
+
+	errs.append(Labels(spec.Labels))
+
+	if spec.Inputs != nil {
+		if len(spec.Inputs.Type) > 0 && spec.Inputs.Type != SchemaTypeObject {
+			errs.append(ErrInvalidInputSchema)
+		}
+		errs.append(TypeSchema(spec.Inputs))
+	}
+	if spec.Output != nil {
+		errs.append(TypeSchema(spec.Output))
+	}
+
+	// Check the references to other tasks in the expressions. The expressions of internal workflows, such as those
+	// created for dynamic tasks, can refer to the tasks of the invocation that created them, so these are skipped.
+	if !spec.Internal {
+		for _, taskID := range sortedTaskIDs(spec.GetTasks()) {
+			errs.append(taskReferences(spec, taskID))
+		}
+	}
+
 	// Check for circular dependencies
 	dg := graph.Parse(graph.NewTaskSpecIterator(spec.Tasks))
 	if len(topo.DirectedCyclesIn(dg)) > 0 {
@@ -163,6 +241,84 @@ func WorkflowSpec(spec *types.WorkflowSpec) error {
 	return errs.getOrNil()
 }
 
+// taskReferences checks whether the tasks that the expressions of a task refer to are defined and are guaranteed to
+// have completed by the time the expressions are evaluated. That is, the referenced task should be the task itself or
+// one of its (indirect) dependencies. A task that has an error handler is considered to be a dependency of the handler.
+func taskReferences(spec *types.WorkflowSpec, taskID string) error {
+	errs := Error{subject: "TaskSpec"}
+	task := spec.Tasks[taskID]
+	deps := upstreamTasks(spec, taskID)
+	for _, tv := range []*typedvalues.TypedValue{task.GetOutput(), task.GetOutputHeaders()} {
+		if tv != nil {
+			checkTaskReferences(spec, taskID, deps, tv, &errs)
+		}
+	}
+	for _, key := range sortedInputKeys(task.GetInputs()) {
+		checkTaskReferences(spec, taskID, deps, task.GetInputs()[key], &errs)
+	}
+	return errs.getOrNil()
+}
+
+func checkTaskReferences(spec *types.WorkflowSpec, taskID string, deps map[string]bool, tv *typedvalues.TypedValue,
+	errs *Error) {
+	refs, err := expr.TaskReferences(tv, spec.GetExpressionLanguage())
+	if err != nil {
+		errs.append(fmt.Errorf("%v: '%v' (%v)", ErrInvalidExpression, taskID, err))
+		return
+	}
+	for _, ref := range refs {
+		if _, ok := spec.Tasks[ref]; !ok {
+			errs.append(fmt.Errorf("%v: '%v->%v'", ErrUndefinedTaskReference, taskID, ref))
+		} else if ref != taskID && !deps[ref] {
+			errs.append(fmt.Errorf("%v: '%v->%v'", ErrTaskReferenceNotRequired, taskID, ref))
+		}
+	}
+}
+
+// upstreamTasks returns the ids of the tasks that have to complete before the task can start.
+func upstreamTasks(spec *types.WorkflowSpec, taskID string) map[string]bool {
+	upstream := map[string]bool{}
+	queue := []string{taskID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		var deps []string
+		for dep := range spec.Tasks[id].GetRequires() {
+			deps = append(deps, dep)
+		}
+		for other, task := range spec.Tasks {
+			if task.GetOnError() == id {
+				deps = append(deps, other)
+			}
+		}
+		for _, dep := range deps {
+			if !upstream[dep] {
+				upstream[dep] = true
+				queue = append(queue, dep)
+			}
+		}
+	}
+	return upstream
+}
+
+func sortedTaskIDs(tasks map[string]*types.TaskSpec) []string {
+	ids := make([]string, 0, len(tasks))
+	for id := range tasks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func sortedInputKeys(inputs map[string]*typedvalues.TypedValue) []string {
+	keys := make([]string, 0, len(inputs))
+	for key := range inputs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func TaskSpec(spec *types.TaskSpec) error {
 	errs := Error{subject: "TaskSpec"}
 
@@ -175,6 +331,94 @@ func TaskSpec(spec *types.TaskSpec) error {
 		errs.append(ErrTaskRequiresFnRef)
 	}
 
+	if spec.Retry != nil {
+		errs.append(RetryPolicy(spec.Retry))
+	}
+
+	return errs.getOrNil()
+}
+
+// Labels validates the labels of an object, which should not contain the characters that have a meaning in label
+// selectors. Values can be empty.
+func Labels(labels map[string]string) error {
+	errs := Error{subject: "Labels"}
+
+	for key, value := range labels {
+		if len(key) == 0 || !isLabel(key) || !isLabel(value) {
+			errs.append(fmt.Errorf("%v: '%v=%v'", ErrInvalidLabel, key, value))
+		}
+	}
+
+	return errs.getOrNil()
+}
+
+func isLabel(s string) bool {
+	for _, c := range s {
+		if unicode.IsSpace(c) || strings.ContainsRune(",=!()", c) {
+			return false
+		}
+	}
+	return true
+}
+
+func ConcurrencyPolicy(policy *types.ConcurrencyPolicy) error {
+	errs := Error{subject: "ConcurrencyPolicy"}
+
+	if policy == nil {
+		errs.append(ErrObjectEmpty)
+		return errs.getOrNil()
+	}
+
+	if policy.Max < 0 {
+		errs.append(ErrInvalidConcurrency)
+	}
+
+	for fn, limit := range policy.Functions {
+		if len(fn) == 0 {
+			errs.append(ErrTaskRequiresFnRef)
+		}
+		if limit < 0 {
+			errs.append(fmt.Errorf("%v: '%v'", ErrInvalidConcurrency, fn))
+		}
+	}
+
+	return errs.getOrNil()
+}
+
+func RetryPolicy(policy *types.RetryPolicy) error {
+	errs := Error{subject: "RetryPolicy"}
+
+	if policy == nil {
+		errs.append(ErrObjectEmpty)
+		return errs.getOrNil()
+	}
+
+	if policy.MaxAttempts < 1 {
+		errs.append(ErrInvalidMaxAttempts)
+	}
+
+	if _, ok := types.RetryPolicy_Backoff_name[int32(policy.Backoff)]; !ok {
+		errs.append(fmt.Errorf("%v: '%v'", ErrInvalidBackoff, policy.Backoff))
+	}
+
+	if policy.Delay != nil {
+		if d, err := ptypes.Duration(policy.Delay); err != nil || d < 0 {
+			errs.append(fmt.Errorf("%v: delay", ErrInvalidDelay))
+		}
+	}
+
+	if policy.MaxDelay != nil {
+		if d, err := ptypes.Duration(policy.MaxDelay); err != nil || d < 0 {
+			errs.append(fmt.Errorf("%v: maxDelay", ErrInvalidDelay))
+		}
+	}
+
+	for _, pattern := range policy.RetryOn {
+		if _, err := regexp.Compile(pattern); err != nil {
+			errs.append(fmt.Errorf("%v: '%v'", ErrInvalidRetryOn, pattern))
+		}
+	}
+
 	return errs.getOrNil()
 }
 
@@ -245,6 +489,39 @@ func WorkflowInvocationSpec(spec *types.WorkflowInvocationSpec) error {
 		errs.append(ErrNoWorkflow)
 	}
 
+	if _, ok := types.WorkflowInvocationSpec_Priority_name[int32(spec.Priority)]; !ok {
+		errs.append(ErrInvalidPriority)
+	}
+
+	errs.append(Labels(spec.Labels))
+
+	return errs.getOrNil()
+}
+
+func TriggerSpec(spec *types.TriggerSpec) error {
+	errs := Error{subject: "TriggerSpec"}
+
+	if spec == nil {
+		errs.append(ErrObjectEmpty)
+		return errs.getOrNil()
+	}
+
+	if len(spec.WorkflowId) == 0 {
+		errs.append(ErrNoWorkflow)
+	}
+
+	if _, err := cron.ParseStandard(spec.Cron); err != nil {
+		errs.append(fmt.Errorf("%v: '%v' (%v)", ErrInvalidSchedule, spec.Cron, err))
+	}
+
+	if spec.Timeout != nil {
+		if d, err := ptypes.Duration(spec.Timeout); err != nil || d <= 0 {
+			errs.append(ErrInvalidTimeout)
+		}
+	}
+
+	errs.append(Labels(spec.Labels))
+
 	return errs.getOrNil()
 }
 
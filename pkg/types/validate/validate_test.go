@@ -2,8 +2,11 @@ package validate
 
 import (
 	"testing"
+	"time"
 
 	"github.com/fission/fission-workflows/pkg/types"
+	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -48,6 +51,12 @@ func TestWorkflowSpecInvalidOutputTask(t *testing.T) {
 	assert.Error(t, WorkflowSpec(spec))
 }
 
+func TestWorkflowSpecInvalidName(t *testing.T) {
+	spec := validSpec()
+	spec.Name = "foo@2"
+	assert.Error(t, WorkflowSpec(spec))
+}
+
 func TestWorkflowSpecNoTasks(t *testing.T) {
 	spec := validSpec()
 	spec.Tasks = map[string]*types.TaskSpec{}
@@ -65,3 +74,159 @@ func TestWorkflowSpecInvalidCircularDependency(t *testing.T) {
 	spec.Tasks["first"].Require("last")
 	assert.Error(t, WorkflowSpec(spec))
 }
+
+func TestWorkflowSpecValidRetryPolicy(t *testing.T) {
+	spec := validSpec()
+	spec.Tasks["middle"].Retry = &types.RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     types.RetryPolicy_CONSTANT,
+		RetryOn:     []string{"^timeout$"},
+	}
+	err := WorkflowSpec(spec)
+	assert.NoError(t, err, Format(err))
+}
+
+func TestWorkflowSpecInvalidRetryPolicy(t *testing.T) {
+	spec := validSpec()
+	spec.Tasks["middle"].Retry = &types.RetryPolicy{
+		MaxAttempts: 0,
+		RetryOn:     []string{"("},
+	}
+	err := WorkflowSpec(spec)
+	assert.Error(t, err)
+	assert.True(t, err.(Error).Contains(ErrInvalidMaxAttempts))
+}
+
+func TestWorkflowSpecValidConcurrencyPolicy(t *testing.T) {
+	spec := validSpec()
+	spec.Concurrency = &types.ConcurrencyPolicy{
+		Max:       10,
+		Functions: map[string]int32{"fission://fn": 2},
+	}
+	err := WorkflowSpec(spec)
+	assert.NoError(t, err, Format(err))
+}
+
+func TestWorkflowSpecInvalidConcurrencyPolicy(t *testing.T) {
+	spec := validSpec()
+	spec.Concurrency = &types.ConcurrencyPolicy{
+		Max:       -1,
+		Functions: map[string]int32{"fn": -2},
+	}
+	err := WorkflowSpec(spec)
+	assert.Error(t, err)
+	assert.True(t, err.(Error).Contains(ErrInvalidConcurrency))
+}
+
+func TestWorkflowSpecValidErrorHandler(t *testing.T) {
+	spec := validSpec()
+	spec.Tasks["handler"] = &types.TaskSpec{
+		FunctionRef: "fn",
+	}
+	spec.Tasks["middle"].OnError = "handler"
+	err := WorkflowSpec(spec)
+	assert.NoError(t, err, Format(err))
+}
+
+func TestWorkflowSpecInvalidErrorHandler(t *testing.T) {
+	spec := validSpec()
+	spec.Tasks["middle"].OnError = "nonExistent"
+	err := WorkflowSpec(spec)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrUndefinedErrorHandler.Error())
+
+	spec = validSpec()
+	spec.Tasks["middle"].OnError = "first"
+	err = WorkflowSpec(spec)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidErrorHandler.Error())
+}
+
+func TestWorkflowSpecValidTaskReferences(t *testing.T) {
+	spec := validSpec()
+	spec.Tasks["handler"] = &types.TaskSpec{
+		FunctionRef: "fn",
+		Inputs:      types.Input("{ $.Tasks.middle.Status }"),
+	}
+	spec.Tasks["middle"].OnError = "handler"
+	spec.Tasks["last"].Inputs = types.Input("{ output('first') + output('middle') }")
+	spec.Tasks["last"].Output = typedvalues.MustWrap("{ path: output('last').field }")
+	err := WorkflowSpec(spec)
+	assert.NoError(t, err, Format(err))
+}
+
+func TestWorkflowSpecInvalidTaskReferences(t *testing.T) {
+	spec := validSpec()
+	spec.Tasks["middle"].Inputs = types.Input("{ output('frist') }")
+	spec.Tasks["first"].Inputs = types.Input("{ $.Tasks.last.Output }")
+	spec.Tasks["last"].Inputs = types.Input("{ output('first' }")
+	err := WorkflowSpec(spec)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrUndefinedTaskReference.Error()+": 'middle->frist'")
+	assert.Contains(t, err.Error(), ErrTaskReferenceNotRequired.Error()+": 'first->last'")
+	assert.Contains(t, err.Error(), ErrInvalidExpression.Error()+": 'last'")
+}
+
+func TestWorkflowSpecExpressionLanguage(t *testing.T) {
+	spec := validSpec()
+	spec.ExpressionLanguage = "path"
+	spec.Tasks["last"].Inputs = types.Input("{ output('middle').field }")
+	err := WorkflowSpec(spec)
+	assert.NoError(t, err, Format(err))
+
+	spec.ExpressionLanguage = "cobol"
+	err = WorkflowSpec(spec)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), ErrInvalidExpressionLanguage.Error())
+}
+
+func TestTriggerSpec(t *testing.T) {
+	for _, schedule := range []string{"*/5 * * * *", "0 9 * * 1-5", "@hourly", "@every 30s"} {
+		err := TriggerSpec(&types.TriggerSpec{
+			WorkflowId: "someWorkflow",
+			Cron:       schedule,
+			Timeout:    ptypes.DurationProto(time.Minute),
+		})
+		assert.NoError(t, err, Format(err))
+	}
+}
+
+func TestTriggerSpecInvalid(t *testing.T) {
+	err := TriggerSpec(&types.TriggerSpec{
+		Cron:    "* * *",
+		Timeout: ptypes.DurationProto(-time.Minute),
+	})
+	assert.Error(t, err)
+	assert.True(t, err.(Error).Contains(ErrNoWorkflow))
+	assert.True(t, err.(Error).Contains(ErrInvalidTimeout))
+	assert.Contains(t, err.Error(), ErrInvalidSchedule.Error())
+}
+
+func TestWorkflowInvocationSpecPriority(t *testing.T) {
+	spec := types.NewWorkflowInvocationSpec("someWorkflow", time.Now().Add(time.Minute))
+	spec.Priority = types.WorkflowInvocationSpec_LOW
+	assert.NoError(t, WorkflowInvocationSpec(spec))
+
+	spec.Priority = types.WorkflowInvocationSpec_Priority(42)
+	err := WorkflowInvocationSpec(spec)
+	assert.Error(t, err)
+	assert.True(t, err.(Error).Contains(ErrInvalidPriority))
+}
+
+func TestLabels(t *testing.T) {
+	assert.NoError(t, Labels(nil))
+	assert.NoError(t, Labels(map[string]string{"app": "shop", "team.io/owner": "", "env": "prod-1"}))
+	for _, labels := range []map[string]string{
+		{"": "value"},
+		{"app name": "shop"},
+		{"app": "a,b"},
+		{"!app": "shop"},
+		{"app": "(shop)"},
+	} {
+		assert.Error(t, Labels(labels), "%v", labels)
+	}
+
+	spec := validSpec()
+	spec.Labels = map[string]string{"app=shop": ""}
+	assert.Error(t, WorkflowSpec(spec))
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	RevisionDelimiter = "@"
+)
+
+var (
+	ErrInvalidWorkflowRef = errors.New("invalid workflow reference")
+)
+
+// WorkflowRef references a revision of a named workflow, formatted as `<name>[@<revision>]`.
+//
+// A zero revision references the latest revision of the workflow.
+type WorkflowRef struct {
+	Name     string
+	Revision int64
+}
+
+func (r WorkflowRef) Format() string {
+	if r.Revision <= 0 {
+		return r.Name
+	}
+	return r.Name + RevisionDelimiter + strconv.FormatInt(r.Revision, 10)
+}
+
+// Latest indicates whether the reference refers to the latest revision of the workflow.
+func (r WorkflowRef) Latest() bool {
+	return r.Revision <= 0
+}
+
+func ParseWorkflowRef(s string) (WorkflowRef, error) {
+	name := s
+	var revision int64
+	if i := strings.LastIndex(s, RevisionDelimiter); i >= 0 {
+		name = s[:i]
+		rev, err := strconv.ParseInt(s[i+len(RevisionDelimiter):], 10, 64)
+		if err != nil || rev <= 0 {
+			return WorkflowRef{}, ErrInvalidWorkflowRef
+		}
+		revision = rev
+	}
+	if len(name) == 0 {
+		return WorkflowRef{}, ErrInvalidWorkflowRef
+	}
+	return WorkflowRef{
+		Name:     name,
+		Revision: revision,
+	}, nil
+}
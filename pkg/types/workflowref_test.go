@@ -0,0 +1,36 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var workflowRefCases = map[string]struct {
+	WorkflowRef
+	err error
+}{
+	"foo":        {WorkflowRef{Name: "foo"}, nil},
+	"foo@1":      {WorkflowRef{Name: "foo", Revision: 1}, nil},
+	"foo-bar@42": {WorkflowRef{Name: "foo-bar", Revision: 42}, nil},
+
+	"":        {WorkflowRef{}, ErrInvalidWorkflowRef},
+	"@1":      {WorkflowRef{}, ErrInvalidWorkflowRef},
+	"foo@":    {WorkflowRef{}, ErrInvalidWorkflowRef},
+	"foo@0":   {WorkflowRef{}, ErrInvalidWorkflowRef},
+	"foo@-1":  {WorkflowRef{}, ErrInvalidWorkflowRef},
+	"foo@bar": {WorkflowRef{}, ErrInvalidWorkflowRef},
+}
+
+func TestParseWorkflowRef(t *testing.T) {
+	for input, expected := range workflowRefCases {
+		t.Run(input, func(t *testing.T) {
+			ref, err := ParseWorkflowRef(input)
+			assert.Equal(t, expected.err, err)
+			assert.Equal(t, expected.WorkflowRef, ref)
+			if err == nil {
+				assert.Equal(t, input, ref.Format())
+			}
+		})
+	}
+}
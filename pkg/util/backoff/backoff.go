@@ -52,6 +52,15 @@ func (i *Instance) C(ctx context.Context) <-chan int {
 	return c
 }
 
+// Duration returns the backoff duration for the current attempt, bounded by MaxBackoffDuration if set.
+func (i *Instance) Duration() time.Duration {
+	backoff := i.BackoffPolicy(i.Attempt, i.BaseRetryDuration)
+	if i.MaxBackoffDuration > 0 {
+		return min(backoff, i.MaxBackoffDuration)
+	}
+	return backoff
+}
+
 func New() *Instance {
 	return &Instance{}
 }
@@ -62,6 +71,10 @@ func ExponentialBackoff(i int, unit time.Duration) time.Duration {
 	return time.Duration(1<<uint(i)) * unit
 }
 
+func ConstantBackoff(i int, unit time.Duration) time.Duration {
+	return unit
+}
+
 func min(l, r time.Duration) time.Duration {
 	if l < r {
 		return l
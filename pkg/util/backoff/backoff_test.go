@@ -27,3 +27,19 @@ func TestInstance_C(t *testing.T) {
 	assert.True(t, end.Sub(start) > 200*time.Millisecond)
 	assert.True(t, end.Sub(start) < 400*time.Millisecond)
 }
+
+func TestInstance_Duration(t *testing.T) {
+	i := Instance{
+		BackoffPolicy:      ExponentialBackoff,
+		BaseRetryDuration:  time.Second,
+		MaxBackoffDuration: 5 * time.Second,
+	}
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		i.Attempt = attempt
+		assert.Equal(t, expected, i.Duration())
+	}
+
+	i.BackoffPolicy = ConstantBackoff
+	i.MaxBackoffDuration = 0
+	assert.Equal(t, time.Second, i.Duration())
+}
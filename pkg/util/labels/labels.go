@@ -31,12 +31,24 @@ func (l Set) Get(label string) (value string, exists bool) {
 // If the set is nil, a set will be created.
 // If a label with the same name exists already in the set, it will be overwritten.
 func (l *Set) Set(label, value string) {
-	if l == nil {
+	if *l == nil {
 		*l = map[string]string{}
 	}
 	(*l)[label] = value
 }
 
+// Merge returns a new set containing the labels of the sets, in which the labels of later sets override the labels
+// with the same key of earlier sets. If none of the sets contain labels, nil is returned.
+func Merge(sets ...map[string]string) Set {
+	var merged Set
+	for _, set := range sets {
+		for label, value := range set {
+			merged.Set(label, value)
+		}
+	}
+	return merged
+}
+
 // InMatcher matches all labels where the label keys equals the key, and (optionally)
 // the value has to be in the set of accepted values.
 type InMatcher struct {
@@ -107,3 +119,36 @@ func (s OrMatcher) Matches(labels Labels) bool {
 	}
 	return false
 }
+
+// ExistsMatcher matches all labels that contain the key, regardless of its value.
+type ExistsMatcher struct {
+	Key string
+}
+
+// Exists matches all labels that contain the key, regardless of its value.
+func Exists(key string) ExistsMatcher {
+	return ExistsMatcher{
+		Key: key,
+	}
+}
+
+// Matches returns true if the labels contain the key of the existsMatcher.
+func (s ExistsMatcher) Matches(labels Labels) bool {
+	_, ok := labels.Get(s.Key)
+	return ok
+}
+
+// NotMatcher inverts a matcher, selecting all labels that are not selected by the matcher.
+type NotMatcher struct {
+	Matcher Matcher
+}
+
+// Not returns a matcher that selects all labels that are not selected by the matcher.
+func Not(m Matcher) NotMatcher {
+	return NotMatcher{m}
+}
+
+// Matches returns true if the labels are not selected by the matcher of the notMatcher.
+func (s NotMatcher) Matches(labels Labels) bool {
+	return !s.Matcher.Matches(labels)
+}
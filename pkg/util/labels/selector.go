@@ -0,0 +1,93 @@
+package labels
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var setRequirement = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+
+// Parse parses a label selector into a matcher. The selector consists of comma-separated requirements, all of which
+// have to be met by the labels. The supported requirements, following the selectors of Kubernetes, are:
+//
+//   key=value, key==value     the label equals the value
+//   key!=value                the label does not exist, or does not equal the value
+//   key in (value1, value2)   the label equals one of the values
+//   key notin (value1, ...)   the label does not exist, or does not equal any of the values
+//   key                       the label exists
+//   !key                      the label does not exist
+//
+// An empty selector matches all labels.
+func Parse(selector string) (Matcher, error) {
+	var matchers []Matcher
+	for _, req := range splitRequirements(selector) {
+		req = strings.TrimSpace(req)
+		if len(req) == 0 {
+			continue
+		}
+		m, err := parseRequirement(req)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	return And(matchers...), nil
+}
+
+func parseRequirement(req string) (Matcher, error) {
+	if match := setRequirement.FindStringSubmatch(req); match != nil {
+		var values []string
+		for _, value := range strings.Split(match[3], ",") {
+			values = append(values, strings.TrimSpace(value))
+		}
+		if match[2] == "notin" {
+			return Not(In(match[1], values...)), nil
+		}
+		return In(match[1], values...), nil
+	}
+
+	var m Matcher
+	var key string
+	if i := strings.Index(req, "!="); i >= 0 {
+		key = req[:i]
+		m = Not(In(strings.TrimSpace(key), strings.TrimSpace(req[i+2:])))
+	} else if i := strings.Index(req, "=="); i >= 0 {
+		key = req[:i]
+		m = In(strings.TrimSpace(key), strings.TrimSpace(req[i+2:]))
+	} else if i := strings.Index(req, "="); i >= 0 {
+		key = req[:i]
+		m = In(strings.TrimSpace(key), strings.TrimSpace(req[i+1:]))
+	} else if strings.HasPrefix(req, "!") {
+		key = req[1:]
+		m = Not(Exists(strings.TrimSpace(key)))
+	} else {
+		key = req
+		m = Exists(key)
+	}
+	if key = strings.TrimSpace(key); len(key) == 0 || strings.ContainsAny(key, " \t()!=") {
+		return nil, fmt.Errorf("invalid label selector requirement '%s'", req)
+	}
+	return m, nil
+}
+
+// splitRequirements splits the selector on the commas that are not part of a set of values.
+func splitRequirements(selector string) []string {
+	var reqs []string
+	depth := 0
+	start := 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				reqs = append(reqs, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(reqs, selector[start:])
+}
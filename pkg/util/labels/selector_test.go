@@ -0,0 +1,52 @@
+package labels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	set := Set{
+		"app":  "shop",
+		"tier": "backend",
+	}
+	cases := map[string]bool{
+		"":                               true,
+		"app=shop":                       true,
+		"app == shop":                    true,
+		"app=web":                        false,
+		"app!=web":                       true,
+		"missing!=web":                   true,
+		"app":                            true,
+		"!app":                           false,
+		"!missing":                       true,
+		"tier in (frontend, backend)":    true,
+		"tier notin (frontend, backend)": false,
+		"missing notin (a)":              true,
+		"app=shop,tier in (a,b)":         false,
+		"app=shop, tier in (backend,a)":  true,
+	}
+	for selector, expected := range cases {
+		m, err := Parse(selector)
+		if !assert.NoError(t, err, selector) {
+			continue
+		}
+		assert.Equal(t, expected, m.Matches(set), selector)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, selector := range []string{"=shop", "app in shop", "!", "a b"} {
+		_, err := Parse(selector)
+		assert.Error(t, err, selector)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	assert.Nil(t, Merge(nil, map[string]string{}))
+	parent := map[string]string{"app": "shop", "tier": "backend"}
+	merged := Merge(parent, map[string]string{"tier": "frontend"})
+	assert.Equal(t, Set{"app": "shop", "tier": "frontend"}, merged)
+	assert.Equal(t, "backend", parent["tier"])
+}
@@ -42,9 +42,18 @@ func NewNamedDelayingQueue(name string) DelayingInterface {
 	return newDelayingQueue(DefaultMaxSize, clock.RealClock{}, name)
 }
 
+// NewDelayingQueueFrom adds delayed queuing ability to an existing workqueue, such as a WeightedFairQueue.
+func NewDelayingQueueFrom(queue Interface) DelayingInterface {
+	return newDelayingQueueFrom(queue, clock.RealClock{})
+}
+
 func newDelayingQueue(maxSize int, clock clock.Clock, name string) DelayingInterface {
+	return newDelayingQueueFrom(NewNamed(maxSize, name), clock)
+}
+
+func newDelayingQueueFrom(queue Interface, clock clock.Clock) DelayingInterface {
 	ret := &delayingType{
-		Interface:       NewNamed(maxSize, name),
+		Interface:       queue,
 		clock:           clock,
 		heartbeat:       clock.Tick(maxWait),
 		stopCh:          make(chan struct{}),
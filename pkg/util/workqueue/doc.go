@@ -7,6 +7,8 @@
 // - workqueue.go 		- Added Replace field to allow subsequent Adds of the same ID to simply replace the value.
 // - delaying_queue.go 	- added non-blocking TryAddAfter.
 // - all 				- Replaced t and set types with interface{} and map[interface{}]interface{}
+// - delaying_queue.go 	- added NewDelayingQueueFrom to wrap other queue implementations.
+// - fair.go 			- added WeightedFairQueue, which is not part of upstream.
 //
 // upstream source: https://github.com/kubernetes/client-go/tree/master/util/workqueue
 package workqueue
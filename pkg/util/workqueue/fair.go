@@ -0,0 +1,267 @@
+package workqueue
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultWeight is the weight of the classes that have no weight configured in a WeightedFairQueue.
+const DefaultWeight = 1
+
+var (
+	fairQueueDepth = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "workflows",
+		Subsystem: "workqueue",
+		Name:      "depth",
+		Help:      "The number of items waiting in the queue by class",
+	}, []string{"queue", "class"})
+
+	fairQueueAdds = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "workflows",
+		Subsystem: "workqueue",
+		Name:      "adds_total",
+		Help:      "The number of items added to the queue by class",
+	}, []string{"queue", "class"})
+
+	fairQueueLatency = prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Namespace: "workflows",
+		Subsystem: "workqueue",
+		Name:      "queue_duration_seconds",
+		Help:      "How long items waited in the queue before being processed by class",
+	}, []string{"queue", "class"})
+)
+
+func init() {
+	prometheus.MustRegister(fairQueueDepth, fairQueueAdds, fairQueueLatency)
+}
+
+// WeightedFairQueue is a work queue that divides its items into classes, such as priorities. Within a class items
+// are processed in FIFO order, whereas the classes share the queue in proportion to their weight. For example, as long
+// as both have items waiting, a class with weight 4 has four items processed for each item of a class with weight 1.
+// Classes that have been idle do not build up credit to use later on.
+//
+// Other than the order, the queue has the same semantics as Type: items are deduplicated by their key, and an item
+// that is added again while it is being processed, is queued again once it is done.
+type WeightedFairQueue struct {
+	name    string
+	maxSize int
+	replace bool
+	weights map[string]int
+	classOf func(item interface{}) string
+
+	classes map[string]*fairClass
+	size    int
+
+	// vtime is the virtual time of the queue: the start tag of the last dequeued item.
+	vtime float64
+
+	dirty      map[interface{}]interface{}
+	processing map[interface{}]interface{}
+
+	cond         *sync.Cond
+	shuttingDown bool
+}
+
+type fairClass struct {
+	name   string
+	weight int
+	queue  []fairEntry
+
+	// finish is the virtual finish tag of the last dequeued item of the class.
+	finish float64
+}
+
+type fairEntry struct {
+	key      interface{}
+	queuedAt time.Time
+}
+
+// NewWeightedFairQueue creates a new weighted-fair queue. The classOf function determines the class of an item,
+// and weights contains the weights of the classes. Classes without a weight are assigned DefaultWeight. The name is
+// used to identify the queue in the metrics.
+func NewWeightedFairQueue(name string, maxSize int, replace bool, weights map[string]int,
+	classOf func(item interface{}) string) *WeightedFairQueue {
+	return &WeightedFairQueue{
+		name:       name,
+		maxSize:    maxSize,
+		replace:    replace,
+		weights:    weights,
+		classOf:    classOf,
+		classes:    map[string]*fairClass{},
+		dirty:      map[interface{}]interface{}{},
+		processing: map[interface{}]interface{}{},
+		cond:       sync.NewCond(&sync.Mutex{}),
+	}
+}
+
+// Add marks item as needing processing.
+func (q *WeightedFairQueue) Add(item interface{}) (accepted bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if q.shuttingDown {
+		return false
+	}
+
+	key := getKey(item)
+	if _, ok := q.dirty[key]; ok {
+		if q.replace {
+			q.dirty[key] = item
+		}
+		return true
+	}
+
+	if q.size >= q.maxSize {
+		return false
+	}
+
+	q.dirty[key] = item
+	if _, ok := q.processing[key]; ok {
+		return true
+	}
+
+	q.enqueue(key, item)
+	return true
+}
+
+// Len returns the current queue length, for informational purposes only.
+func (q *WeightedFairQueue) Len() int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	return q.size
+}
+
+// LenOf returns the current length of the queue of a class, for informational purposes only.
+func (q *WeightedFairQueue) LenOf(class string) int {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	if c, ok := q.classes[class]; ok {
+		return len(c.queue)
+	}
+	return 0
+}
+
+// Get blocks until it can return an item to be processed. If shutdown = true, the caller should end their goroutine.
+// You must call Done with item when you have finished processing it.
+func (q *WeightedFairQueue) Get() (item interface{}, shutdown bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	for q.size == 0 && !q.shuttingDown {
+		q.cond.Wait()
+	}
+	if q.size == 0 {
+		// We must be shutting down.
+		return nil, true
+	}
+
+	key := q.dequeue()
+	item = q.dirty[key]
+	q.processing[key] = item
+	delete(q.dirty, key)
+
+	return item, false
+}
+
+// Done marks item as done processing, and if it has been marked as dirty again while it was being processed, it will
+// be re-added to the queue for re-processing.
+func (q *WeightedFairQueue) Done(item interface{}) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	key := getKey(item)
+	delete(q.processing, key)
+	if dirty, ok := q.dirty[key]; ok {
+		q.enqueue(key, dirty)
+	}
+}
+
+// ShutDown will cause q to ignore all new items added to it. As soon as the worker goroutines have drained the
+// existing items in the queue, they will be instructed to exit.
+func (q *WeightedFairQueue) ShutDown() {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+	q.shuttingDown = true
+	q.cond.Broadcast()
+}
+
+func (q *WeightedFairQueue) ShuttingDown() bool {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	return q.shuttingDown
+}
+
+// enqueue appends the key to the queue of the class of the item. The caller should hold the lock.
+func (q *WeightedFairQueue) enqueue(key interface{}, item interface{}) {
+	name := q.classOf(item)
+	c, ok := q.classes[name]
+	if !ok {
+		weight, ok := q.weights[name]
+		if !ok || weight <= 0 {
+			weight = DefaultWeight
+		}
+		c = &fairClass{
+			name:   name,
+			weight: weight,
+		}
+		q.classes[name] = c
+	}
+	// A class that becomes active again starts at the current virtual time, rather than using the credit from the
+	// time that it was idle.
+	if len(c.queue) == 0 && c.finish < q.vtime {
+		c.finish = q.vtime
+	}
+	c.queue = append(c.queue, fairEntry{key: key, queuedAt: time.Now()})
+	q.size++
+
+	fairQueueAdds.WithLabelValues(q.name, name).Inc()
+	fairQueueDepth.WithLabelValues(q.name, name).Inc()
+	q.cond.Signal()
+}
+
+// dequeue removes and returns the next key of the class with the earliest virtual finish tag. The caller should hold
+// the lock and ensure that the queue is not empty.
+func (q *WeightedFairQueue) dequeue() interface{} {
+	var next *fairClass
+	var nextFinish float64
+	for _, c := range q.sortedClasses() {
+		if len(c.queue) == 0 {
+			continue
+		}
+		finish := c.finish + 1/float64(c.weight)
+		if next == nil || finish < nextFinish {
+			next = c
+			nextFinish = finish
+		}
+	}
+
+	if next.finish > q.vtime {
+		q.vtime = next.finish
+	}
+	next.finish = nextFinish
+	entry := next.queue[0]
+	next.queue = next.queue[1:]
+	q.size--
+
+	fairQueueDepth.WithLabelValues(q.name, next.name).Dec()
+	fairQueueLatency.WithLabelValues(q.name, next.name).Observe(time.Since(entry.queuedAt).Seconds())
+	return entry.key
+}
+
+// sortedClasses returns the classes ordered by descending weight, which ensures that ties are broken in favor of the
+// class with the highest weight.
+func (q *WeightedFairQueue) sortedClasses() []*fairClass {
+	classes := make([]*fairClass, 0, len(q.classes))
+	for _, c := range q.classes {
+		classes = append(classes, c)
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		if classes[i].weight != classes[j].weight {
+			return classes[i].weight > classes[j].weight
+		}
+		return classes[i].name < classes[j].name
+	})
+	return classes
+}
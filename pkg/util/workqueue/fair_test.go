@@ -0,0 +1,93 @@
+package workqueue_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fission/fission-workflows/pkg/util/workqueue"
+	"github.com/stretchr/testify/assert"
+)
+
+// classOfPrefix classifies string items by the part before the first '-', e.g. 'high-1' belongs to class 'high'.
+func classOfPrefix(item interface{}) string {
+	return strings.SplitN(item.(string), "-", 2)[0]
+}
+
+func drain(q workqueue.Interface, n int) []string {
+	var items []string
+	for i := 0; i < n; i++ {
+		item, _ := q.Get()
+		q.Done(item)
+		items = append(items, classOfPrefix(item))
+	}
+	return items
+}
+
+func TestWeightedFairQueue_Weights(t *testing.T) {
+	q := workqueue.NewWeightedFairQueue("test", 100, false, map[string]int{"high": 3, "low": 1}, classOfPrefix)
+	for _, item := range []string{"low-1", "low-2", "low-3", "high-1", "high-2", "high-3", "high-4", "high-5",
+		"high-6"} {
+		assert.True(t, q.Add(item))
+	}
+	assert.Equal(t, 9, q.Len())
+	assert.Equal(t, 3, q.LenOf("low"))
+
+	assert.Equal(t, []string{"high", "high", "high", "low", "high", "high", "high", "low", "low"}, drain(q, 9))
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestWeightedFairQueue_FIFOWithinClass(t *testing.T) {
+	q := workqueue.NewWeightedFairQueue("test", 100, false, nil, classOfPrefix)
+	q.Add("a-1")
+	q.Add("a-2")
+	q.Add("a-3")
+
+	for _, expected := range []string{"a-1", "a-2", "a-3"} {
+		item, _ := q.Get()
+		assert.Equal(t, expected, item)
+		q.Done(item)
+	}
+}
+
+func TestWeightedFairQueue_IdleClassHasNoCredit(t *testing.T) {
+	q := workqueue.NewWeightedFairQueue("test", 100, false, map[string]int{"high": 1, "low": 1}, classOfPrefix)
+	for _, item := range []string{"high-1", "high-2", "high-3", "high-4"} {
+		q.Add(item)
+	}
+	assert.Equal(t, []string{"high", "high"}, drain(q, 2))
+
+	// Low has been idle, so it should not be able to catch up by getting all of its items processed first.
+	q.Add("low-1")
+	q.Add("low-2")
+	q.Add("low-3")
+	assert.Equal(t, []string{"low", "high", "low", "high", "low"}, drain(q, 5))
+}
+
+func TestWeightedFairQueue_Dedup(t *testing.T) {
+	q := workqueue.NewWeightedFairQueue("test", 2, false, nil, classOfPrefix)
+	assert.True(t, q.Add("a-1"))
+	assert.True(t, q.Add("a-1"))
+	assert.Equal(t, 1, q.Len())
+	assert.True(t, q.Add("a-2"))
+	assert.False(t, q.Add("a-3"))
+
+	// Items that are added while being processed are queued again once done.
+	item, _ := q.Get()
+	assert.True(t, q.Add(item))
+	assert.Equal(t, 1, q.Len())
+	q.Done(item)
+	assert.Equal(t, 2, q.Len())
+}
+
+func TestWeightedFairQueue_ShutDown(t *testing.T) {
+	q := workqueue.NewWeightedFairQueue("test", 10, false, nil, classOfPrefix)
+	q.Add("a-1")
+	q.ShutDown()
+	assert.False(t, q.Add("a-2"))
+
+	item, shutdown := q.Get()
+	assert.Equal(t, "a-1", item)
+	assert.False(t, shutdown)
+	_, shutdown = q.Get()
+	assert.True(t, shutdown)
+}
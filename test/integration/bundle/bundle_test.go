@@ -5,20 +5,30 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/fission/fission-workflows/cmd/fission-workflows-bundle/bundle"
 	"github.com/fission/fission-workflows/pkg/api"
+	"github.com/fission/fission-workflows/pkg/api/events"
 	"github.com/fission/fission-workflows/pkg/apiserver"
+	"github.com/fission/fission-workflows/pkg/apiserver/httpclient"
+	"github.com/fission/fission-workflows/pkg/fes"
+	"github.com/fission/fission-workflows/pkg/fnenv"
+	"github.com/fission/fission-workflows/pkg/fnenv/mock"
 	"github.com/fission/fission-workflows/pkg/fnenv/native/builtin"
 	"github.com/fission/fission-workflows/pkg/types"
 	"github.com/fission/fission-workflows/pkg/types/typedvalues"
+	"github.com/fission/fission-workflows/pkg/types/validate"
 	"github.com/fission/fission-workflows/pkg/util"
 	"github.com/fission/fission-workflows/test/integration"
 	"github.com/golang/protobuf/ptypes"
-	"github.com/golang/protobuf/ptypes/empty"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
@@ -28,6 +38,7 @@ const (
 	testSuiteTimeout = 10 * time.Minute
 	testTimeout      = time.Minute
 	gRPCAddress      = ":5555"
+	httpAddress      = "http://localhost:8080"
 )
 
 func defaultDeadline() time.Time {
@@ -78,7 +89,7 @@ func TestWorkflowCreate(t *testing.T) {
 	assert.Equal(t, wf.Status.Status, types.WorkflowStatus_READY)
 
 	// Test workflow list
-	l, err := client.Workflow.List(ctx, &empty.Empty{})
+	l, err := client.Workflow.List(ctx, &apiserver.WorkflowListQuery{})
 	assert.NoError(t, err)
 	if len(l.Workflows) != 1 || l.Workflows[0] != wf.ID() {
 		t.Errorf("Listed workflows '%v' did not match expected workflow '%s'", l.Workflows, wf.ID())
@@ -473,6 +484,188 @@ func TestWorkflowCancellation(t *testing.T) {
 	assert.Equal(t, api.ErrInvocationCanceled, wfi.GetStatus().GetError().Error())
 }
 
+func TestInvocationRetry(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "second",
+		Tasks: types.Tasks{
+			"first": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("foo"),
+			},
+			"second": {
+				FunctionRef: builtin.Sleep,
+				Inputs:      types.Input("500ms"),
+				Requires:    types.Require("first"),
+			},
+		},
+	}
+
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+	assert.NoError(t, err)
+
+	// Cancel the invocation once the first task has succeeded
+	md, err := client.Invocation.Invoke(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+	awaitInvocation(ctx, t, client, md, func(wfi *types.WorkflowInvocation) bool {
+		return wfi.GetStatus().GetTasks()["first"].GetStatus().Successful()
+	})
+	_, err = client.Invocation.Cancel(ctx, md)
+	assert.NoError(t, err)
+	wfi := awaitInvocation(ctx, t, client, md, func(wfi *types.WorkflowInvocation) bool {
+		return wfi.GetStatus().Finished()
+	})
+	assert.Equal(t, types.WorkflowInvocationStatus_ABORTED, wfi.GetStatus().GetStatus())
+
+	// Retry the invocation, which should reuse the output of the first task
+	_, err = client.Invocation.Retry(ctx, md)
+	assert.NoError(t, err)
+	wfi = awaitInvocation(ctx, t, client, md, func(wfi *types.WorkflowInvocation) bool {
+		return wfi.GetStatus().Finished()
+	})
+	assert.True(t, wfi.GetStatus().Successful())
+
+	invocationEvents, err := client.Invocation.Events(ctx, md)
+	assert.NoError(t, err)
+	var succeeded int
+	for _, event := range invocationEvents.GetEvents() {
+		if event.GetType() == string(events.EventTaskSucceeded) && event.GetAggregate().GetId() == wfi.Status.Tasks["first"].ID() {
+			succeeded++
+		}
+	}
+	assert.Equal(t, 1, succeeded)
+
+	// Succeeded invocations cannot be retried
+	_, err = client.Invocation.Retry(ctx, md)
+	assert.Error(t, err)
+}
+
+func TestInvocationRetryDefaultDeadline(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+
+	// Shorten the default runtime to let the deadline of the invocation pass within the test.
+	engine := bundle.NewLocalEngine(&bundle.LocalOptions{
+		MaxRuntime: time.Second,
+	})
+	defer engine.Close()
+	awaitStatus := func(md *types.ObjectMetadata,
+		status types.WorkflowInvocationStatus_Status) *types.WorkflowInvocation {
+		for {
+			wfi, err := engine.Invocations.Get(ctx, md)
+			if err == nil && wfi.GetStatus().GetStatus() == status {
+				return wfi
+			}
+			select {
+			case <-ctx.Done():
+				t.Fatalf("invocation %s did not become %v: %v", md.GetId(), status, ctx.Err())
+			case <-time.After(50 * time.Millisecond):
+			}
+		}
+	}
+
+	wf, err := engine.Workflows.CreateSync(ctx, &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "sleep",
+		Tasks: types.Tasks{
+			"sleep": {
+				FunctionRef: builtin.Sleep,
+				Inputs:      types.Input("200ms"),
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	// Cancel the invocation, which does not specify a deadline, and wait until its default deadline has passed.
+	md, err := engine.Invocations.Invoke(ctx, &types.WorkflowInvocationSpec{WorkflowId: wf.ID()})
+	assert.NoError(t, err)
+	_, err = engine.Invocations.Cancel(ctx, md)
+	assert.NoError(t, err)
+	wfi := awaitStatus(md, types.WorkflowInvocationStatus_ABORTED)
+	deadline, err := wfi.Deadline(time.Second)
+	assert.NoError(t, err)
+	time.Sleep(time.Until(deadline))
+
+	// The retried invocation should be granted the default runtime again.
+	_, err = engine.Invocations.Retry(ctx, md)
+	assert.NoError(t, err)
+	wfi = awaitStatus(md, types.WorkflowInvocationStatus_SUCCEEDED)
+	assert.True(t, wfi.GetStatus().Successful())
+}
+
+func TestInvocationWatch(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "second",
+		Tasks: types.Tasks{
+			"first": {
+				FunctionRef: builtin.Sleep,
+				Inputs:      types.Input("100ms"),
+			},
+			"second": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("foo"),
+				Requires:    types.Require("first"),
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+	assert.NoError(t, err)
+
+	md, err := client.Invocation.Invoke(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+
+	// The gRPC stream should contain all events and end once the invocation has completed
+	stream, err := client.Invocation.Watch(ctx, md)
+	assert.NoError(t, err)
+	var grpcEvents []*fes.Event
+	for {
+		event, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			return
+		}
+		grpcEvents = append(grpcEvents, event)
+	}
+	if assert.NotEmpty(t, grpcEvents) {
+		assert.Equal(t, string(events.EventInvocationCreated), grpcEvents[0].GetType())
+		assert.Equal(t, string(events.EventInvocationCompleted), grpcEvents[len(grpcEvents)-1].GetType())
+	}
+	var succeeded int
+	for _, event := range grpcEvents {
+		if event.GetType() == string(events.EventTaskSucceeded) {
+			succeeded++
+		}
+	}
+	assert.Equal(t, 2, succeeded)
+
+	// The Server-Sent Events endpoint should replay the same events of the finished invocation
+	httpClient := httpclient.NewInvocationAPI(httpAddress, http.Client{})
+	var sseEvents []*fes.Event
+	err = httpClient.Watch(ctx, md.GetId(), func(event *fes.Event) error {
+		sseEvents = append(sseEvents, event)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, len(grpcEvents), len(sseEvents))
+
+	// Watching a non-existent invocation should fail
+	err = httpClient.Watch(ctx, "nonexistent", func(event *fes.Event) error {
+		return nil
+	})
+	assert.Error(t, err)
+}
+
 func TestInvocationInvalid(t *testing.T) {
 	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
 	defer cancelFn()
@@ -522,6 +715,629 @@ func TestInvocationFailed(t *testing.T) {
 	assert.Equal(t, len(wfSpec.Tasks), len(wfi.Status.Tasks))
 }
 
+func TestInvocationRetried(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	msg := "expected error"
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task1",
+		Tasks: types.Tasks{
+			"task1": {
+				FunctionRef: builtin.Fail,
+				Inputs:      types.Input(msg),
+				Retry: &types.RetryPolicy{
+					MaxAttempts: 3,
+					Delay:       ptypes.DurationProto(10 * time.Millisecond),
+				},
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+	assert.NoError(t, err, err)
+	assert.NotNil(t, wf)
+	assert.NotEmpty(t, wf.ID())
+
+	wiSpec := types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline())
+	wfi, err := client.Invocation.InvokeSync(ctx, wiSpec)
+	assert.NoError(t, err)
+	assert.True(t, wfi.Status.Finished())
+	assert.False(t, wfi.Status.Successful())
+	assert.Equal(t, int32(2), wfi.Status.Tasks["task1"].GetStatus().GetRetries())
+
+	// Every attempt should be recorded in the history of the invocation
+	invocationEvents, err := client.Invocation.Events(ctx, wfi.GetMetadata())
+	assert.NoError(t, err)
+	var failures, retries int
+	for _, event := range invocationEvents.GetEvents() {
+		switch event.GetType() {
+		case string(events.EventTaskFailed):
+			failures++
+		case string(events.EventTaskRetried):
+			retries++
+		}
+	}
+	assert.Equal(t, 3, failures)
+	assert.Equal(t, 2, retries)
+}
+
+func TestInvocationRetryDoesNotBlockOtherTasks(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "failing",
+		Tasks: types.Tasks{
+			"failing": {
+				FunctionRef: builtin.Fail,
+				Inputs:      types.Input("expected error"),
+				Retry: &types.RetryPolicy{
+					MaxAttempts: 2,
+					Delay:       ptypes.DurationProto(time.Second),
+				},
+			},
+			"sleep": {
+				FunctionRef: builtin.Sleep,
+				Inputs:      types.Input("50ms"),
+			},
+			"independent": {
+				FunctionRef: builtin.Noop,
+				Requires:    types.Require("sleep"),
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+	assert.NoError(t, err, err)
+
+	wfi, err := client.Invocation.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+	assert.False(t, wfi.Status.Successful())
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, wfi.Status.Tasks["independent"].GetStatus().GetStatus())
+
+	// The independent task should have run while the failed task was waiting for its retry.
+	invocationEvents, err := client.Invocation.Events(ctx, wfi.GetMetadata())
+	assert.NoError(t, err)
+	var retriedAt time.Time
+	for _, event := range invocationEvents.GetEvents() {
+		if event.GetType() == string(events.EventTaskRetried) {
+			retriedAt, _ = ptypes.Timestamp(event.GetTimestamp())
+		}
+	}
+	completedAt, _ := ptypes.Timestamp(wfi.Status.Tasks["independent"].GetStatus().GetUpdatedAt())
+	assert.False(t, retriedAt.IsZero())
+	assert.True(t, completedAt.Before(retriedAt), "independent task completed at %v, after the retry at %v",
+		completedAt, retriedAt)
+}
+
+func TestInvocationList(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+	wf, err := client.Workflow.CreateSync(ctx, &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		Name:       "listed",
+		OutputTask: "task",
+		Tasks: types.Tasks{
+			"task": {
+				FunctionRef: builtin.Noop,
+			},
+		},
+	})
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+	assert.NoError(t, err)
+
+	var invocationIDs []string
+	for i := 0; i < 3; i++ {
+		wfi, err := client.Invocation.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+		assert.NoError(t, err)
+		invocationIDs = append([]string{wfi.ID()}, invocationIDs...)
+	}
+
+	// The invocations are listed from new to old in pages.
+	query := &apiserver.InvocationListQuery{
+		Workflows: []string{wf.ID()},
+		PageSize:  2,
+		Summaries: true,
+	}
+	page, err := client.Invocation.List(ctx, query)
+	assert.NoError(t, err)
+	assert.Equal(t, invocationIDs[:2], page.Invocations)
+	assert.NotEmpty(t, page.NextPageToken)
+	if assert.Len(t, page.Summaries, 2) {
+		assert.Equal(t, "listed", page.Summaries[0].WorkflowName)
+		assert.Equal(t, types.WorkflowInvocationStatus_SUCCEEDED, page.Summaries[0].Status)
+	}
+	query.PageToken = page.NextPageToken
+	page, err = client.Invocation.List(ctx, query)
+	assert.NoError(t, err)
+	assert.Equal(t, invocationIDs[2:], page.Invocations)
+	assert.Empty(t, page.NextPageToken)
+
+	page, err = client.Invocation.List(ctx, &apiserver.InvocationListQuery{
+		Statuses:      []types.WorkflowInvocationStatus_Status{types.WorkflowInvocationStatus_FAILED},
+		LabelSelector: "workflow=" + wf.ID(),
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, page.Invocations)
+
+	page, err = client.Invocation.List(ctx, &apiserver.InvocationListQuery{
+		LabelSelector: "workflow=" + wf.ID() + ",!parent",
+		CreatedAfter:  ptypes.TimestampNow(),
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, page.Invocations)
+
+	_, err = client.Invocation.List(ctx, &apiserver.InvocationListQuery{LabelSelector: "workflow in"})
+	assert.Error(t, err)
+
+	// The query should be passed on as-is by the HTTP gateway.
+	createdAfter, _ := ptypes.TimestampProto(time.Now().Add(-time.Hour))
+	httpClient := httpclient.NewInvocationAPI(httpAddress, http.Client{})
+	page, err = httpClient.List(ctx, &apiserver.InvocationListQuery{
+		Workflows:     []string{wf.ID()},
+		Statuses:      []types.WorkflowInvocationStatus_Status{types.WorkflowInvocationStatus_SUCCEEDED},
+		CreatedAfter:  createdAfter,
+		LabelSelector: "!parent",
+		PageSize:      1,
+		Summaries:     true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, invocationIDs[:1], page.Invocations)
+	assert.Len(t, page.Summaries, 1)
+	assert.NotEmpty(t, page.NextPageToken)
+}
+
+func TestLabels(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+	wf, err := client.Workflow.CreateSync(ctx, &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task",
+		Tasks: types.Tasks{
+			"task": {
+				FunctionRef: builtin.Noop,
+			},
+		},
+		Labels:      map[string]string{"team": "payments"},
+		Annotations: map[string]string{"description": "Labeled workflow"},
+	})
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+	assert.NoError(t, err)
+	assert.Equal(t, "payments", wf.GetMetadata().GetLabels()["team"])
+	assert.Equal(t, "Labeled workflow", wf.GetMetadata().GetAnnotations()["description"])
+
+	wfs, err := client.Workflow.List(ctx, &apiserver.WorkflowListQuery{LabelSelector: "team in (payments, ops)"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{wf.ID()}, wfs.Workflows)
+	wfs, err = client.Workflow.List(ctx, &apiserver.WorkflowListQuery{LabelSelector: "team=ops"})
+	assert.NoError(t, err)
+	assert.Empty(t, wfs.Workflows)
+
+	spec := types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline())
+	spec.Labels = map[string]string{"batch": "42"}
+	wfi, err := client.Invocation.InvokeSync(ctx, spec)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"batch": "42"}, wfi.GetMetadata().GetLabels())
+	wfis, err := client.Invocation.List(ctx, &apiserver.InvocationListQuery{
+		LabelSelector: "batch=42,workflow=" + wf.ID(),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{wfi.ID()}, wfis.Invocations)
+
+	// Labels that conflict with the label selector syntax are rejected.
+	spec.Labels = map[string]string{"batch": "a,b"}
+	_, err = client.Invocation.Invoke(ctx, spec)
+	assert.Error(t, err)
+}
+
+func TestInvocationErrorHandled(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wfSpec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "handler",
+		Tasks: types.Tasks{
+			"task1": {
+				FunctionRef: builtin.Fail,
+				Inputs:      types.Input("expected error"),
+				OnError:     "handler",
+			},
+			"task2": {
+				FunctionRef: builtin.Noop,
+				Requires:    types.Require("task1"),
+			},
+			"handler": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("recovered"),
+			},
+		},
+	}
+	wf, err := client.Workflow.CreateSync(ctx, wfSpec)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+	assert.NoError(t, err, err)
+	assert.NotNil(t, wf)
+
+	wiSpec := types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline())
+	wfi, err := client.Invocation.InvokeSync(ctx, wiSpec)
+	assert.NoError(t, err)
+	assert.True(t, wfi.Status.Successful())
+	assert.Equal(t, "recovered", typedvalues.MustUnwrap(wfi.Status.Output))
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, wfi.Status.Tasks["task1"].GetStatus().GetStatus())
+	assert.Equal(t, types.TaskInvocationStatus_SKIPPED, wfi.Status.Tasks["task2"].GetStatus().GetStatus())
+	assert.Equal(t, types.TaskInvocationStatus_SUCCEEDED, wfi.Status.Tasks["handler"].GetStatus().GetStatus())
+}
+
+func TestWorkflowRevisions(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+	name := "revisioned"
+
+	makeSpec := func(output string) *types.WorkflowSpec {
+		return &types.WorkflowSpec{
+			ApiVersion: types.WorkflowAPIVersion,
+			Name:       name,
+			OutputTask: "task",
+			Tasks: map[string]*types.TaskSpec{
+				"task": {
+					FunctionRef: builtin.Noop,
+					Inputs:      types.Input(output),
+				},
+			},
+		}
+	}
+
+	// Updating a workflow that does not exist fails.
+	_, err := client.Workflow.Update(ctx, makeSpec("v0"))
+	assert.Error(t, err)
+
+	wf1, err := client.Workflow.CreateSync(ctx, makeSpec("v1"))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, wf1.GetMetadata().GetRevision())
+	defer client.Workflow.Delete(ctx, wf1.GetMetadata())
+
+	md, err := client.Workflow.Update(ctx, makeSpec("v2"))
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, md.GetRevision())
+	assert.NotEqual(t, wf1.ID(), md.GetId())
+	defer client.Workflow.Delete(ctx, md)
+
+	// Each revision is a separate workflow, which can be retrieved by its id or by its name and revision.
+	wf, err := client.Workflow.Get(ctx, &types.ObjectMetadata{Id: name + "@1"})
+	assert.NoError(t, err)
+	assert.Equal(t, wf1.ID(), wf.ID())
+	wf, err = client.Workflow.Get(ctx, &types.ObjectMetadata{Id: name})
+	assert.NoError(t, err)
+	assert.Equal(t, md.GetId(), wf.ID())
+	_, err = client.Workflow.Get(ctx, &types.ObjectMetadata{Id: name + "@3"})
+	assert.Error(t, err)
+
+	// Invocations reference either a specific or the latest revision.
+	for ref, expected := range map[string]string{
+		name + "@1": "v1",
+		name + "@2": "v2",
+		name:        "v2",
+	} {
+		wfi, err := client.Invocation.InvokeSync(ctx, types.NewWorkflowInvocationSpec(ref, defaultDeadline()))
+		assert.NoError(t, err)
+		assert.True(t, wfi.GetStatus().Successful())
+		assert.Equal(t, expected, typedvalues.MustUnwrap(wfi.GetStatus().GetOutput()), ref)
+	}
+
+	history, err := client.Workflow.History(ctx, &types.ObjectMetadata{Name: name})
+	assert.NoError(t, err)
+	if assert.Len(t, history.GetRevisions(), 2) {
+		assert.Equal(t, wf1.ID(), history.GetRevisions()[0].ID())
+		assert.Equal(t, md.GetId(), history.GetRevisions()[1].ID())
+	}
+}
+
+func TestWorkflowRevisionsConcurrent(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+	spec := &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		Name:       "revisioned-concurrently",
+		OutputTask: "task",
+		Tasks: map[string]*types.TaskSpec{
+			"task": {
+				FunctionRef: builtin.Noop,
+			},
+		},
+	}
+
+	// Concurrently created revisions each receive a unique revision number.
+	n := 20
+	revisions := make([]int, n)
+	wg := sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			md, err := client.Workflow.Create(ctx, spec)
+			if assert.NoError(t, err) {
+				revisions[i] = int(md.GetRevision())
+			}
+		}(i)
+	}
+	wg.Wait()
+	sort.Ints(revisions)
+	for i, revision := range revisions {
+		assert.Equal(t, i+1, revision)
+	}
+}
+
+func TestTrigger(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wf, err := client.Workflow.CreateSync(ctx, &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "task",
+		Tasks: map[string]*types.TaskSpec{
+			"task": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("{$.Invocation.Inputs.default}"),
+			},
+		},
+	})
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+
+	// Triggers require a valid schedule and an existing workflow.
+	_, err = client.Trigger.Create(ctx, &types.TriggerSpec{WorkflowId: wf.ID(), Cron: "every second"})
+	assert.Error(t, err)
+	_, err = client.Trigger.Create(ctx, &types.TriggerSpec{WorkflowId: "nonExistent", Cron: "@every 1s"})
+	assert.Error(t, err)
+
+	md, err := client.Trigger.Create(ctx, &types.TriggerSpec{
+		WorkflowId: wf.ID(),
+		Cron:       "@every 1s",
+		Inputs:     types.Input("scheduled"),
+		Labels:     map[string]string{"schedule": "secondly"},
+	})
+	assert.NoError(t, err)
+	triggers, err := client.Trigger.List(ctx, &apiserver.TriggerListQuery{})
+	assert.NoError(t, err)
+	assert.Contains(t, triggers.GetTriggers(), md.GetId())
+	triggers, err = client.Trigger.List(ctx, &apiserver.TriggerListQuery{LabelSelector: "schedule=secondly"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{md.GetId()}, triggers.GetTriggers())
+
+	// Wait for the trigger to fire
+	var trigger *types.Trigger
+	for len(trigger.GetStatus().GetLastInvocationId()) == 0 {
+		select {
+		case <-ctx.Done():
+			assert.FailNow(t, "trigger did not fire")
+		case <-time.After(100 * time.Millisecond):
+		}
+		trigger, err = client.Trigger.Get(ctx, md)
+		assert.NoError(t, err)
+	}
+	assert.Nil(t, trigger.GetStatus().GetError())
+
+	wfi := awaitInvocation(ctx, t, client, &types.ObjectMetadata{Id: trigger.GetStatus().GetLastInvocationId()},
+		func(wfi *types.WorkflowInvocation) bool {
+			return wfi.GetStatus().Finished()
+		})
+	assert.Equal(t, md.GetId(), wfi.GetSpec().GetTriggerId())
+	assert.Equal(t, wf.ID(), wfi.GetSpec().GetWorkflowId())
+	assert.Equal(t, "secondly", wfi.GetMetadata().GetLabels()["schedule"])
+	assert.True(t, wfi.GetStatus().Successful())
+	assert.Equal(t, "scheduled", typedvalues.MustUnwrap(wfi.GetStatus().GetOutput()))
+
+	_, err = client.Trigger.Delete(ctx, md)
+	assert.NoError(t, err)
+	trigger, err = client.Trigger.Get(ctx, md)
+	assert.NoError(t, err)
+	assert.Equal(t, types.TriggerStatus_DELETED, trigger.GetStatus().GetStatus())
+	triggers, err = client.Trigger.List(ctx, &apiserver.TriggerListQuery{})
+	assert.NoError(t, err)
+	assert.NotContains(t, triggers.GetTriggers(), md.GetId())
+}
+
+func TestSignal(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wf, err := client.Workflow.CreateSync(ctx, &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "result",
+		Tasks: map[string]*types.TaskSpec{
+			"approval": {
+				FunctionRef: builtin.Wait,
+				// The payload of the signal is transformed like the output of any other task.
+				Output: typedvalues.MustWrap("{output('approval').toUpperCase()}"),
+			},
+			"parallel": {
+				FunctionRef: builtin.Noop,
+			},
+			"result": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("{output('approval')}"),
+				Requires:    types.Require("approval", "parallel"),
+			},
+		},
+	})
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+
+	md, err := client.Invocation.Invoke(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+
+	// Other tasks should not be blocked by the task that awaits the signal.
+	wfi := awaitInvocation(ctx, t, client, md, func(wfi *types.WorkflowInvocation) bool {
+		approval, _ := wfi.TaskInvocation("approval")
+		parallel, _ := wfi.TaskInvocation("parallel")
+		return approval.GetStatus().GetStatus() == types.TaskInvocationStatus_IN_PROGRESS &&
+			parallel.GetStatus().Successful()
+	})
+	assert.False(t, wfi.GetStatus().Finished())
+
+	// Only tasks that are awaiting a signal can be signaled.
+	_, err = client.Invocation.Signal(ctx, &apiserver.SignalRequest{InvocationID: md.GetId(), TaskID: "parallel"})
+	assert.Error(t, err)
+	_, err = client.Invocation.Signal(ctx, &apiserver.SignalRequest{InvocationID: md.GetId(), TaskID: "nonExistent"})
+	assert.Error(t, err)
+
+	_, err = client.Invocation.Signal(ctx, &apiserver.SignalRequest{
+		InvocationID: md.GetId(),
+		TaskID:       "approval",
+		Payload:      typedvalues.MustWrap("approved"),
+	})
+	assert.NoError(t, err)
+
+	wfi = awaitInvocation(ctx, t, client, md, func(wfi *types.WorkflowInvocation) bool {
+		return wfi.GetStatus().Finished()
+	})
+	assert.True(t, wfi.GetStatus().Successful())
+	assert.Equal(t, "APPROVED", typedvalues.MustUnwrap(wfi.GetStatus().GetOutput()))
+}
+
+func TestSignalTimeout(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wf, err := client.Workflow.CreateSync(ctx, &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "approval",
+		Tasks: map[string]*types.TaskSpec{
+			"approval": {
+				FunctionRef: builtin.Wait,
+				Timeout:     ptypes.DurationProto(time.Second),
+			},
+		},
+	})
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+
+	md, err := client.Invocation.Invoke(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+
+	wfi := awaitInvocation(ctx, t, client, md, func(wfi *types.WorkflowInvocation) bool {
+		return wfi.GetStatus().Finished()
+	})
+	assert.False(t, wfi.GetStatus().Successful())
+	approval, _ := wfi.TaskInvocation("approval")
+	assert.Equal(t, types.TaskInvocationStatus_FAILED, approval.GetStatus().GetStatus())
+}
+
+func TestConcurrencyPolicy(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	sleep := &types.TaskSpec{
+		FunctionRef: builtin.Sleep,
+		Inputs:      typedvalues.MustWrapMapTypedValue(map[string]interface{}{builtin.SleepInput: "200ms"}),
+	}
+	wf, err := client.Workflow.CreateSync(ctx, &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "done",
+		Concurrency: &types.ConcurrencyPolicy{
+			Max: 1,
+		},
+		Tasks: map[string]*types.TaskSpec{
+			"a": sleep,
+			"b": sleep,
+			"c": sleep,
+			"done": {
+				FunctionRef: builtin.Noop,
+				Requires: map[string]*types.TaskDependencyParameters{
+					"a": {},
+					"b": {},
+					"c": {},
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+
+	md, err := client.Invocation.Invoke(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+	wfi := awaitInvocation(ctx, t, client, md, func(wfi *types.WorkflowInvocation) bool {
+		return wfi.GetStatus().Finished()
+	})
+	assert.True(t, wfi.GetStatus().Successful())
+
+	// The sleeping tasks should have run one after the other.
+	type interval struct{ start, end time.Time }
+	var runs []interval
+	for _, taskID := range []string{"a", "b", "c"} {
+		taskRun, ok := wfi.TaskInvocation(taskID)
+		assert.True(t, ok)
+		start, _ := ptypes.Timestamp(taskRun.GetMetadata().GetCreatedAt())
+		end, _ := ptypes.Timestamp(taskRun.GetStatus().GetUpdatedAt())
+		runs = append(runs, interval{start, end})
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].start.Before(runs[j].start) })
+	for i := 1; i < len(runs); i++ {
+		assert.False(t, runs[i].start.Before(runs[i-1].end), "tasks ran concurrently: %v", runs)
+	}
+}
+
+func TestWorkflowSchemas(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wf, err := client.Workflow.CreateSync(ctx, &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "echo",
+		Inputs: &types.TypeSchema{
+			Type:     validate.SchemaTypeObject,
+			Required: []string{"name"},
+			Properties: map[string]*types.TypeSchema{
+				"name": {Type: validate.SchemaTypeString},
+			},
+		},
+		Output: &types.TypeSchema{Type: validate.SchemaTypeInteger},
+		Tasks: map[string]*types.TaskSpec{
+			"echo": {
+				FunctionRef: builtin.Noop,
+				Inputs: map[string]*typedvalues.TypedValue{
+					types.InputMain: typedvalues.MustWrap("{$.Invocation.Inputs.name}"),
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+
+	// Invocations with inputs that do not match the schema are rejected.
+	wiSpec := types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline())
+	wiSpec.Inputs = typedvalues.MustWrapMapTypedValue(map[string]interface{}{
+		"name": 42,
+	})
+	_, err = client.Invocation.Invoke(ctx, wiSpec)
+	assert.Error(t, err)
+
+	// The output of the invocation should match the output schema.
+	wiSpec.Inputs = typedvalues.MustWrapMapTypedValue(map[string]interface{}{
+		"name": "world",
+	})
+	wfi, err := client.Invocation.InvokeSync(ctx, wiSpec)
+	assert.NoError(t, err)
+	assert.True(t, wfi.GetStatus().Finished())
+	assert.False(t, wfi.GetStatus().Successful())
+	assert.Contains(t, wfi.GetStatus().GetError().GetMessage(), validate.ErrSchemaTypeMismatch.Error())
+}
+
 func TestInvocationWithForcedOutputs(t *testing.T) {
 	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
 	defer cancelFn()
@@ -704,6 +1520,22 @@ func TestMassivelyParallelInvocation(t *testing.T) {
 	assert.True(t, wfi.Status.Successful())
 }
 
+// awaitInvocation polls the invocation until it satisfies the condition or the context is done.
+func awaitInvocation(ctx context.Context, t *testing.T, client *apiserver.Client, md *types.ObjectMetadata,
+	condition func(wfi *types.WorkflowInvocation) bool) *types.WorkflowInvocation {
+	for {
+		wfi, err := client.Invocation.Get(ctx, md)
+		if err == nil && condition(wfi) {
+			return wfi
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("invocation %s did not reach the expected state: %v", md.GetId(), ctx.Err())
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
 func setup(ctx context.Context) *apiserver.Client {
 	conn, err := grpc.Dial(gRPCAddress, grpc.WithInsecure())
 	if err != nil {
@@ -716,3 +1548,117 @@ func setup(ctx context.Context) *apiserver.Client {
 	}
 	return c
 }
+
+func TestWorkflowInvalidTaskReference(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	// The reference to the misspelled task is detected before the workflow is stored.
+	_, err := client.Workflow.CreateSync(ctx, &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "second",
+		Tasks: map[string]*types.TaskSpec{
+			"first": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("hello"),
+			},
+			"second": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("{ output('frist') }"),
+				Requires:    types.Require("first"),
+			},
+		},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), validate.ErrUndefinedTaskReference.Error())
+}
+
+func TestWorkflowGraph(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+	client := setup(ctx)
+
+	wf, err := client.Workflow.CreateSync(ctx, &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "second",
+		Tasks: map[string]*types.TaskSpec{
+			"first": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("hello"),
+			},
+			"second": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("{ output('first') }"),
+				Requires:    types.Require("first"),
+			},
+		},
+	})
+	assert.NoError(t, err)
+	defer client.Workflow.Delete(ctx, wf.GetMetadata())
+
+	wfGraph, err := client.Workflow.Graph(ctx, &apiserver.GraphRequest{Id: wf.ID()})
+	assert.NoError(t, err)
+	assert.Equal(t, "dot", wfGraph.GetFormat())
+	assert.Contains(t, wfGraph.GetContent(), "\"first\" -> \"second\";")
+
+	_, err = client.Workflow.Graph(ctx, &apiserver.GraphRequest{Id: wf.ID(), Format: "png"})
+	assert.Error(t, err)
+
+	wfi, err := client.Invocation.InvokeSync(ctx, types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline()))
+	assert.NoError(t, err)
+
+	// The HTTP API renders the same graph, in which the nodes are colored by the status of the tasks.
+	httpClient := httpclient.NewInvocationAPI(httpAddress, http.Client{})
+	wfiGraph, err := httpClient.Graph(ctx, wfi.ID(), "mermaid")
+	assert.NoError(t, err)
+	assert.Contains(t, wfiGraph.GetContent(), "t_first --> t_second")
+	assert.Contains(t, wfiGraph.GetContent(), "class t_second succeeded")
+}
+
+func TestLocalEngine(t *testing.T) {
+	ctx, cancelFn := context.WithTimeout(context.Background(), testTimeout)
+	defer cancelFn()
+
+	runtime := mock.NewRuntime()
+	runtime.Functions["greet"] = func(spec *types.TaskInvocationSpec) (*typedvalues.TypedValue, error) {
+		name, err := typedvalues.UnwrapString(spec.GetInputs()[types.InputMain])
+		if err != nil {
+			return nil, err
+		}
+		return typedvalues.MustWrap("Hello, " + name), nil
+	}
+	resolver := mock.NewResolver()
+	resolver.FnNameIDs["greet"] = "greet"
+	engine := bundle.NewLocalEngine(&bundle.LocalOptions{
+		Runtimes:  map[string]fnenv.Runtime{"mock": runtime},
+		Resolvers: map[string]fnenv.RuntimeResolver{"mock": resolver},
+	})
+	defer engine.Close()
+
+	// The local engine combines the mocked function with the internal functions.
+	wf, err := engine.Workflows.CreateSync(ctx, &types.WorkflowSpec{
+		ApiVersion: types.WorkflowAPIVersion,
+		OutputTask: "second",
+		Tasks: map[string]*types.TaskSpec{
+			"first": {
+				FunctionRef: "greet",
+				Inputs:      types.Input("{ $.Invocation.Inputs.default }"),
+			},
+			"second": {
+				FunctionRef: builtin.Noop,
+				Inputs:      types.Input("{ output('first') }"),
+				Requires:    types.Require("first"),
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	spec := types.NewWorkflowInvocationSpec(wf.ID(), defaultDeadline())
+	spec.Inputs = types.Input("world")
+	wfi, err := engine.Invocations.InvokeSync(ctx, spec)
+	assert.NoError(t, err)
+	assert.True(t, wfi.GetStatus().Successful())
+	assert.Equal(t, "Hello, world", typedvalues.MustUnwrap(wfi.GetStatus().GetOutput()))
+	assert.Len(t, wfi.GetStatus().GetTasks(), 2)
+}
@@ -21,8 +21,10 @@ func SetupBundle(ctx context.Context, opts ...bundle.Options) bundle.Options {
 			InternalRuntime:      true,
 			InvocationController: true,
 			WorkflowController:   true,
+			TriggerController:    true,
 			HTTPGateway:          true,
 			InvocationAPI:        true,
+			TriggerAPI:           true,
 			WorkflowAPI:          true,
 			AdminAPI:             true,
 			Metrics:              true,